@@ -0,0 +1,36 @@
+package controller
+
+import (
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+)
+
+type adminController struct {
+	dataEnrichmentService service.DataEnrichmentService
+}
+
+type enrichmentStatusResponse struct {
+	Providers []service.ProviderStatus `json:"providers"`
+}
+
+func NewAdminController(dataEnrichmentService service.DataEnrichmentService) *adminController {
+	log.Info("Creating new admin controller instance")
+	return &adminController{
+		dataEnrichmentService: dataEnrichmentService,
+	}
+}
+
+// GetEnrichmentStatus godoc
+// @Summary Get enrichment providers status
+// @Description Show the circuit breaker state of every configured enrichment provider
+// @Tags Admin
+// @Produce json
+// @Success 200 {object} enrichmentStatusResponse
+// @Router /admin/enrichment/status [get]
+func (ac *adminController) GetEnrichmentStatus(ctx *fiber.Ctx) error {
+	log.Info("Fetching enrichment providers status")
+	return ctx.JSON(enrichmentStatusResponse{
+		Providers: ac.dataEnrichmentService.ProviderStatuses(),
+	})
+}
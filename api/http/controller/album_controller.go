@@ -0,0 +1,202 @@
+package controller
+
+import (
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+type albumController struct {
+	albumService models.AlbumService
+	timeout      time.Duration
+}
+
+func NewAlbumController(albumService models.AlbumService, timeout time.Duration) *albumController {
+	log.Info("Creating new album controller instance")
+	return &albumController{
+		albumService: albumService,
+		timeout:      timeout,
+	}
+}
+
+// CreateAlbum godoc
+// @Summary Create album
+// @Description Create a new album for an artist given by artist_id or group_name
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Param album body models.Album true "Album"
+// @Success 201 {object} models.Album
+// @Failure 400 {object} controller.Problem "Invalid request body"
+// @Failure 409 {object} controller.Problem "Album already exists"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /albums [post]
+func (ac *albumController) CreateAlbum(ctx *fiber.Ctx) error {
+	log.Info("Creating new album")
+	req := new(models.Album)
+
+	if err := ctx.BodyParser(req); err != nil {
+		log.Warnf("Failed to parse request body: %v", err)
+		return invalidBody(err)
+	}
+
+	reqCtx, cancel := requestContext(ctx, ac.timeout)
+	defer cancel()
+
+	album, err := ac.albumService.CreateAlbum(reqCtx, req)
+	if err != nil {
+		log.Errorf("Failed to create album: %v", err)
+		return err
+	}
+
+	log.Infof("Album created successfully with ID %s", album.ID)
+	return ctx.Status(fiber.StatusCreated).JSON(album)
+}
+
+// GetAlbums godoc
+// @Summary Get list of albums
+// @Description Retrieve albums ordered by release date
+// @Tags Albums
+// @Produce json
+// @Param title query string false "Part of the album title"
+// @Param artist_id query string false "ID of the artist"
+// @Param page query int false "Page number, 1 by default"
+// @Param page_size query int false "Number of records per page, 20 by default and at most 100"
+// @Success 200 {object} models.Page[models.Album]
+// @Header 200 {string} Link "Links to the next and previous pages"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /albums [get]
+func (ac *albumController) GetAlbums(ctx *fiber.Ctx) error {
+	log.Info("Fetching album list")
+	filters := models.AlbumFilters{}
+
+	title := ctx.Query("title")
+	if title != "" {
+		log.Debugf("Received title filter: %s", title)
+		filters.Title = &title
+	}
+
+	artistID := ctx.Query("artist_id")
+	if artistID != "" {
+		log.Debugf("Received artist ID filter: %s", artistID)
+		filters.ArtistID = &artistID
+	}
+
+	page := ctx.QueryInt("page")
+	pageSize := ctx.QueryInt("page_size")
+	log.Debugf("Pagination info: page %d, page_size %d", page, pageSize)
+
+	reqCtx, cancel := requestContext(ctx, ac.timeout)
+	defer cancel()
+
+	albums, err := ac.albumService.GetAlbums(reqCtx, filters, page, pageSize)
+	if err != nil {
+		log.Errorf("Failed to get album list: %v", err)
+		return err
+	}
+
+	log.Info("Successfully fetched album list")
+	return sendPage(ctx, albums)
+}
+
+// GetAlbum godoc
+// @Summary Get album
+// @Description Retrieve an album with its track listing
+// @Tags Albums
+// @Produce json
+// @Param id path string true "Album ID"
+// @Success 200 {object} models.Album
+// @Failure 404 {object} controller.Problem "Album not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /albums/{id} [get]
+func (ac *albumController) GetAlbum(ctx *fiber.Ctx) error {
+	albumID := ctx.Params("id")
+	log.Infof("Fetching album with ID: %s", albumID)
+
+	reqCtx, cancel := requestContext(ctx, ac.timeout)
+	defer cancel()
+
+	album, err := ac.albumService.GetAlbum(reqCtx, albumID)
+	if err != nil {
+		log.Errorf("Failed to get album %s: %v", albumID, err)
+		return err
+	}
+	if album == nil {
+		log.Warnf("Album %s not found", albumID)
+		return models.ErrAlbumNotFound
+	}
+
+	return ctx.JSON(album)
+}
+
+// GetTracks godoc
+// @Summary Get album tracks
+// @Description Retrieve the track listing of an album ordered by disc and track number
+// @Tags Albums
+// @Produce json
+// @Param id path string true "Album ID"
+// @Success 200 {array} models.AlbumTrack
+// @Failure 404 {object} controller.Problem "Album not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /albums/{id}/tracks [get]
+func (ac *albumController) GetTracks(ctx *fiber.Ctx) error {
+	albumID := ctx.Params("id")
+	log.Infof("Fetching tracks of album %s", albumID)
+
+	reqCtx, cancel := requestContext(ctx, ac.timeout)
+	defer cancel()
+
+	tracks, err := ac.albumService.GetTracks(reqCtx, albumID)
+	if err != nil {
+		log.Errorf("Failed to get tracks of album %s: %v", albumID, err)
+		return err
+	}
+	if tracks == nil {
+		log.Warnf("Album %s not found", albumID)
+		return models.ErrAlbumNotFound
+	}
+
+	return ctx.JSON(tracks)
+}
+
+// AddTrack godoc
+// @Summary Add track to album
+// @Description Put a song on an album at the given disc and track number, or move it there if it is already on the album
+// @Tags Albums
+// @Accept json
+// @Produce json
+// @Param id path string true "Album ID"
+// @Param track body models.AlbumTrack true "Track"
+// @Success 200 {object} models.AlbumTrack
+// @Failure 400 {object} controller.Problem "Invalid request body"
+// @Failure 404 {object} controller.Problem "Album or music not found"
+// @Failure 409 {object} controller.Problem "Position is already taken"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /albums/{id}/tracks [post]
+func (ac *albumController) AddTrack(ctx *fiber.Ctx) error {
+	albumID := ctx.Params("id")
+	log.Infof("Adding track to album %s", albumID)
+
+	req := new(models.AlbumTrack)
+	if err := ctx.BodyParser(req); err != nil {
+		log.Warnf("Failed to parse request body: %v", err)
+		return invalidBody(err)
+	}
+
+	reqCtx, cancel := requestContext(ctx, ac.timeout)
+	defer cancel()
+
+	track, err := ac.albumService.AddTrack(reqCtx, albumID, *req)
+	if err != nil {
+		log.Errorf("Failed to add track to album %s: %v", albumID, err)
+		return err
+	}
+	if track == nil {
+		log.Warnf("Album %s or music %s not found", albumID, req.MusicID)
+		return models.NewError(models.ErrNotFound, "album or music not found")
+	}
+
+	log.Infof("Music %s added to album %s", track.MusicID, albumID)
+	return ctx.JSON(track)
+}
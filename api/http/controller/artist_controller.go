@@ -0,0 +1,197 @@
+package controller
+
+import (
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+type artistController struct {
+	artistService models.ArtistService
+	timeout       time.Duration
+}
+
+func NewArtistController(artistService models.ArtistService, timeout time.Duration) *artistController {
+	log.Info("Creating new artist controller instance")
+	return &artistController{
+		artistService: artistService,
+		timeout:       timeout,
+	}
+}
+
+// CreateArtist godoc
+// @Summary Create artist
+// @Description Create a new artist. Names are unique regardless of case.
+// @Tags Artists
+// @Accept json
+// @Produce json
+// @Param artist body models.Artist true "Artist"
+// @Success 201 {object} models.Artist
+// @Failure 400 {object} controller.Problem "Invalid request body"
+// @Failure 409 {object} controller.Problem "Artist already exists"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /artists [post]
+func (ac *artistController) CreateArtist(ctx *fiber.Ctx) error {
+	log.Info("Creating new artist")
+	req := new(models.Artist)
+
+	if err := ctx.BodyParser(req); err != nil {
+		log.Warnf("Failed to parse request body: %v", err)
+		return invalidBody(err)
+	}
+
+	reqCtx, cancel := requestContext(ctx, ac.timeout)
+	defer cancel()
+
+	artist, err := ac.artistService.CreateArtist(reqCtx, req)
+	if err != nil {
+		log.Errorf("Failed to create artist: %v", err)
+		return err
+	}
+
+	log.Infof("Artist created successfully with ID %s", artist.ID)
+	return ctx.Status(fiber.StatusCreated).JSON(artist)
+}
+
+// GetArtists godoc
+// @Summary Get list of artists
+// @Description Retrieve artists ordered by name
+// @Tags Artists
+// @Produce json
+// @Param name query string false "Part of the artist name"
+// @Param country query string false "Country"
+// @Param page query int false "Page number, 1 by default"
+// @Param page_size query int false "Number of records per page, 20 by default and at most 100"
+// @Success 200 {object} models.Page[models.Artist]
+// @Header 200 {string} Link "Links to the next and previous pages"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /artists [get]
+func (ac *artistController) GetArtists(ctx *fiber.Ctx) error {
+	log.Info("Fetching artist list")
+	filters := models.ArtistFilters{}
+
+	name := ctx.Query("name")
+	if name != "" {
+		log.Debugf("Received name filter: %s", name)
+		filters.Name = &name
+	}
+
+	country := ctx.Query("country")
+	if country != "" {
+		log.Debugf("Received country filter: %s", country)
+		filters.Country = &country
+	}
+
+	page := ctx.QueryInt("page")
+	pageSize := ctx.QueryInt("page_size")
+	log.Debugf("Pagination info: page %d, page_size %d", page, pageSize)
+
+	reqCtx, cancel := requestContext(ctx, ac.timeout)
+	defer cancel()
+
+	artists, err := ac.artistService.GetArtists(reqCtx, filters, page, pageSize)
+	if err != nil {
+		log.Errorf("Failed to get artist list: %v", err)
+		return err
+	}
+
+	log.Info("Successfully fetched artist list")
+	return sendPage(ctx, artists)
+}
+
+// GetArtist godoc
+// @Summary Get artist
+// @Description Retrieve an artist by ID
+// @Tags Artists
+// @Produce json
+// @Param id path string true "Artist ID"
+// @Success 200 {object} models.Artist
+// @Failure 404 {object} controller.Problem "Artist not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /artists/{id} [get]
+func (ac *artistController) GetArtist(ctx *fiber.Ctx) error {
+	artistID := ctx.Params("id")
+	log.Infof("Fetching artist with ID: %s", artistID)
+
+	reqCtx, cancel := requestContext(ctx, ac.timeout)
+	defer cancel()
+
+	artist, err := ac.artistService.GetArtist(reqCtx, artistID)
+	if err != nil {
+		log.Errorf("Failed to get artist %s: %v", artistID, err)
+		return err
+	}
+	if artist == nil {
+		log.Warnf("Artist %s not found", artistID)
+		return models.ErrArtistNotFound
+	}
+
+	return ctx.JSON(artist)
+}
+
+// UpdateArtist godoc
+// @Summary Update artist
+// @Description Update an existing artist. Renaming an artist renames it for all of its music.
+// @Tags Artists
+// @Accept json
+// @Produce json
+// @Param id path string true "Artist ID"
+// @Param artist body models.Artist true "Updated artist fields"
+// @Success 200 {object} models.Artist
+// @Failure 400 {object} controller.Problem "Invalid request body"
+// @Failure 404 {object} controller.Problem "Artist not found"
+// @Failure 409 {object} controller.Problem "Artist with this name already exists"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /artists/{id} [put]
+func (ac *artistController) UpdateArtist(ctx *fiber.Ctx) error {
+	req := new(models.Artist)
+	if err := ctx.BodyParser(req); err != nil {
+		log.Warnf("Failed to parse request body: %v", err)
+		return invalidBody(err)
+	}
+	req.ID = ctx.Params("id")
+	log.Infof("Updating artist with ID: %s", req.ID)
+
+	reqCtx, cancel := requestContext(ctx, ac.timeout)
+	defer cancel()
+
+	artist, err := ac.artistService.UpdateArtist(reqCtx, *req)
+	if err != nil {
+		log.Errorf("Failed to update artist %s: %v", req.ID, err)
+		return err
+	}
+	if artist == nil {
+		log.Warnf("Artist %s not found", req.ID)
+		return models.ErrArtistNotFound
+	}
+
+	log.Infof("Artist with ID %s updated successfully", req.ID)
+	return ctx.JSON(artist)
+}
+
+// DeleteArtist godoc
+// @Summary Delete artist
+// @Description Delete an artist by ID. Artists that still have music cannot be deleted.
+// @Tags Artists
+// @Param id path string true "Artist ID"
+// @Success 200 {string} string "Artist deleted successfully"
+// @Failure 409 {object} controller.Problem "Artist still has music"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /artists/{id} [delete]
+func (ac *artistController) DeleteArtist(ctx *fiber.Ctx) error {
+	artistID := ctx.Params("id")
+	log.Infof("Deleting artist with ID: %s", artistID)
+
+	reqCtx, cancel := requestContext(ctx, ac.timeout)
+	defer cancel()
+
+	err := ac.artistService.DeleteArtist(reqCtx, artistID)
+	if err != nil {
+		log.Errorf("Failed to delete artist %s: %v", artistID, err)
+		return err
+	}
+
+	log.Infof("Artist with ID %s deleted successfully", artistID)
+	return ctx.SendString("Artist deleted successfully")
+}
@@ -0,0 +1,95 @@
+package controller
+
+import (
+	"context"
+	"errors"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+)
+
+// problemContentType is the media type of error responses, see RFC 7807.
+const problemContentType = "application/problem+json"
+
+// Problem is the body of every error response.
+type Problem struct {
+	Type     string              `json:"type" example:"about:blank"`
+	Title    string              `json:"title" example:"Bad Request"`
+	Status   int                 `json:"status" example:"400"`
+	Detail   string              `json:"detail,omitempty" example:"music song name is required"`
+	Instance string              `json:"instance,omitempty" example:"/music"`
+	Errors   []models.FieldError `json:"errors,omitempty"`
+	// Songs similar to the one being saved, if it was refused as a possible duplicate
+	Candidates []models.SimilarMusic `json:"candidates,omitempty"`
+}
+
+var errPreconditionRequired = errors.New("If-Match header with the ETag of the music is required")
+
+// invalidBody reports a request body that cannot be parsed.
+func invalidBody(err error) error {
+	return models.NewValidationError("body", err.Error())
+}
+
+// invalidQuery reports query parameters that cannot be parsed, e.g. a year that is not a number.
+func invalidQuery(err error) error {
+	return models.NewValidationError("query", err.Error())
+}
+
+// errorStatus maps an error to the status code of its response. Errors of conditional requests and
+// patches have their own codes; other domain errors are mapped by their kind.
+func errorStatus(err error) int {
+	var fiberErr *fiber.Error
+	switch {
+	case errors.As(err, &fiberErr):
+		return fiberErr.Code
+	case errors.Is(err, errPreconditionRequired):
+		return fiber.StatusPreconditionRequired
+	case errors.Is(err, models.ErrVersionMismatch):
+		return fiber.StatusPreconditionFailed
+	case errors.Is(err, models.ErrUnsupportedPatch):
+		return fiber.StatusUnsupportedMediaType
+	case errors.Is(err, models.ErrInvalidPatch):
+		return fiber.StatusUnprocessableEntity
+	case errors.Is(err, context.DeadlineExceeded):
+		return fiber.StatusGatewayTimeout
+	case errors.Is(err, models.ErrValidation):
+		return fiber.StatusBadRequest
+	case errors.Is(err, models.ErrNotFound):
+		return fiber.StatusNotFound
+	case errors.Is(err, models.ErrConflict):
+		return fiber.StatusConflict
+	case errors.Is(err, models.ErrUpstream):
+		return fiber.StatusBadGateway
+	default:
+		return fiber.StatusInternalServerError
+	}
+}
+
+// ErrorHandler sends the errors returned by handlers as problem details. The details of internal
+// errors are only logged.
+func ErrorHandler(ctx *fiber.Ctx, err error) error {
+	status := errorStatus(err)
+	problem := Problem{
+		Type:     "about:blank",
+		Title:    http.StatusText(status),
+		Status:   status,
+		Detail:   err.Error(),
+		Instance: ctx.OriginalURL(),
+	}
+	if status == fiber.StatusInternalServerError {
+		log.Errorf("Internal error handling %s %s: %v", ctx.Method(), ctx.Path(), err)
+		problem.Detail = ""
+	}
+
+	var validation *models.ValidationError
+	if errors.As(err, &validation) {
+		problem.Errors = validation.Fields
+	}
+	var duplicates *models.DuplicatesError
+	if errors.As(err, &duplicates) {
+		problem.Candidates = duplicates.Candidates
+	}
+
+	return ctx.Status(status).JSON(problem, problemContentType)
+}
@@ -1,17 +1,24 @@
 package controller
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
 	"github.com/gofiber/fiber/v2"
 	log "github.com/sirupsen/logrus"
-	"net/http"
+	"reflect"
+	"strconv"
+	"strings"
 	"time"
 )
 
 type musicController struct {
 	musicService models.MusicService
+	jobService   models.JobService
+	timeout      time.Duration
 }
 
 func parseTime(t string) (*time.Time, error) {
@@ -36,110 +43,563 @@ func parseTime(t string) (*time.Time, error) {
 	return &tm, nil
 }
 
-func NewMusicController(musicService models.MusicService) *musicController {
+// authorHeader names the user making a change, which is recorded in the revision history.
+const authorHeader = "X-User"
+
+// requestContext bounds the work done on behalf of a request, including upstream enrichment calls.
+func requestContext(ctx *fiber.Ctx, timeout time.Duration) (context.Context, context.CancelFunc) {
+	reqCtx := context.Context(ctx.Context())
+	if author := strings.TrimSpace(ctx.Get(authorHeader)); author != "" {
+		reqCtx = models.ContextWithAuthor(reqCtx, author)
+	}
+	if timeout <= 0 {
+		return context.WithCancel(reqCtx)
+	}
+	return context.WithTimeout(reqCtx, timeout)
+}
+
+func (mc *musicController) requestContext(ctx *fiber.Ctx) (context.Context, context.CancelFunc) {
+	return requestContext(ctx, mc.timeout)
+}
+
+// ifMatchVersion returns the version of the song from the If-Match header, which every change of a song
+// is made conditional on, so a missing header is an error. "*" matches any version and gives nil.
+func ifMatchVersion(ctx *fiber.Ctx) (*int, error) {
+	ifMatch := strings.TrimSpace(ctx.Get(fiber.HeaderIfMatch))
+	if ifMatch == "" {
+		return nil, errPreconditionRequired
+	}
+	if ifMatch == "*" {
+		return nil, nil
+	}
+
+	// If-Match uses strong comparison, so a weak or malformed ETag never matches
+	version, err := strconv.Atoi(strings.Trim(ifMatch, `"`))
+	if err != nil || !strings.HasPrefix(ifMatch, `"`) || !strings.HasSuffix(ifMatch, `"`) {
+		return nil, models.ErrVersionMismatch
+	}
+	return &version, nil
+}
+
+// setETag sends the version of a song as its ETag.
+func setETag(ctx *fiber.Ctx, music *models.Music) {
+	if music != nil && music.Version > 0 {
+		ctx.Set(fiber.HeaderETag, `"`+strconv.Itoa(music.Version)+`"`)
+	}
+}
+
+// musicFields are the JSON names of the fields of a song that can be selected with fields=.
+var musicFields = jsonFieldNames(reflect.TypeOf(models.Music{}))
+
+func jsonFieldNames(t reflect.Type) map[string]bool {
+	names := map[string]bool{}
+	for i := 0; i < t.NumField(); i++ {
+		name := strings.Split(t.Field(i).Tag.Get("json"), ",")[0]
+		if name != "" && name != "-" {
+			names[name] = true
+		}
+	}
+	return names
+}
+
+// queryList returns the values of a query parameter that is repeated or separated by commas.
+func queryList(ctx *fiber.Ctx, name string) []string {
+	var values []string
+	for _, param := range ctx.Context().QueryArgs().PeekMulti(name) {
+		for _, value := range strings.Split(string(param), ",") {
+			if value = strings.TrimSpace(value); value != "" {
+				values = append(values, value)
+			}
+		}
+	}
+	return values
+}
+
+// musicFilters turns validated query parameters of the music list into its filters.
+func musicFilters(params models.MusicListParams) models.MusicFilters {
+	optional := func(value string) *string {
+		if value == "" {
+			return nil
+		}
+		return &value
+	}
+	filters := models.MusicFilters{
+		Link:      optional(params.Link),
+		SongName:  optional(params.SongName),
+		GroupName: optional(params.GroupName),
+		ArtistID:  optional(params.ArtistID),
+		Genre:     optional(params.Genre),
+		Tags:      params.Tags,
+		TagMode:   params.TagMode,
+		Year:      params.Year,
+	}
+
+	// Форматы уже проверены при валидации, поэтому ошибки разбора здесь не возникают
+	if params.ReleaseDate != "" {
+		filters.ReleaseDate, _ = parseTime(params.ReleaseDate)
+	}
+	if params.ReleaseDateFrom != "" {
+		from, _, _ := models.ParseReleaseDate(params.ReleaseDateFrom)
+		filters.ReleaseDateFrom = &from
+	}
+	if params.ReleaseDateTo != "" {
+		to, precision, _ := models.ParseReleaseDate(params.ReleaseDateTo)
+		// Конец диапазона включает весь указанный год или месяц
+		to = models.ReleaseDateEnd(to, precision)
+		filters.ReleaseDateTo = &to
+	}
+	if params.Decade != "" {
+		decade, _ := service.ParseDecade(params.Decade)
+		filters.Decade = &decade
+	}
+	filters.Sort, _ = service.ParseSort(params.Sort)
+	return filters
+}
+
+// selectFields returns only the given fields of music, and always its id.
+func selectFields(music *models.Music, fields []string) (map[string]json.RawMessage, error) {
+	encoded, err := json.Marshal(music)
+	if err != nil {
+		return nil, err
+	}
+	all := map[string]json.RawMessage{}
+	if err := json.Unmarshal(encoded, &all); err != nil {
+		return nil, err
+	}
+
+	selected := map[string]json.RawMessage{"id": all["id"]}
+	for _, field := range fields {
+		if value, ok := all[field]; ok {
+			selected[field] = value
+		}
+	}
+	return selected, nil
+}
+
+// wantsAsync reports whether the client asked for POST /music to be processed in the background.
+func wantsAsync(ctx *fiber.Ctx) bool {
+	if ctx.QueryBool("async") {
+		return true
+	}
+	return strings.Contains(ctx.Get("Prefer"), "respond-async")
+}
+
+func NewMusicController(musicService models.MusicService, jobService models.JobService, timeout time.Duration) *musicController {
 	log.Info("Creating new music controller instance")
 	return &musicController{
 		musicService: musicService,
+		jobService:   jobService,
+		timeout:      timeout,
 	}
 }
 
+// GetMusicList godoc
+// @Summary Get list of music
+// @Description Retrieve a list of music based on provided filters. With the cursor parameter the list is paginated by cursors instead of pages and a models.CursorPage is returned; pass the returned next_cursor to get the next page.
+// @Tags Music
+// @Produce json
+// @Param params query models.MusicListParams false "Filters, sorting and pagination"
+// @Success 200 {object} models.Page[models.Music]
+// @Header 200 {string} Link "Links to the next and previous pages"
+// @Failure 400 {object} controller.Problem "Invalid query parameters"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/info [get]
 func (mc *musicController) GetMusicList(ctx *fiber.Ctx) error {
 	log.Info("Fetching music list")
-	filters := models.MusicFilters{}
 
-	releaseDateStr := ctx.Query("release_date")
-	if releaseDateStr != "" {
-		log.Debugf("Received release date filter: %s", releaseDateStr)
-		releaseDate, err := parseTime(releaseDateStr)
+	params := new(models.MusicListParams)
+	if err := ctx.QueryParser(params); err != nil {
+		log.Warnf("Failed to parse query parameters: %v", err)
+		return invalidQuery(err)
+	}
+	params.Tags = queryList(ctx, "tag")
+	if err := service.ValidateRequest(params); err != nil {
+		log.Warnf("Invalid query parameters: %v", err)
+		return err
+	}
+	log.Debugf("Received music list parameters: %+v", *params)
+	filters := musicFilters(*params)
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	if cursorMode(ctx) {
+		if ctx.Query("page") != "" {
+			log.Warn("Both page and cursor are given")
+			return models.NewValidationError("page", "page cannot be combined with cursor")
+		}
+		musicList, err := mc.musicService.GetMusicsByCursor(reqCtx, filters, params.Cursor, params.PageSize)
 		if err != nil {
-			log.Warnf("Invalid release date format: %v", err)
-			return ctx.Status(fiber.StatusBadRequest).SendString(err.Error())
+			log.Errorf("Failed to get music list: %v", err)
+			return err
 		}
-		filters.ReleaseDate = releaseDate
-	}
 
-	link := ctx.Query("link")
-	if link != "" {
-		log.Debugf("Received link filter: %s", link)
-		filters.Link = &link
+		log.Info("Successfully fetched music list")
+		return sendCursorPage(ctx, musicList)
 	}
 
-	songName := ctx.Query("song_name")
-	if songName != "" {
-		log.Debugf("Received song name filter: %s", songName)
-		filters.SongName = &songName
+	musicList, err := mc.musicService.GetMusicsByFilters(reqCtx, filters, params.Page, params.PageSize)
+	if err != nil {
+		log.Errorf("Failed to get music list: %v", err)
+		return err
 	}
 
-	groupName := ctx.Query("group_name")
-	if groupName != "" {
-		log.Debugf("Received group name filter: %s", groupName)
-		filters.GroupName = &groupName
+	log.Info("Successfully fetched music list")
+	return sendPage(ctx, musicList)
+}
+
+// SearchMusic godoc
+// @Summary Search music by lyrics
+// @Description Full-text search over song lyrics. Songs are ranked by relevance and returned with the matching verses, matched words wrapped in <b> tags.
+// @Tags Music
+// @Produce json
+// @Param params query models.SearchParams false "Search query and pagination"
+// @Success 200 {object} models.Page[models.SearchResult]
+// @Header 200 {string} Link "Links to the next and previous pages"
+// @Failure 400 {object} controller.Problem "Invalid query parameters"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/search [get]
+func (mc *musicController) SearchMusic(ctx *fiber.Ctx) error {
+	params := new(models.SearchParams)
+	if err := ctx.QueryParser(params); err != nil {
+		log.Warnf("Failed to parse query parameters: %v", err)
+		return invalidQuery(err)
+	}
+	log.Infof("Searching music by lyrics: %s", params.Query)
+	if err := service.ValidateRequest(params); err != nil {
+		log.Warnf("Invalid query parameters: %v", err)
+		return err
 	}
+	log.Debugf("Pagination info: page %d, page_size %d", params.Page, params.PageSize)
 
-	page := ctx.QueryInt("page")
-	pageSize := ctx.QueryInt("page_size")
-	log.Debugf("Pagination info: page %d, page_size %d", page, pageSize)
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
 
-	musicList, err := mc.musicService.GetMusicsByFilters(ctx.Context(), filters, page, pageSize)
+	res, err := mc.musicService.SearchMusic(reqCtx, params.Query, params.Page, params.PageSize)
 	if err != nil {
-		log.Errorf("Failed to get music list: %v", err)
-		return ctx.Status(fiber.StatusInternalServerError).SendString(fmt.Sprintf("error: %v", err))
+		log.Errorf("Failed to search music: %v", err)
+		return err
 	}
 
-	log.Info("Successfully fetched music list")
-	return ctx.JSON(musicList)
+	log.Infof("Successfully searched music, found %d songs", res.Total)
+	return sendPage(ctx, res)
 }
 
+// SaveMusic godoc
+// @Summary Save new music
+// @Description Save a new music record. With async=true (or "Prefer: respond-async") the song is enriched in the background and a job is returned instead.
+// @Tags Music
+// @Accept json
+// @Produce json
+// @Param music body models.MusicQuery true "Music input"
+// @Param async query bool false "Enqueue the song for background enrichment"
+// @Param force query bool false "Save the song even if similar songs already exist"
+// @Success 200 {object} models.Music
+// @Success 202 {object} models.EnrichmentJob
+// @Failure 400 {object} controller.Problem "Invalid request body"
+// @Failure 409 {object} controller.Problem "Similar songs already exist; candidates lists them"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Failure 502 {object} controller.Problem "Enrichment providers failed"
+// @Failure 504 {object} controller.Problem "Enrichment timed out"
+// @Router /music [post]
 func (mc *musicController) SaveMusic(ctx *fiber.Ctx) error {
 	log.Info("Saving new music")
 	req := new(models.MusicQuery)
 
 	if err := ctx.BodyParser(req); err != nil {
 		log.Warnf("Failed to parse request body: %v", err)
-		return ctx.Status(fiber.StatusBadRequest).SendString(fmt.Sprintf("error: %v", err))
+		return invalidBody(err)
+	}
+	if err := service.ValidateRequest(req); err != nil {
+		return err
+	}
+	if ctx.QueryBool("force") {
+		req.Force = true
+	}
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	if wantsAsync(ctx) {
+		job, err := mc.jobService.EnqueueMusic(reqCtx, req)
+		if err != nil {
+			log.Errorf("Failed to enqueue music: %v", err)
+			return err
+		}
+
+		log.Infof("Music enqueued as job %s", job.ID)
+		ctx.Location("/music/jobs/" + job.ID)
+		return ctx.Status(fiber.StatusAccepted).JSON(job)
 	}
 
-	savedMusic, err := mc.musicService.SaveMusic(ctx.Context(), req)
+	savedMusic, err := mc.musicService.SaveMusic(reqCtx, req)
 	if err != nil {
 		log.Errorf("Failed to save music: %v", err)
-		return ctx.Status(fiber.StatusInternalServerError).SendString(fmt.Sprintf("error: %v", err))
+		return err
 	}
 
 	log.Info("Music saved successfully")
 	return ctx.JSON(savedMusic)
 }
 
+// DeleteMusic godoc
+// @Summary Delete music
+// @Description Move a music record to the trash. It can be restored until the trash is purged.
+// @Tags Music
+// @Param id path string true "Music ID"
+// @Param If-Match header string true "ETag of the music as last read"
+// @Success 200 {string} string "Music deleted successfully"
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 412 {object} controller.Problem "Music was changed since it was read"
+// @Failure 428 {object} controller.Problem "If-Match header is missing"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id} [delete]
 func (mc *musicController) DeleteMusic(ctx *fiber.Ctx) error {
 	musicID := ctx.Params("id")
 	log.Infof("Deleting music with ID: %s", musicID)
-	err := mc.musicService.DeleteMusic(ctx.Context(), musicID)
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	expectedVersion, err := ifMatchVersion(ctx)
+	if err != nil {
+		log.Warnf("Precondition of deleting music with ID %s failed: %v", musicID, err)
+		return err
+	}
+
+	err = mc.musicService.DeleteMusic(reqCtx, musicID, expectedVersion)
 	if err != nil {
 		log.Errorf("Failed to delete music with ID %s: %v", musicID, err)
-		return ctx.Status(fiber.StatusInternalServerError).SendString(fmt.Sprintf("error: %v", err))
+		return err
 	}
 
 	log.Infof("Music with ID %s deleted successfully", musicID)
 	return ctx.SendString("Music deleted successfully")
 }
 
+// GetTrash godoc
+// @Summary Get deleted music
+// @Description Retrieve songs in the trash, most recently deleted first
+// @Tags Music
+// @Produce json
+// @Param params query models.PageParams false "Pagination"
+// @Success 200 {object} models.Page[models.Music]
+// @Header 200 {string} Link "Links to the next and previous pages"
+// @Failure 400 {object} controller.Problem "Invalid query parameters"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/trash [get]
+func (mc *musicController) GetTrash(ctx *fiber.Ctx) error {
+	log.Info("Fetching trash")
+
+	params := new(models.PageParams)
+	if err := ctx.QueryParser(params); err != nil {
+		log.Warnf("Failed to parse query parameters: %v", err)
+		return invalidQuery(err)
+	}
+	if err := service.ValidateRequest(params); err != nil {
+		log.Warnf("Invalid query parameters: %v", err)
+		return err
+	}
+	log.Debugf("Pagination info: page %d, page_size %d", params.Page, params.PageSize)
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	musicList, err := mc.musicService.GetTrash(reqCtx, params.Page, params.PageSize)
+	if err != nil {
+		log.Errorf("Failed to get trash: %v", err)
+		return err
+	}
+
+	return sendPage(ctx, musicList)
+}
+
+// RestoreMusic godoc
+// @Summary Restore deleted music
+// @Description Take a song out of the trash
+// @Tags Music
+// @Produce json
+// @Param id path string true "Music ID"
+// @Success 200 {object} models.Music
+// @Failure 404 {object} controller.Problem "Music is not in trash"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/restore [post]
+func (mc *musicController) RestoreMusic(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	log.Infof("Restoring music with ID: %s", musicID)
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	res, err := mc.musicService.RestoreMusic(reqCtx, musicID)
+	if err != nil {
+		log.Errorf("Failed to restore music with ID %s: %v", musicID, err)
+		return err
+	}
+	if res == nil {
+		log.Warnf("Music with ID %s is not in trash", musicID)
+		return models.NewError(models.ErrNotFound, "music is not in trash")
+	}
+
+	log.Infof("Music with ID %s restored", musicID)
+	setETag(ctx, res)
+	return ctx.JSON(res)
+}
+
+// GetMusic godoc
+// @Summary Get music
+// @Description Retrieve a song by ID. Verses are only returned with include=verses, and fields selects the returned fields.
+// @Tags Music
+// @Produce json
+// @Param id path string true "Music ID"
+// @Param fields query []string false "Fields to return, e.g. song_name,group_name; the id is always returned" collectionFormat(csv)
+// @Param include query []string false "Related data to return" Enums(verses) collectionFormat(csv)
+// @Success 200 {object} models.Music
+// @Failure 400 {object} controller.Problem "Unknown field or include"
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id} [get]
+func (mc *musicController) GetMusic(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	log.Infof("Fetching music with ID: %s", musicID)
+
+	withVerses := false
+	for _, include := range queryList(ctx, "include") {
+		if include != "verses" {
+			log.Warnf("Unknown include %s", include)
+			return models.NewFieldError("include", fmt.Errorf("unknown include %s", include))
+		}
+		withVerses = true
+	}
+
+	fields := queryList(ctx, "fields")
+	for _, field := range fields {
+		if !musicFields[field] {
+			log.Warnf("Unknown field %s", field)
+			return models.NewFieldError("fields", fmt.Errorf("unknown field %s", field))
+		}
+		if field == "verses" {
+			withVerses = true
+		}
+	}
+	if withVerses && len(fields) > 0 {
+		fields = append(fields, "verses")
+	}
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	res, err := mc.musicService.GetMusicByID(reqCtx, musicID, withVerses)
+	if err != nil {
+		log.Errorf("Failed to get music with ID %s: %v", musicID, err)
+		return err
+	}
+	if res == nil {
+		log.Warnf("Music with ID %s not found", musicID)
+		return models.ErrMusicNotFound
+	}
+
+	setETag(ctx, res)
+	if len(fields) == 0 {
+		return ctx.JSON(res)
+	}
+
+	selected, err := selectFields(res, fields)
+	if err != nil {
+		log.Errorf("Failed to select fields of music with ID %s: %v", musicID, err)
+		return err
+	}
+	return ctx.JSON(selected)
+}
+
+// GetVersesOfMusic godoc
+// @Summary Get verses of music
+// @Description Retrieve verses of a music track with pagination. With the cursor parameter the verses are paginated by cursors instead of pages and a models.VersePage is returned; pass the returned next_cursor to get the next page.
+// @Tags Music
+// @Produce json
+// @Param params query models.VerseListParams false "Filters and pagination"
+// @Success 200 {array} models.Verse
+// @Failure 400 {object} controller.Problem "Invalid query parameters"
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/verses [get]
 func (mc *musicController) GetVersesOfMusic(ctx *fiber.Ctx) error {
-	musicID := ctx.Query("music_id")
+	params := new(models.VerseListParams)
+	if err := ctx.QueryParser(params); err != nil {
+		log.Warnf("Failed to parse query parameters: %v", err)
+		return invalidQuery(err)
+	}
+	musicID := params.MusicID
 	log.Infof("Fetching verses for music ID: %s", musicID)
+	if err := service.ValidateRequest(params); err != nil {
+		log.Warnf("Invalid query parameters: %v", err)
+		return err
+	}
+
+	filters := models.VerseFilters{AtMs: params.AtMs}
+	if params.SectionType != "" {
+		log.Debugf("Received section type filter: %s", params.SectionType)
+		filters.SectionType = &params.SectionType
+	}
+
+	if cursorMode(ctx) {
+		if ctx.Query("page") != "" {
+			log.Warn("Both page and cursor are given")
+			return models.NewValidationError("page", "page cannot be combined with cursor")
+		}
 
-	page := ctx.QueryInt("page")
-	pageSize := ctx.QueryInt("page_size")
+		reqCtx, cancel := mc.requestContext(ctx)
+		defer cancel()
+
+		res, err := mc.musicService.GetVersesByCursor(reqCtx, musicID, filters, params.Cursor, params.PageSize)
+		if err != nil {
+			log.Errorf("Failed to get verses for music ID %s: %v", musicID, err)
+			return err
+		}
+
+		log.Infof("Successfully fetched verses for music ID: %s", musicID)
+		res.Next = nextCursorURL(ctx, res.NextCursor, res.PageSize)
+		setETag(ctx, res.Music)
+		return ctx.JSON(res)
+	}
+
+	page, pageSize, err := service.NormalizePagination(params.Page, params.PageSize)
+	if err != nil {
+		log.Warnf("Invalid pagination: %v", err)
+		return err
+	}
 	log.Debugf("Pagination info: page %d, page_size %d", page, pageSize)
 
-	res, err := mc.musicService.GetMusicTextWithPaginationByVerse(ctx.Context(), musicID, page, pageSize)
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	res, err := mc.musicService.GetMusicTextWithPaginationByVerse(reqCtx, musicID, filters, pageSize, (page-1)*pageSize)
 	if err != nil {
 		log.Errorf("Failed to get verses for music ID %s: %v", musicID, err)
-		return ctx.Status(fiber.StatusInternalServerError).SendString(fmt.Sprintf("error: %v", err))
+		return err
 	}
 
 	log.Infof("Successfully fetched verses for music ID: %s", musicID)
+	setETag(ctx, res)
 	return ctx.JSON(res)
 }
 
+// UpdateMusic godoc
+// @Summary Update music
+// @Description Update an existing music record. Only the given fields are changed: omitted or empty fields are left as they are, and verses are matched by their number. Use PATCH to clear the release date or the link. The change is recorded as a new revision.
+// @Tags Music
+// @Accept json
+// @Produce json
+// @Param id path string true "Music ID"
+// @Param music body models.Music true "Updated music object"
+// @Param If-Match header string true "ETag of the music as last read"
+// @Param X-User header string false "Author of the change"
+// @Success 200 {object} models.Music
+// @Failure 400 {object} controller.Problem "Invalid request body"
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 412 {object} controller.Problem "Music was changed since it was read"
+// @Failure 428 {object} controller.Problem "If-Match header is missing"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id} [put]
 func (mc *musicController) UpdateMusic(ctx *fiber.Ctx) error {
 	req := new(models.Music)
 	req.ID = ctx.Params("id")
@@ -147,17 +607,543 @@ func (mc *musicController) UpdateMusic(ctx *fiber.Ctx) error {
 
 	if err := ctx.BodyParser(req); err != nil {
 		log.Warnf("Failed to parse request body: %v", err)
-		ctx.Status(http.StatusBadRequest)
-		return ctx.SendString(fmt.Sprintf("error: %v", err))
+		return invalidBody(err)
+	}
+	if err := service.ValidateRequest(req); err != nil {
+		return err
+	}
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	expectedVersion, err := ifMatchVersion(ctx)
+	if err != nil {
+		log.Warnf("Precondition of updating music with ID %s failed: %v", req.ID, err)
+		return err
 	}
 
-	updatedMusic, err := mc.musicService.UpdateMusic(ctx.Context(), *req)
+	updatedMusic, err := mc.musicService.UpdateMusic(reqCtx, *req, expectedVersion)
 	if err != nil {
 		log.Errorf("Failed to update music with ID %s: %v", req.ID, err)
-		ctx.Status(fiber.StatusInternalServerError)
-		return ctx.SendString(fmt.Sprintf("error: %v", err))
+		return err
 	}
 
 	log.Infof("Music with ID %s updated successfully", req.ID)
+	setETag(ctx, &updatedMusic)
 	return ctx.JSON(updatedMusic)
 }
+
+// patchType returns the patch format given by the Content-Type of a PATCH request. Plain JSON is
+// taken as a merge patch.
+func patchType(ctx *fiber.Ctx) models.PatchType {
+	contentType := strings.ToLower(strings.TrimSpace(strings.Split(ctx.Get(fiber.HeaderContentType), ";")[0]))
+	if contentType == fiber.MIMEApplicationJSON {
+		return models.PatchMerge
+	}
+	return models.PatchType(contentType)
+}
+
+// PatchMusic godoc
+// @Summary Patch music
+// @Description Partially update a music record with an RFC 7396 merge patch or an RFC 6902 JSON patch of the document {song_name, group_name, release_date, link, verses: [{text, section_type, section_label, repeat_of}]}. Null clears release_date and link; a changed verse list replaces all verses. The change is recorded as a new revision.
+// @Tags Music
+// @Accept json
+// @Accept application/merge-patch+json
+// @Accept application/json-patch+json
+// @Produce json
+// @Param id path string true "Music ID"
+// @Param patch body models.MusicDocument true "Merge patch, or array of JSON patch operations"
+// @Param If-Match header string true "ETag of the music as last read"
+// @Param X-User header string false "Author of the change"
+// @Success 200 {object} models.Music
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 412 {object} controller.Problem "Music was changed since it was read"
+// @Failure 415 {object} controller.Problem "Unsupported patch format"
+// @Failure 422 {object} controller.Problem "Patch cannot be applied"
+// @Failure 428 {object} controller.Problem "If-Match header is missing"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id} [patch]
+func (mc *musicController) PatchMusic(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	log.Infof("Patching music with ID: %s", musicID)
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	expectedVersion, err := ifMatchVersion(ctx)
+	if err != nil {
+		log.Warnf("Precondition of patching music with ID %s failed: %v", musicID, err)
+		return err
+	}
+
+	res, err := mc.musicService.PatchMusic(reqCtx, musicID, patchType(ctx), ctx.Body(), expectedVersion)
+	if err != nil {
+		log.Errorf("Failed to patch music with ID %s: %v", musicID, err)
+		return err
+	}
+	if res == nil {
+		log.Warnf("Music with ID %s not found", musicID)
+		return models.ErrMusicNotFound
+	}
+
+	log.Infof("Music with ID %s patched successfully", musicID)
+	setETag(ctx, res)
+	return ctx.JSON(res)
+}
+
+// GetJob godoc
+// @Summary Get enrichment job
+// @Description Retrieve the status of a background enrichment job and, once it succeeded, the saved music
+// @Tags Music
+// @Produce json
+// @Param id path string true "Job ID"
+// @Success 200 {object} models.EnrichmentJob
+// @Failure 404 {object} controller.Problem "Job not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/jobs/{id} [get]
+func (mc *musicController) GetJob(ctx *fiber.Ctx) error {
+	jobID := ctx.Params("id")
+	log.Infof("Fetching enrichment job with ID: %s", jobID)
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	job, err := mc.jobService.GetJob(reqCtx, jobID)
+	if err != nil {
+		log.Errorf("Failed to get enrichment job %s: %v", jobID, err)
+		return err
+	}
+	if job == nil {
+		log.Warnf("Enrichment job %s not found", jobID)
+		return models.ErrJobNotFound
+	}
+
+	log.Infof("Successfully fetched enrichment job %s", jobID)
+	return ctx.JSON(job)
+}
+
+// ImportLRC godoc
+// @Summary Import timed lyrics
+// @Description Replace the lyrics of a music track with the lines of an LRC or enhanced LRC document, keeping their timestamps. The change is recorded as a new revision.
+// @Tags Music
+// @Accept plain
+// @Produce json
+// @Param id path string true "Music ID"
+// @Param lrc body string true "LRC document"
+// @Param If-Match header string true "ETag of the music as last read"
+// @Success 200 {object} models.Music
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 412 {object} controller.Problem "Music was changed since it was read"
+// @Failure 428 {object} controller.Problem "If-Match header is missing"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/lrc [put]
+func (mc *musicController) ImportLRC(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	log.Infof("Importing LRC for music ID: %s", musicID)
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	expectedVersion, err := ifMatchVersion(ctx)
+	if err != nil {
+		log.Warnf("Precondition of importing LRC for music ID %s failed: %v", musicID, err)
+		return err
+	}
+
+	res, err := mc.musicService.ImportLRC(reqCtx, musicID, string(ctx.Body()), expectedVersion)
+	if err != nil {
+		log.Errorf("Failed to import LRC for music ID %s: %v", musicID, err)
+		return err
+	}
+	if res == nil {
+		log.Warnf("Music with ID %s not found", musicID)
+		return models.ErrMusicNotFound
+	}
+
+	log.Infof("Successfully imported LRC for music ID: %s", musicID)
+	setETag(ctx, res)
+	return ctx.JSON(res)
+}
+
+// ExportLRC godoc
+// @Summary Export timed lyrics
+// @Description Download the timed lyrics of a music track as an LRC document
+// @Tags Music
+// @Produce plain
+// @Param id path string true "Music ID"
+// @Success 200 {string} string "LRC document"
+// @Failure 404 {object} controller.Problem "Music or timed lyrics not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/lrc [get]
+func (mc *musicController) ExportLRC(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	log.Infof("Exporting LRC for music ID: %s", musicID)
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	lrc, err := mc.musicService.ExportLRC(reqCtx, musicID)
+	if err != nil {
+		log.Errorf("Failed to export LRC for music ID %s: %v", musicID, err)
+		return err
+	}
+	if lrc == "" {
+		log.Warnf("No timed lyrics for music ID %s", musicID)
+		return models.NewError(models.ErrNotFound, "timed lyrics not found")
+	}
+
+	log.Infof("Successfully exported LRC for music ID: %s", musicID)
+	ctx.Set(fiber.HeaderContentType, "application/x-lrc; charset=utf-8")
+	ctx.Attachment(musicID + ".lrc")
+	return ctx.SendString(lrc)
+}
+
+// GetDuplicates godoc
+// @Summary Get likely duplicates
+// @Description Retrieve clusters of songs that the last background scan found to be likely duplicates, by normalized title, group and lyrics similarity
+// @Tags Music
+// @Produce json
+// @Success 200 {array} models.DuplicateCluster
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/duplicates [get]
+func (mc *musicController) GetDuplicates(ctx *fiber.Ctx) error {
+	log.Info("Fetching duplicate report")
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	res, err := mc.musicService.GetDuplicates(reqCtx)
+	if err != nil {
+		log.Errorf("Failed to get duplicates: %v", err)
+		return err
+	}
+
+	log.Infof("Successfully fetched %d duplicate clusters", len(res))
+	return ctx.JSON(res)
+}
+
+// MergeMusic godoc
+// @Summary Merge duplicates
+// @Description Merge songs into the surviving one: missing metadata and, if it has none, lyrics are taken from the merged songs, which are then moved to the trash. The change to the surviving song is recorded as a new revision.
+// @Tags Music
+// @Accept json
+// @Produce json
+// @Param id path string true "ID of the surviving music"
+// @Param merge body models.MergeRequest true "Songs to merge"
+// @Param If-Match header string true "ETag of the surviving music as last read"
+// @Success 200 {object} models.Music
+// @Failure 400 {object} controller.Problem "Invalid request body"
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 412 {object} controller.Problem "Surviving music was changed since it was read"
+// @Failure 428 {object} controller.Problem "If-Match header is missing"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/merge [post]
+func (mc *musicController) MergeMusic(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	log.Infof("Merging music into ID: %s", musicID)
+
+	req := new(models.MergeRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		log.Warnf("Failed to parse request body: %v", err)
+		return invalidBody(err)
+	}
+	if err := service.ValidateRequest(req); err != nil {
+		return err
+	}
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	expectedVersion, err := ifMatchVersion(ctx)
+	if err != nil {
+		log.Warnf("Precondition of merging music into ID %s failed: %v", musicID, err)
+		return err
+	}
+
+	res, err := mc.musicService.MergeMusic(reqCtx, musicID, req.MergeIDs, expectedVersion)
+	if err != nil {
+		log.Errorf("Failed to merge music into ID %s: %v", musicID, err)
+		return err
+	}
+	if res == nil {
+		log.Warnf("Music to merge into ID %s not found", musicID)
+		return models.ErrMusicNotFound
+	}
+
+	log.Infof("Successfully merged music into ID: %s", musicID)
+	setETag(ctx, res)
+	return ctx.JSON(res)
+}
+
+// GetRevisions godoc
+// @Summary Get revisions of music
+// @Description List the revisions of a song, newest first, with the fields and verses each of them changed
+// @Tags Music
+// @Produce json
+// @Param id path string true "Music ID"
+// @Success 200 {array} models.Revision
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/revisions [get]
+func (mc *musicController) GetRevisions(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	log.Infof("Fetching revisions of music ID: %s", musicID)
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	revisions, err := mc.musicService.GetRevisions(reqCtx, musicID)
+	if err != nil {
+		log.Errorf("Failed to get revisions of music ID %s: %v", musicID, err)
+		return err
+	}
+	if len(revisions) == 0 {
+		log.Warnf("Music with ID %s not found", musicID)
+		return models.ErrMusicNotFound
+	}
+
+	return ctx.JSON(revisions)
+}
+
+// GetRevision godoc
+// @Summary Get revision of music
+// @Description Retrieve a revision of a song together with the snapshot of the song after it
+// @Tags Music
+// @Produce json
+// @Param id path string true "Music ID"
+// @Param rev path int true "Revision number"
+// @Success 200 {object} models.Revision
+// @Failure 400 {object} controller.Problem "Invalid revision"
+// @Failure 404 {object} controller.Problem "Revision not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/revisions/{rev} [get]
+func (mc *musicController) GetRevision(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	revision, err := ctx.ParamsInt("rev")
+	if err != nil {
+		log.Warnf("Invalid revision %s: %v", ctx.Params("rev"), err)
+		return models.NewValidationError("rev", "revision must be a number")
+	}
+	log.Infof("Fetching revision %d of music ID: %s", revision, musicID)
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	res, err := mc.musicService.GetRevision(reqCtx, musicID, revision)
+	if err != nil {
+		log.Errorf("Failed to get revision %d of music ID %s: %v", revision, musicID, err)
+		return err
+	}
+	if res == nil {
+		log.Warnf("Revision %d of music ID %s not found", revision, musicID)
+		return models.ErrRevisionNotFound
+	}
+
+	return ctx.JSON(res)
+}
+
+// RestoreRevision godoc
+// @Summary Restore revision of music
+// @Description Roll a song back to its state at a revision. The rollback is recorded as a new revision; timed lines of verses whose text changes are dropped.
+// @Tags Music
+// @Produce json
+// @Param id path string true "Music ID"
+// @Param rev path int true "Revision number"
+// @Param If-Match header string true "ETag of the music as last read"
+// @Param X-User header string false "Author of the change"
+// @Success 200 {object} models.Music
+// @Failure 400 {object} controller.Problem "Invalid revision"
+// @Failure 404 {object} controller.Problem "Music or revision not found"
+// @Failure 412 {object} controller.Problem "Music was changed since it was read"
+// @Failure 428 {object} controller.Problem "If-Match header is missing"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/revisions/{rev}/restore [post]
+func (mc *musicController) RestoreRevision(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	revision, err := ctx.ParamsInt("rev")
+	if err != nil {
+		log.Warnf("Invalid revision %s: %v", ctx.Params("rev"), err)
+		return models.NewValidationError("rev", "revision must be a number")
+	}
+	log.Infof("Restoring music ID %s to revision %d", musicID, revision)
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	expectedVersion, err := ifMatchVersion(ctx)
+	if err != nil {
+		log.Warnf("Precondition of restoring music ID %s failed: %v", musicID, err)
+		return err
+	}
+
+	res, err := mc.musicService.RestoreRevision(reqCtx, musicID, revision, expectedVersion)
+	if err != nil {
+		log.Errorf("Failed to restore music ID %s to revision %d: %v", musicID, revision, err)
+		return err
+	}
+	if res == nil {
+		log.Warnf("Music ID %s or its revision %d not found", musicID, revision)
+		return models.NewError(models.ErrNotFound, "music or revision not found")
+	}
+
+	log.Infof("Music ID %s restored to revision %d", musicID, revision)
+	setETag(ctx, res)
+	return ctx.JSON(res)
+}
+
+// InsertVerse godoc
+// @Summary Insert verse
+// @Description Insert a verse before the verse at the given position, or append it without one. The following verses are renumbered.
+// @Tags Music
+// @Accept json
+// @Produce json
+// @Param id path string true "Music ID"
+// @Param verse body models.VerseInsert true "Verse to insert"
+// @Param If-Match header string true "ETag of the music as last read"
+// @Param X-User header string false "Author of the change"
+// @Success 200 {object} models.Music
+// @Failure 400 {object} controller.Problem "Invalid request body"
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 412 {object} controller.Problem "Music was changed since it was read"
+// @Failure 428 {object} controller.Problem "If-Match header is missing"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/verses [post]
+func (mc *musicController) InsertVerse(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	log.Infof("Inserting verse into music ID: %s", musicID)
+
+	req := new(models.VerseInsert)
+	if err := ctx.BodyParser(req); err != nil {
+		log.Warnf("Failed to parse request body: %v", err)
+		return invalidBody(err)
+	}
+	if err := service.ValidateRequest(req); err != nil {
+		return err
+	}
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	expectedVersion, err := ifMatchVersion(ctx)
+	if err != nil {
+		log.Warnf("Precondition of inserting verse into music ID %s failed: %v", musicID, err)
+		return err
+	}
+
+	res, err := mc.musicService.InsertVerse(reqCtx, musicID, *req, expectedVersion)
+	if err != nil {
+		log.Errorf("Failed to insert verse into music ID %s: %v", musicID, err)
+		return err
+	}
+	if res == nil {
+		log.Warnf("Music with ID %s not found", musicID)
+		return models.ErrMusicNotFound
+	}
+
+	log.Infof("Verse inserted into music ID: %s", musicID)
+	setETag(ctx, res)
+	return ctx.JSON(res)
+}
+
+// DeleteVerse godoc
+// @Summary Delete verse
+// @Description Delete a verse with its timed lines. The following verses are renumbered.
+// @Tags Music
+// @Produce json
+// @Param id path string true "Music ID"
+// @Param number path int true "Verse number"
+// @Param If-Match header string true "ETag of the music as last read"
+// @Param X-User header string false "Author of the change"
+// @Success 200 {object} models.Music
+// @Failure 400 {object} controller.Problem "Invalid verse number"
+// @Failure 404 {object} controller.Problem "Music or verse not found"
+// @Failure 412 {object} controller.Problem "Music was changed since it was read"
+// @Failure 428 {object} controller.Problem "If-Match header is missing"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/verses/{number} [delete]
+func (mc *musicController) DeleteVerse(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	number, err := ctx.ParamsInt("number")
+	if err != nil {
+		log.Warnf("Invalid verse number %s: %v", ctx.Params("number"), err)
+		return models.NewValidationError("number", "verse number must be a number")
+	}
+	log.Infof("Deleting verse %d of music ID: %s", number, musicID)
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	expectedVersion, err := ifMatchVersion(ctx)
+	if err != nil {
+		log.Warnf("Precondition of deleting verse of music ID %s failed: %v", musicID, err)
+		return err
+	}
+
+	res, err := mc.musicService.DeleteVerse(reqCtx, musicID, number, expectedVersion)
+	if err != nil {
+		log.Errorf("Failed to delete verse %d of music ID %s: %v", number, musicID, err)
+		return err
+	}
+	if res == nil {
+		log.Warnf("Music with ID %s not found", musicID)
+		return models.ErrMusicNotFound
+	}
+
+	log.Infof("Verse %d of music ID %s deleted", number, musicID)
+	setETag(ctx, res)
+	return ctx.JSON(res)
+}
+
+// ReorderVerses godoc
+// @Summary Reorder verses
+// @Description Renumber the verses of a song in the given order, which must list every current verse number exactly once
+// @Tags Music
+// @Accept json
+// @Produce json
+// @Param id path string true "Music ID"
+// @Param order body models.VerseOrder true "Verse numbers in their new order"
+// @Param If-Match header string true "ETag of the music as last read"
+// @Param X-User header string false "Author of the change"
+// @Success 200 {object} models.Music
+// @Failure 400 {object} controller.Problem "Invalid order"
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 412 {object} controller.Problem "Music was changed since it was read"
+// @Failure 428 {object} controller.Problem "If-Match header is missing"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/verses/order [put]
+func (mc *musicController) ReorderVerses(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	log.Infof("Reordering verses of music ID: %s", musicID)
+
+	req := new(models.VerseOrder)
+	if err := ctx.BodyParser(req); err != nil {
+		log.Warnf("Failed to parse request body: %v", err)
+		return invalidBody(err)
+	}
+	if err := service.ValidateRequest(req); err != nil {
+		return err
+	}
+
+	reqCtx, cancel := mc.requestContext(ctx)
+	defer cancel()
+
+	expectedVersion, err := ifMatchVersion(ctx)
+	if err != nil {
+		log.Warnf("Precondition of reordering verses of music ID %s failed: %v", musicID, err)
+		return err
+	}
+
+	res, err := mc.musicService.ReorderVerses(reqCtx, musicID, req.Order, expectedVersion)
+	if err != nil {
+		log.Errorf("Failed to reorder verses of music ID %s: %v", musicID, err)
+		return err
+	}
+	if res == nil {
+		log.Warnf("Music with ID %s not found", musicID)
+		return models.ErrMusicNotFound
+	}
+
+	log.Infof("Verses of music ID %s reordered", musicID)
+	setETag(ctx, res)
+	return ctx.JSON(res)
+}
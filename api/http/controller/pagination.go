@@ -0,0 +1,68 @@
+package controller
+
+import (
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/gofiber/fiber/v2"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// pageURL returns the URL of the current request for another page of the list.
+func pageURL(ctx *fiber.Ctx, page, pageSize int) string {
+	query, _ := url.ParseQuery(string(ctx.Request().URI().QueryString()))
+	query.Set("page", strconv.Itoa(page))
+	query.Set("page_size", strconv.Itoa(pageSize))
+	return ctx.BaseURL() + ctx.Path() + "?" + query.Encode()
+}
+
+// sendPage sends a page of a list with links to its neighbouring pages, which are also sent
+// in the Link header.
+func sendPage[T any](ctx *fiber.Ctx, page *models.Page[T]) error {
+	var links []string
+	if page.HasNext() {
+		page.Next = pageURL(ctx, page.Page+1, page.PageSize)
+		links = append(links, `<`+page.Next+`>; rel="next"`)
+	}
+	if page.Page > 1 {
+		page.Prev = pageURL(ctx, page.Page-1, page.PageSize)
+		links = append(links, `<`+page.Prev+`>; rel="prev"`)
+	}
+	if len(links) > 0 {
+		ctx.Set(fiber.HeaderLink, strings.Join(links, ", "))
+	}
+
+	return ctx.JSON(page)
+}
+
+// cursorMode reports whether the request asks for cursor pagination. An empty cursor parameter
+// starts it from the first item.
+func cursorMode(ctx *fiber.Ctx) bool {
+	return ctx.Context().QueryArgs().Has("cursor")
+}
+
+// cursorURL returns the URL of the current request for the page following cursor.
+func cursorURL(ctx *fiber.Ctx, cursor string, pageSize int) string {
+	query, _ := url.ParseQuery(string(ctx.Request().URI().QueryString()))
+	query.Del("page")
+	query.Set("cursor", cursor)
+	query.Set("page_size", strconv.Itoa(pageSize))
+	return ctx.BaseURL() + ctx.Path() + "?" + query.Encode()
+}
+
+// nextCursorURL returns the link to the page following nextCursor and sets it in the Link header.
+// It returns an empty string on the last page.
+func nextCursorURL(ctx *fiber.Ctx, nextCursor string, pageSize int) string {
+	if nextCursor == "" {
+		return ""
+	}
+	next := cursorURL(ctx, nextCursor, pageSize)
+	ctx.Set(fiber.HeaderLink, `<`+next+`>; rel="next"`)
+	return next
+}
+
+// sendCursorPage sends a page of a list paginated with cursors with a link to the next page.
+func sendCursorPage[T any](ctx *fiber.Ctx, page *models.CursorPage[T]) error {
+	page.Next = nextCursorURL(ctx, page.NextCursor, page.PageSize)
+	return ctx.JSON(page)
+}
@@ -0,0 +1,250 @@
+package controller
+
+import (
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/gofiber/fiber/v2"
+	log "github.com/sirupsen/logrus"
+	"net/url"
+	"time"
+)
+
+type tagController struct {
+	tagService models.TagService
+	timeout    time.Duration
+}
+
+func NewTagController(tagService models.TagService, timeout time.Duration) *tagController {
+	log.Info("Creating new tag controller instance")
+	return &tagController{
+		tagService: tagService,
+		timeout:    timeout,
+	}
+}
+
+// pathParam returns a path parameter with its percent-encoding removed, so that tags may contain spaces.
+func pathParam(ctx *fiber.Ctx, name string) string {
+	value := ctx.Params(name)
+	if unescaped, err := url.PathUnescape(value); err == nil {
+		return unescaped
+	}
+	return value
+}
+
+// GetTags godoc
+// @Summary Get list of tags
+// @Description Retrieve tags in use ordered by the number of songs carrying them
+// @Tags Tags
+// @Produce json
+// @Param page query int false "Page number, 1 by default"
+// @Param page_size query int false "Number of records per page, 20 by default and at most 100"
+// @Success 200 {object} models.Page[models.Tag]
+// @Header 200 {string} Link "Links to the next and previous pages"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /tags [get]
+func (tc *tagController) GetTags(ctx *fiber.Ctx) error {
+	log.Info("Fetching tag list")
+
+	page := ctx.QueryInt("page")
+	pageSize := ctx.QueryInt("page_size")
+	log.Debugf("Pagination info: page %d, page_size %d", page, pageSize)
+
+	reqCtx, cancel := requestContext(ctx, tc.timeout)
+	defer cancel()
+
+	tags, err := tc.tagService.GetTags(reqCtx, page, pageSize)
+	if err != nil {
+		log.Errorf("Failed to get tag list: %v", err)
+		return err
+	}
+
+	return sendPage(ctx, tags)
+}
+
+// GetGenres godoc
+// @Summary Get list of genres
+// @Description Retrieve all genres with the number of songs in each
+// @Tags Tags
+// @Produce json
+// @Success 200 {array} models.Genre
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /genres [get]
+func (tc *tagController) GetGenres(ctx *fiber.Ctx) error {
+	log.Info("Fetching genre list")
+
+	reqCtx, cancel := requestContext(ctx, tc.timeout)
+	defer cancel()
+
+	genres, err := tc.tagService.GetGenres(reqCtx)
+	if err != nil {
+		log.Errorf("Failed to get genre list: %v", err)
+		return err
+	}
+
+	return ctx.JSON(genres)
+}
+
+// CreateGenre godoc
+// @Summary Create genre
+// @Description Add a genre songs can be filed under. Songs tagged with its name during enrichment are added to it.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param genre body models.Genre true "Genre"
+// @Success 201 {object} models.Genre
+// @Failure 400 {object} controller.Problem "Invalid request body"
+// @Failure 409 {object} controller.Problem "Genre already exists"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /genres [post]
+func (tc *tagController) CreateGenre(ctx *fiber.Ctx) error {
+	log.Info("Creating new genre")
+	req := new(models.Genre)
+
+	if err := ctx.BodyParser(req); err != nil {
+		log.Warnf("Failed to parse request body: %v", err)
+		return invalidBody(err)
+	}
+
+	reqCtx, cancel := requestContext(ctx, tc.timeout)
+	defer cancel()
+
+	genre, err := tc.tagService.CreateGenre(reqCtx, req.Name)
+	if err != nil {
+		log.Errorf("Failed to create genre: %v", err)
+		return err
+	}
+
+	log.Infof("Genre created successfully with ID %s", genre.ID)
+	return ctx.Status(fiber.StatusCreated).JSON(genre)
+}
+
+// GetMusicTags godoc
+// @Summary Get tags of music
+// @Description Retrieve the genres and tags of a song
+// @Tags Tags
+// @Produce json
+// @Param id path string true "Music ID"
+// @Success 200 {object} models.MusicTags
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/tags [get]
+func (tc *tagController) GetMusicTags(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	log.Infof("Fetching tags of music %s", musicID)
+
+	reqCtx, cancel := requestContext(ctx, tc.timeout)
+	defer cancel()
+
+	tags, err := tc.tagService.GetMusicTags(reqCtx, musicID)
+	return tc.sendMusicTags(ctx, musicID, tags, err)
+}
+
+// AddMusicTags godoc
+// @Summary Tag music
+// @Description Attach tags to a song. Tags are stored in lower case; tags the song already has are kept.
+// @Tags Tags
+// @Accept json
+// @Produce json
+// @Param id path string true "Music ID"
+// @Param tags body models.TagsRequest true "Tags"
+// @Success 200 {object} models.MusicTags
+// @Failure 400 {object} controller.Problem "Invalid request body"
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/tags [post]
+func (tc *tagController) AddMusicTags(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	log.Infof("Tagging music %s", musicID)
+
+	req := new(models.TagsRequest)
+	if err := ctx.BodyParser(req); err != nil {
+		log.Warnf("Failed to parse request body: %v", err)
+		return invalidBody(err)
+	}
+
+	reqCtx, cancel := requestContext(ctx, tc.timeout)
+	defer cancel()
+
+	tags, err := tc.tagService.AddMusicTags(reqCtx, musicID, req.Tags)
+	return tc.sendMusicTags(ctx, musicID, tags, err)
+}
+
+// RemoveMusicTag godoc
+// @Summary Untag music
+// @Description Detach a tag from a song
+// @Tags Tags
+// @Produce json
+// @Param id path string true "Music ID"
+// @Param tag path string true "Tag"
+// @Success 200 {object} models.MusicTags
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/tags/{tag} [delete]
+func (tc *tagController) RemoveMusicTag(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	tag := pathParam(ctx, "tag")
+	log.Infof("Removing tag %s from music %s", tag, musicID)
+
+	reqCtx, cancel := requestContext(ctx, tc.timeout)
+	defer cancel()
+
+	tags, err := tc.tagService.RemoveMusicTag(reqCtx, musicID, tag)
+	return tc.sendMusicTags(ctx, musicID, tags, err)
+}
+
+// AddMusicGenre godoc
+// @Summary Add music to genre
+// @Description File a song under an existing genre
+// @Tags Tags
+// @Produce json
+// @Param id path string true "Music ID"
+// @Param genre path string true "Genre name"
+// @Success 200 {object} models.MusicTags
+// @Failure 404 {object} controller.Problem "Music or genre not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/genres/{genre} [put]
+func (tc *tagController) AddMusicGenre(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	genre := pathParam(ctx, "genre")
+	log.Infof("Adding music %s to genre %s", musicID, genre)
+
+	reqCtx, cancel := requestContext(ctx, tc.timeout)
+	defer cancel()
+
+	tags, err := tc.tagService.AddMusicGenre(reqCtx, musicID, genre)
+	return tc.sendMusicTags(ctx, musicID, tags, err)
+}
+
+// RemoveMusicGenre godoc
+// @Summary Remove music from genre
+// @Description Take a song out of a genre
+// @Tags Tags
+// @Produce json
+// @Param id path string true "Music ID"
+// @Param genre path string true "Genre name"
+// @Success 200 {object} models.MusicTags
+// @Failure 404 {object} controller.Problem "Music not found"
+// @Failure 500 {object} controller.Problem "Internal server error"
+// @Router /music/{id}/genres/{genre} [delete]
+func (tc *tagController) RemoveMusicGenre(ctx *fiber.Ctx) error {
+	musicID := ctx.Params("id")
+	genre := pathParam(ctx, "genre")
+	log.Infof("Removing music %s from genre %s", musicID, genre)
+
+	reqCtx, cancel := requestContext(ctx, tc.timeout)
+	defer cancel()
+
+	tags, err := tc.tagService.RemoveMusicGenre(reqCtx, musicID, genre)
+	return tc.sendMusicTags(ctx, musicID, tags, err)
+}
+
+func (tc *tagController) sendMusicTags(ctx *fiber.Ctx, musicID string, tags *models.MusicTags, err error) error {
+	if err != nil {
+		log.Errorf("Failed to update tags of music %s: %v", musicID, err)
+		return err
+	}
+	if tags == nil {
+		log.Warnf("Music %s not found", musicID)
+		return models.ErrMusicNotFound
+	}
+	return ctx.JSON(tags)
+}
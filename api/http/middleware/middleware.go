@@ -20,7 +20,8 @@ func MiddlewaresSetup(server *fiber.App) {
 
 	server.Use(
 		cors.New(cors.Config{
-			AllowMethods: "POST, GET, DELETE, PUT",
+			AllowMethods:  "POST, GET, DELETE, PUT, PATCH",
+			ExposeHeaders: "ETag, Link",
 		}),
 		logger.New(logger.Config{
 			Output: file,
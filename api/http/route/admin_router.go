@@ -0,0 +1,16 @@
+package route
+
+import (
+	"github.com/Seven11Eleven/music_library/api/http/controller"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/gofiber/fiber/v2"
+)
+
+func NewAdminRouter(
+	group fiber.Router,
+	dataEnrichmentService service.DataEnrichmentService,
+) {
+	adminController := controller.NewAdminController(dataEnrichmentService)
+
+	group.Get("/enrichment/status", adminController.GetEnrichmentStatus)
+}
@@ -0,0 +1,22 @@
+package route
+
+import (
+	"github.com/Seven11Eleven/music_library/api/http/controller"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+func NewAlbumRouter(
+	group fiber.Router,
+	albumService models.AlbumService,
+	timeout time.Duration,
+) {
+	albumController := controller.NewAlbumController(albumService, timeout)
+
+	group.Get("/", albumController.GetAlbums)
+	group.Post("/", albumController.CreateAlbum)
+	group.Get("/:id", albumController.GetAlbum)
+	group.Get("/:id/tracks", albumController.GetTracks)
+	group.Post("/:id/tracks", albumController.AddTrack)
+}
@@ -0,0 +1,22 @@
+package route
+
+import (
+	"github.com/Seven11Eleven/music_library/api/http/controller"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+func NewArtistRouter(
+	group fiber.Router,
+	artistService models.ArtistService,
+	timeout time.Duration,
+) {
+	artistController := controller.NewArtistController(artistService, timeout)
+
+	group.Get("/", artistController.GetArtists)
+	group.Post("/", artistController.CreateArtist)
+	group.Get("/:id", artistController.GetArtist)
+	group.Put("/:id", artistController.UpdateArtist)
+	group.Delete("/:id", artistController.DeleteArtist)
+}
@@ -10,13 +10,30 @@ import (
 func NewMusicRouter(
 	group fiber.Router,
 	musicService models.MusicService,
+	jobService models.JobService,
 	timeout time.Duration,
 ) {
-	musicController := controller.NewMusicController(musicService)
+	musicController := controller.NewMusicController(musicService, jobService, timeout)
 
 	group.Get("/info", musicController.GetMusicList)
 	group.Get("/verses", musicController.GetVersesOfMusic)
+	group.Get("/search", musicController.SearchMusic)
+	group.Get("/duplicates", musicController.GetDuplicates)
+	group.Get("/trash", musicController.GetTrash)
+	group.Get("/jobs/:id", musicController.GetJob)
+	group.Get("/:id", musicController.GetMusic)
+	group.Get("/:id/lrc", musicController.ExportLRC)
+	group.Put("/:id/lrc", musicController.ImportLRC)
+	group.Post("/:id/verses", musicController.InsertVerse)
+	group.Put("/:id/verses/order", musicController.ReorderVerses)
+	group.Delete("/:id/verses/:number", musicController.DeleteVerse)
+	group.Post("/:id/merge", musicController.MergeMusic)
+	group.Post("/:id/restore", musicController.RestoreMusic)
+	group.Get("/:id/revisions", musicController.GetRevisions)
+	group.Get("/:id/revisions/:rev", musicController.GetRevision)
+	group.Post("/:id/revisions/:rev/restore", musicController.RestoreRevision)
 	group.Delete("/:id", musicController.DeleteMusic)
 	group.Post("/", musicController.SaveMusic)
 	group.Put("/:id", musicController.UpdateMusic)
+	group.Patch("/:id", musicController.PatchMusic)
 }
@@ -3,6 +3,7 @@ package route
 import (
 	"github.com/Seven11Eleven/music_library/api/http/middleware"
 	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
 	"github.com/gofiber/fiber/v2"
 	"time"
 )
@@ -10,12 +11,27 @@ import (
 func SetupRoutes(
 	app *fiber.App,
 	musicService models.MusicService,
+	jobService models.JobService,
+	artistService models.ArtistService,
+	albumService models.AlbumService,
+	tagService models.TagService,
+	dataEnrichmentService service.DataEnrichmentService,
 	timeout time.Duration,
 ) {
 	middleware.MiddlewaresSetup(app)
 
 	musicRoute := app.Group("/music")
-	NewMusicRouter(musicRoute, musicService, timeout)
+	NewMusicRouter(musicRoute, musicService, jobService, timeout)
+	NewTagRouter(app, musicRoute, tagService, timeout)
+
+	artistRoute := app.Group("/artists")
+	NewArtistRouter(artistRoute, artistService, timeout)
+
+	albumRoute := app.Group("/albums")
+	NewAlbumRouter(albumRoute, albumService, timeout)
+
+	adminRoute := app.Group("/admin")
+	NewAdminRouter(adminRoute, dataEnrichmentService)
 
 	docsRoute := app.Group("/docs")
 	NewDocsRouter(docsRoute)
@@ -0,0 +1,28 @@
+package route
+
+import (
+	"github.com/Seven11Eleven/music_library/api/http/controller"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/gofiber/fiber/v2"
+	"time"
+)
+
+// NewTagRouter mounts /tags and /genres and the tag routes of songs under musicGroup.
+func NewTagRouter(
+	app fiber.Router,
+	musicGroup fiber.Router,
+	tagService models.TagService,
+	timeout time.Duration,
+) {
+	tagController := controller.NewTagController(tagService, timeout)
+
+	app.Get("/tags", tagController.GetTags)
+	app.Get("/genres", tagController.GetGenres)
+	app.Post("/genres", tagController.CreateGenre)
+
+	musicGroup.Get("/:id/tags", tagController.GetMusicTags)
+	musicGroup.Post("/:id/tags", tagController.AddMusicTags)
+	musicGroup.Delete("/:id/tags/:tag", tagController.RemoveMusicTag)
+	musicGroup.Put("/:id/genres/:genre", tagController.AddMusicGenre)
+	musicGroup.Delete("/:id/genres/:genre", tagController.RemoveMusicGenre)
+}
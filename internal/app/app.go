@@ -2,6 +2,7 @@ package app
 
 import (
 	"fmt"
+	"github.com/Seven11Eleven/music_library/api/http/controller"
 	"github.com/Seven11Eleven/music_library/api/http/route"
 	"github.com/Seven11Eleven/music_library/internal/config"
 	"github.com/Seven11Eleven/music_library/internal/database/postgres"
@@ -10,6 +11,7 @@ import (
 	log "github.com/sirupsen/logrus"
 	"os"
 	"os/signal"
+	"sync"
 
 	"context"
 
@@ -21,6 +23,9 @@ type App struct {
 	Router *fiber.App
 	DB     *pgxpool.Pool
 	Env    *config.Config
+
+	ctx     context.Context
+	workers sync.WaitGroup
 }
 
 func NewApp(ctx context.Context) (*App, error) {
@@ -37,23 +42,70 @@ func NewApp(ctx context.Context) (*App, error) {
 	}
 
 	fiberApp := fiber.New(fiber.Config{
-		Immutable: true,
+		Immutable:    true,
+		ErrorHandler: controller.ErrorHandler,
 	})
 
 	return &App{
 		Router: fiberApp,
 		DB:     db,
 		Env:    env,
+		ctx:    ctx,
 	}, nil
 }
 
 func (app *App) Start() {
 	musicRepo := repository.NewMusicRepository(app.DB)
+	if err := musicRepo.SetSearchLanguages(app.ctx, app.Env.SearchLanguages); err != nil {
+		log.Fatalf("Failed to configure lyrics search: %v", err)
+	}
+	artistRepo := repository.NewArtistRepository(app.DB)
+	artistService := service.NewArtistService(artistRepo)
+	albumRepo := repository.NewAlbumRepository(app.DB)
+	albumService := service.NewAlbumService(albumRepo)
+	tagRepo := repository.NewTagRepository(app.DB)
+	tagService := service.NewTagService(tagRepo)
 	dataEnrichmentService := service.NewDataEnrichmentService(app.Env)
-	musicService := service.NewMusicService(musicRepo, dataEnrichmentService)
+	if app.Env.ContextTimeout > 0 && app.Env.ContextTimeout <= app.Env.EnrichmentTimeout {
+		log.Warnf("Request timeout %s is not longer than the enrichment timeout %s, songs saved synchronously will time out before their enrichment does", app.Env.ContextTimeout, app.Env.EnrichmentTimeout)
+	}
+	musicService := service.NewMusicService(
+		musicRepo,
+		dataEnrichmentService,
+		service.WithArtistRepository(artistRepo),
+		service.WithSimilarityThreshold(app.Env.SimilarityThreshold),
+		service.WithDuplicateThreshold(app.Env.DuplicateThreshold),
+		service.WithTrashRetention(app.Env.TrashRetentionDays),
+	)
+	jobRepo := repository.NewJobRepository(app.DB)
+	jobService := service.NewJobService(jobRepo, app.Env)
+	workerPool := service.NewEnrichmentWorkerPool(jobRepo, musicService, app.Env)
+
+	duplicateScanner := service.NewDuplicateScanner(musicService, app.Env)
+	trashPurger := service.NewTrashPurger(musicService, app.Env)
+
+	app.workers.Add(3)
+	go func() {
+		defer app.workers.Done()
+		workerPool.Run(app.ctx)
+	}()
+	go func() {
+		defer app.workers.Done()
+		duplicateScanner.Run(app.ctx)
+	}()
+	go func() {
+		defer app.workers.Done()
+		trashPurger.Run(app.ctx)
+	}()
+
 	route.SetupRoutes(
 		app.Router,
 		musicService,
+		jobService,
+		artistService,
+		albumService,
+		tagService,
+		dataEnrichmentService,
 		app.Env.ContextTimeout,
 	)
 
@@ -71,6 +123,9 @@ func (app *App) Start() {
 }
 
 func (app *App) Close() {
+	// Воркеры завершаются по отмене контекста приложения и должны успеть освободить соединения
+	app.workers.Wait()
+
 	if app.DB != nil {
 		app.DB.Close()
 	}
@@ -14,12 +14,44 @@ type Config struct {
 	AppPort        string        `mapstructure:"PORT"`
 	ContextTimeout time.Duration `mapstructure:"TIMEOUT"`
 	APIKey         string        `mapstructure:"API_KEY"`
+
+	MetadataProviders []string `mapstructure:"METADATA_PROVIDERS"`
+	LyricsProviders   []string `mapstructure:"LYRICS_PROVIDERS"`
+
+	LastFMBaseURL     string        `mapstructure:"LASTFM_BASE_URL"`
+	LyristBaseURL     string        `mapstructure:"LYRIST_BASE_URL"`
+	EnrichmentTimeout time.Duration `mapstructure:"ENRICHMENT_TIMEOUT"`
+
+	EnrichmentMaxRetries     int           `mapstructure:"ENRICHMENT_MAX_RETRIES"`
+	EnrichmentRetryBaseDelay time.Duration `mapstructure:"ENRICHMENT_RETRY_BASE_DELAY"`
+	EnrichmentRetryMaxDelay  time.Duration `mapstructure:"ENRICHMENT_RETRY_MAX_DELAY"`
+	BreakerFailureThreshold  int           `mapstructure:"BREAKER_FAILURE_THRESHOLD"`
+	BreakerOpenTimeout       time.Duration `mapstructure:"BREAKER_OPEN_TIMEOUT"`
+
+	JobWorkers      int           `mapstructure:"JOB_WORKERS"`
+	JobPollInterval time.Duration `mapstructure:"JOB_POLL_INTERVAL"`
+	JobLease        time.Duration `mapstructure:"JOB_LEASE"`
+	JobMaxAttempts  int           `mapstructure:"JOB_MAX_ATTEMPTS"`
+	JobRetryDelay   time.Duration `mapstructure:"JOB_RETRY_DELAY"`
+
+	SearchLanguages     []string `mapstructure:"SEARCH_LANGUAGES"`
+	SimilarityThreshold float64  `mapstructure:"SIMILARITY_THRESHOLD"`
+
+	DuplicateThreshold    float64       `mapstructure:"DUPLICATE_THRESHOLD"`
+	DuplicateScanInterval time.Duration `mapstructure:"DUPLICATE_SCAN_INTERVAL"`
+
+	TrashRetentionDays int           `mapstructure:"TRASH_RETENTION_DAYS"`
+	TrashPurgeInterval time.Duration `mapstructure:"TRASH_PURGE_INTERVAL"`
 }
 
 func MustLoad() *Config {
 	viper.SetConfigFile("/src/.env")
 	viper.AutomaticEnv()
 
+	viper.SetDefault("ENRICHMENT_MAX_RETRIES", 3)
+	viper.SetDefault("BREAKER_FAILURE_THRESHOLD", 5)
+	viper.SetDefault("SIMILARITY_THRESHOLD", 0.3)
+
 	err := viper.ReadInConfig()
 	if err != nil {
 		panic("cannot read the config: " + err.Error())
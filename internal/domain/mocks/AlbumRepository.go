@@ -0,0 +1,207 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Seven11Eleven/music_library/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AlbumRepository is an autogenerated mock type for the AlbumRepository type
+type AlbumRepository struct {
+	mock.Mock
+}
+
+// AddTrack provides a mock function with given fields: ctx, albumID, track
+func (_m *AlbumRepository) AddTrack(ctx context.Context, albumID string, track models.AlbumTrack) (*models.AlbumTrack, error) {
+	ret := _m.Called(ctx, albumID, track)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTrack")
+	}
+
+	var r0 *models.AlbumTrack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.AlbumTrack) (*models.AlbumTrack, error)); ok {
+		return rf(ctx, albumID, track)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.AlbumTrack) *models.AlbumTrack); ok {
+		r0 = rf(ctx, albumID, track)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AlbumTrack)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.AlbumTrack) error); ok {
+		r1 = rf(ctx, albumID, track)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountAlbums provides a mock function with given fields: ctx, filters
+func (_m *AlbumRepository) CountAlbums(ctx context.Context, filters models.AlbumFilters) (int, error) {
+	ret := _m.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountAlbums")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.AlbumFilters) (int, error)); ok {
+		return rf(ctx, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.AlbumFilters) int); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.AlbumFilters) error); ok {
+		r1 = rf(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAlbum provides a mock function with given fields: ctx, album
+func (_m *AlbumRepository) CreateAlbum(ctx context.Context, album *models.Album) (*models.Album, error) {
+	ret := _m.Called(ctx, album)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAlbum")
+	}
+
+	var r0 *models.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Album) (*models.Album, error)); ok {
+		return rf(ctx, album)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Album) *models.Album); ok {
+		r0 = rf(ctx, album)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Album)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Album) error); ok {
+		r1 = rf(ctx, album)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAlbum provides a mock function with given fields: ctx, albumID
+func (_m *AlbumRepository) GetAlbum(ctx context.Context, albumID string) (*models.Album, error) {
+	ret := _m.Called(ctx, albumID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbum")
+	}
+
+	var r0 *models.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Album, error)); ok {
+		return rf(ctx, albumID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Album); ok {
+		r0 = rf(ctx, albumID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Album)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, albumID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAlbums provides a mock function with given fields: ctx, filters, page, pageSize
+func (_m *AlbumRepository) GetAlbums(ctx context.Context, filters models.AlbumFilters, page int, pageSize int) ([]models.Album, error) {
+	ret := _m.Called(ctx, filters, page, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbums")
+	}
+
+	var r0 []models.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.AlbumFilters, int, int) ([]models.Album, error)); ok {
+		return rf(ctx, filters, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.AlbumFilters, int, int) []models.Album); ok {
+		r0 = rf(ctx, filters, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Album)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.AlbumFilters, int, int) error); ok {
+		r1 = rf(ctx, filters, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTracks provides a mock function with given fields: ctx, albumID
+func (_m *AlbumRepository) GetTracks(ctx context.Context, albumID string) ([]models.AlbumTrack, error) {
+	ret := _m.Called(ctx, albumID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTracks")
+	}
+
+	var r0 []models.AlbumTrack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.AlbumTrack, error)); ok {
+		return rf(ctx, albumID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.AlbumTrack); ok {
+		r0 = rf(ctx, albumID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AlbumTrack)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, albumID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAlbumRepository creates a new instance of AlbumRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAlbumRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AlbumRepository {
+	mock := &AlbumRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,179 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Seven11Eleven/music_library/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// AlbumService is an autogenerated mock type for the AlbumService type
+type AlbumService struct {
+	mock.Mock
+}
+
+// AddTrack provides a mock function with given fields: ctx, albumID, track
+func (_m *AlbumService) AddTrack(ctx context.Context, albumID string, track models.AlbumTrack) (*models.AlbumTrack, error) {
+	ret := _m.Called(ctx, albumID, track)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddTrack")
+	}
+
+	var r0 *models.AlbumTrack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.AlbumTrack) (*models.AlbumTrack, error)); ok {
+		return rf(ctx, albumID, track)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.AlbumTrack) *models.AlbumTrack); ok {
+		r0 = rf(ctx, albumID, track)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.AlbumTrack)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.AlbumTrack) error); ok {
+		r1 = rf(ctx, albumID, track)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateAlbum provides a mock function with given fields: ctx, album
+func (_m *AlbumService) CreateAlbum(ctx context.Context, album *models.Album) (*models.Album, error) {
+	ret := _m.Called(ctx, album)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateAlbum")
+	}
+
+	var r0 *models.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Album) (*models.Album, error)); ok {
+		return rf(ctx, album)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Album) *models.Album); ok {
+		r0 = rf(ctx, album)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Album)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Album) error); ok {
+		r1 = rf(ctx, album)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAlbum provides a mock function with given fields: ctx, albumID
+func (_m *AlbumService) GetAlbum(ctx context.Context, albumID string) (*models.Album, error) {
+	ret := _m.Called(ctx, albumID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbum")
+	}
+
+	var r0 *models.Album
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Album, error)); ok {
+		return rf(ctx, albumID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Album); ok {
+		r0 = rf(ctx, albumID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Album)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, albumID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetAlbums provides a mock function with given fields: ctx, filters, page, pageSize
+func (_m *AlbumService) GetAlbums(ctx context.Context, filters models.AlbumFilters, page int, pageSize int) (*models.Page[models.Album], error) {
+	ret := _m.Called(ctx, filters, page, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetAlbums")
+	}
+
+	var r0 *models.Page[models.Album]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.AlbumFilters, int, int) (*models.Page[models.Album], error)); ok {
+		return rf(ctx, filters, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.AlbumFilters, int, int) *models.Page[models.Album]); ok {
+		r0 = rf(ctx, filters, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Page[models.Album])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.AlbumFilters, int, int) error); ok {
+		r1 = rf(ctx, filters, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTracks provides a mock function with given fields: ctx, albumID
+func (_m *AlbumService) GetTracks(ctx context.Context, albumID string) ([]models.AlbumTrack, error) {
+	ret := _m.Called(ctx, albumID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTracks")
+	}
+
+	var r0 []models.AlbumTrack
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.AlbumTrack, error)); ok {
+		return rf(ctx, albumID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.AlbumTrack); ok {
+		r0 = rf(ctx, albumID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.AlbumTrack)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, albumID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewAlbumService creates a new instance of AlbumService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewAlbumService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *AlbumService {
+	mock := &AlbumService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
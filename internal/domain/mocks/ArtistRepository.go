@@ -0,0 +1,225 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Seven11Eleven/music_library/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// ArtistRepository is an autogenerated mock type for the ArtistRepository type
+type ArtistRepository struct {
+	mock.Mock
+}
+
+// CountArtists provides a mock function with given fields: ctx, filters
+func (_m *ArtistRepository) CountArtists(ctx context.Context, filters models.ArtistFilters) (int, error) {
+	ret := _m.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountArtists")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ArtistFilters) (int, error)); ok {
+		return rf(ctx, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ArtistFilters) int); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ArtistFilters) error); ok {
+		r1 = rf(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateArtist provides a mock function with given fields: ctx, artist
+func (_m *ArtistRepository) CreateArtist(ctx context.Context, artist *models.Artist) (*models.Artist, error) {
+	ret := _m.Called(ctx, artist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateArtist")
+	}
+
+	var r0 *models.Artist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Artist) (*models.Artist, error)); ok {
+		return rf(ctx, artist)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.Artist) *models.Artist); ok {
+		r0 = rf(ctx, artist)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Artist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.Artist) error); ok {
+		r1 = rf(ctx, artist)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteArtist provides a mock function with given fields: ctx, artistID
+func (_m *ArtistRepository) DeleteArtist(ctx context.Context, artistID string) error {
+	ret := _m.Called(ctx, artistID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteArtist")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, artistID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetArtist provides a mock function with given fields: ctx, artistID
+func (_m *ArtistRepository) GetArtist(ctx context.Context, artistID string) (*models.Artist, error) {
+	ret := _m.Called(ctx, artistID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtist")
+	}
+
+	var r0 *models.Artist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Artist, error)); ok {
+		return rf(ctx, artistID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Artist); ok {
+		r0 = rf(ctx, artistID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Artist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, artistID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetArtistByName provides a mock function with given fields: ctx, name
+func (_m *ArtistRepository) GetArtistByName(ctx context.Context, name string) (*models.Artist, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtistByName")
+	}
+
+	var r0 *models.Artist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Artist, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Artist); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Artist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetArtists provides a mock function with given fields: ctx, filters, page, pageSize
+func (_m *ArtistRepository) GetArtists(ctx context.Context, filters models.ArtistFilters, page int, pageSize int) ([]models.Artist, error) {
+	ret := _m.Called(ctx, filters, page, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetArtists")
+	}
+
+	var r0 []models.Artist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.ArtistFilters, int, int) ([]models.Artist, error)); ok {
+		return rf(ctx, filters, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.ArtistFilters, int, int) []models.Artist); ok {
+		r0 = rf(ctx, filters, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Artist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.ArtistFilters, int, int) error); ok {
+		r1 = rf(ctx, filters, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateArtist provides a mock function with given fields: ctx, artist
+func (_m *ArtistRepository) UpdateArtist(ctx context.Context, artist models.Artist) (*models.Artist, error) {
+	ret := _m.Called(ctx, artist)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateArtist")
+	}
+
+	var r0 *models.Artist
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Artist) (*models.Artist, error)); ok {
+		return rf(ctx, artist)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Artist) *models.Artist); ok {
+		r0 = rf(ctx, artist)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Artist)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Artist) error); ok {
+		r1 = rf(ctx, artist)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewArtistRepository creates a new instance of ArtistRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewArtistRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *ArtistRepository {
+	mock := &ArtistRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
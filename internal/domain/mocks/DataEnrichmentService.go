@@ -7,6 +7,8 @@ import (
 
 	models "github.com/Seven11Eleven/music_library/internal/domain/models"
 	mock "github.com/stretchr/testify/mock"
+
+	service "github.com/Seven11Eleven/music_library/internal/service"
 )
 
 // DataEnrichmentService is an autogenerated mock type for the DataEnrichmentService type
@@ -44,6 +46,26 @@ func (_m *DataEnrichmentService) FetchEnrichedMusic(ctx context.Context, groupNa
 	return r0, r1
 }
 
+// ProviderStatuses provides a mock function with no fields
+func (_m *DataEnrichmentService) ProviderStatuses() []service.ProviderStatus {
+	ret := _m.Called()
+
+	if len(ret) == 0 {
+		panic("no return value specified for ProviderStatuses")
+	}
+
+	var r0 []service.ProviderStatus
+	if rf, ok := ret.Get(0).(func() []service.ProviderStatus); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]service.ProviderStatus)
+		}
+	}
+
+	return r0
+}
+
 // NewDataEnrichmentService creates a new instance of DataEnrichmentService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
 // The first argument is typically a *testing.T value.
 func NewDataEnrichmentService(t interface {
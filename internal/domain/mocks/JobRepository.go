@@ -0,0 +1,175 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Seven11Eleven/music_library/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+
+	time "time"
+)
+
+// JobRepository is an autogenerated mock type for the JobRepository type
+type JobRepository struct {
+	mock.Mock
+}
+
+// ClaimJob provides a mock function with given fields: ctx, lease
+func (_m *JobRepository) ClaimJob(ctx context.Context, lease time.Duration) (*models.EnrichmentJob, error) {
+	ret := _m.Called(ctx, lease)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ClaimJob")
+	}
+
+	var r0 *models.EnrichmentJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) (*models.EnrichmentJob, error)); ok {
+		return rf(ctx, lease)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Duration) *models.EnrichmentJob); ok {
+		r0 = rf(ctx, lease)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EnrichmentJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Duration) error); ok {
+		r1 = rf(ctx, lease)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CompleteJob provides a mock function with given fields: ctx, jobID, result
+func (_m *JobRepository) CompleteJob(ctx context.Context, jobID string, result *models.Music) error {
+	ret := _m.Called(ctx, jobID, result)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CompleteJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *models.Music) error); ok {
+		r0 = rf(ctx, jobID, result)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CreateJob provides a mock function with given fields: ctx, query, maxAttempts
+func (_m *JobRepository) CreateJob(ctx context.Context, query models.MusicQuery, maxAttempts int) (*models.EnrichmentJob, error) {
+	ret := _m.Called(ctx, query, maxAttempts)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateJob")
+	}
+
+	var r0 *models.EnrichmentJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.MusicQuery, int) (*models.EnrichmentJob, error)); ok {
+		return rf(ctx, query, maxAttempts)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.MusicQuery, int) *models.EnrichmentJob); ok {
+		r0 = rf(ctx, query, maxAttempts)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EnrichmentJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.MusicQuery, int) error); ok {
+		r1 = rf(ctx, query, maxAttempts)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FailJob provides a mock function with given fields: ctx, jobID, jobErr, retryAt
+func (_m *JobRepository) FailJob(ctx context.Context, jobID string, jobErr string, retryAt *time.Time) error {
+	ret := _m.Called(ctx, jobID, jobErr, retryAt)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FailJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *time.Time) error); ok {
+		r0 = rf(ctx, jobID, jobErr, retryAt)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// GetJob provides a mock function with given fields: ctx, jobID
+func (_m *JobRepository) GetJob(ctx context.Context, jobID string) (*models.EnrichmentJob, error) {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetJob")
+	}
+
+	var r0 *models.EnrichmentJob
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.EnrichmentJob, error)); ok {
+		return rf(ctx, jobID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.EnrichmentJob); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.EnrichmentJob)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, jobID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReleaseJob provides a mock function with given fields: ctx, jobID
+func (_m *JobRepository) ReleaseJob(ctx context.Context, jobID string) error {
+	ret := _m.Called(ctx, jobID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReleaseJob")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, jobID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// NewJobRepository creates a new instance of JobRepository. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewJobRepository(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *JobRepository {
+	mock := &JobRepository{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
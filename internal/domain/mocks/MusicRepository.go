@@ -7,6 +7,8 @@ import (
 
 	models "github.com/Seven11Eleven/music_library/internal/domain/models"
 	mock "github.com/stretchr/testify/mock"
+
+	time "time"
 )
 
 // MusicRepository is an autogenerated mock type for the MusicRepository type
@@ -14,17 +16,101 @@ type MusicRepository struct {
 	mock.Mock
 }
 
-// DeleteMusic provides a mock function with given fields: ctx, musicID
-func (_m *MusicRepository) DeleteMusic(ctx context.Context, musicID string) error {
-	ret := _m.Called(ctx, musicID)
+// CountMusicsByFilters provides a mock function with given fields: ctx, filters
+func (_m *MusicRepository) CountMusicsByFilters(ctx context.Context, filters models.MusicFilters) (int, error) {
+	ret := _m.Called(ctx, filters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountMusicsByFilters")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.MusicFilters) (int, error)); ok {
+		return rf(ctx, filters)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.MusicFilters) int); ok {
+		r0 = rf(ctx, filters)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.MusicFilters) error); ok {
+		r1 = rf(ctx, filters)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountSearchResults provides a mock function with given fields: ctx, query
+func (_m *MusicRepository) CountSearchResults(ctx context.Context, query string) (int, error) {
+	ret := _m.Called(ctx, query)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountSearchResults")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (int, error)); ok {
+		return rf(ctx, query)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) int); ok {
+		r0 = rf(ctx, query)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, query)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CountTrash provides a mock function with given fields: ctx
+func (_m *MusicRepository) CountTrash(ctx context.Context) (int, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CountTrash")
+	}
+
+	var r0 int
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteMusic provides a mock function with given fields: ctx, musicID, expectedVersion
+func (_m *MusicRepository) DeleteMusic(ctx context.Context, musicID string, expectedVersion *int) error {
+	ret := _m.Called(ctx, musicID, expectedVersion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for DeleteMusic")
 	}
 
 	var r0 error
-	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
-		r0 = rf(ctx, musicID)
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) error); ok {
+		r0 = rf(ctx, musicID, expectedVersion)
 	} else {
 		r0 = ret.Error(0)
 	}
@@ -32,6 +118,126 @@ func (_m *MusicRepository) DeleteMusic(ctx context.Context, musicID string) erro
 	return r0
 }
 
+// DeleteVerse provides a mock function with given fields: ctx, musicID, number, expectedVersion
+func (_m *MusicRepository) DeleteVerse(ctx context.Context, musicID string, number int, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, number, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVerse")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, number, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *int) *models.Music); ok {
+		r0 = rf(ctx, musicID, number, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, *int) error); ok {
+		r1 = rf(ctx, musicID, number, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindDuplicatePairs provides a mock function with given fields: ctx, threshold
+func (_m *MusicRepository) FindDuplicatePairs(ctx context.Context, threshold float64) ([]models.DuplicatePair, error) {
+	ret := _m.Called(ctx, threshold)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindDuplicatePairs")
+	}
+
+	var r0 []models.DuplicatePair
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, float64) ([]models.DuplicatePair, error)); ok {
+		return rf(ctx, threshold)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, float64) []models.DuplicatePair); ok {
+		r0 = rf(ctx, threshold)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DuplicatePair)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, float64) error); ok {
+		r1 = rf(ctx, threshold)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// FindSimilarMusic provides a mock function with given fields: ctx, songName, groupName, threshold, limit
+func (_m *MusicRepository) FindSimilarMusic(ctx context.Context, songName string, groupName string, threshold float64, limit int) ([]models.SimilarMusic, error) {
+	ret := _m.Called(ctx, songName, groupName, threshold, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for FindSimilarMusic")
+	}
+
+	var r0 []models.SimilarMusic
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float64, int) ([]models.SimilarMusic, error)); ok {
+		return rf(ctx, songName, groupName, threshold, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, float64, int) []models.SimilarMusic); ok {
+		r0 = rf(ctx, songName, groupName, threshold, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SimilarMusic)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, float64, int) error); ok {
+		r1 = rf(ctx, songName, groupName, threshold, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuplicateClusters provides a mock function with given fields: ctx
+func (_m *MusicRepository) GetDuplicateClusters(ctx context.Context) ([]models.DuplicateCluster, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuplicateClusters")
+	}
+
+	var r0 []models.DuplicateCluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.DuplicateCluster, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.DuplicateCluster); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DuplicateCluster)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // GetMusic provides a mock function with given fields: ctx, musicName, groupName
 func (_m *MusicRepository) GetMusic(ctx context.Context, musicName string, groupName string) (*models.Music, error) {
 	ret := _m.Called(ctx, musicName, groupName)
@@ -62,9 +268,39 @@ func (_m *MusicRepository) GetMusic(ctx context.Context, musicName string, group
 	return r0, r1
 }
 
-// GetMusicTextWithPaginationByVerse provides a mock function with given fields: ctx, musicID, limit, offset
-func (_m *MusicRepository) GetMusicTextWithPaginationByVerse(ctx context.Context, musicID string, limit int, offset int) (*models.Music, error) {
-	ret := _m.Called(ctx, musicID, limit, offset)
+// GetMusicByID provides a mock function with given fields: ctx, musicID, withVerses
+func (_m *MusicRepository) GetMusicByID(ctx context.Context, musicID string, withVerses bool) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, withVerses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMusicByID")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*models.Music, error)); ok {
+		return rf(ctx, musicID, withVerses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *models.Music); ok {
+		r0 = rf(ctx, musicID, withVerses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, musicID, withVerses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMusicTextWithPaginationByVerse provides a mock function with given fields: ctx, musicID, filters, limit, offset
+func (_m *MusicRepository) GetMusicTextWithPaginationByVerse(ctx context.Context, musicID string, filters models.VerseFilters, limit int, offset int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, filters, limit, offset)
 
 	if len(ret) == 0 {
 		panic("no return value specified for GetMusicTextWithPaginationByVerse")
@@ -72,19 +308,49 @@ func (_m *MusicRepository) GetMusicTextWithPaginationByVerse(ctx context.Context
 
 	var r0 *models.Music
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (*models.Music, error)); ok {
-		return rf(ctx, musicID, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VerseFilters, int, int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, filters, limit, offset)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) *models.Music); ok {
-		r0 = rf(ctx, musicID, limit, offset)
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VerseFilters, int, int) *models.Music); ok {
+		r0 = rf(ctx, musicID, filters, limit, offset)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*models.Music)
 		}
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
-		r1 = rf(ctx, musicID, limit, offset)
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.VerseFilters, int, int) error); ok {
+		r1 = rf(ctx, musicID, filters, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMusicsAfter provides a mock function with given fields: ctx, filters, after, limit
+func (_m *MusicRepository) GetMusicsAfter(ctx context.Context, filters models.MusicFilters, after models.Cursor, limit int) ([]models.Music, error) {
+	ret := _m.Called(ctx, filters, after, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMusicsAfter")
+	}
+
+	var r0 []models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.MusicFilters, models.Cursor, int) ([]models.Music, error)); ok {
+		return rf(ctx, filters, after, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.MusicFilters, models.Cursor, int) []models.Music); ok {
+		r0 = rf(ctx, filters, after, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.MusicFilters, models.Cursor, int) error); ok {
+		r1 = rf(ctx, filters, after, limit)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -122,6 +388,412 @@ func (_m *MusicRepository) GetMusicsByFilters(ctx context.Context, filters model
 	return r0, r1
 }
 
+// GetRevision provides a mock function with given fields: ctx, musicID, revision
+func (_m *MusicRepository) GetRevision(ctx context.Context, musicID string, revision int) (*models.Revision, error) {
+	ret := _m.Called(ctx, musicID, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevision")
+	}
+
+	var r0 *models.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*models.Revision, error)); ok {
+		return rf(ctx, musicID, revision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *models.Revision); ok {
+		r0 = rf(ctx, musicID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, musicID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: ctx, musicID
+func (_m *MusicRepository) GetRevisions(ctx context.Context, musicID string) ([]models.Revision, error) {
+	ret := _m.Called(ctx, musicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisions")
+	}
+
+	var r0 []models.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Revision, error)); ok {
+		return rf(ctx, musicID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Revision); ok {
+		r0 = rf(ctx, musicID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, musicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTimedLyrics provides a mock function with given fields: ctx, musicID
+func (_m *MusicRepository) GetTimedLyrics(ctx context.Context, musicID string) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTimedLyrics")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Music, error)); ok {
+		return rf(ctx, musicID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Music); ok {
+		r0 = rf(ctx, musicID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, musicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrash provides a mock function with given fields: ctx, page, pageSize
+func (_m *MusicRepository) GetTrash(ctx context.Context, page int, pageSize int) ([]models.Music, error) {
+	ret := _m.Called(ctx, page, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrash")
+	}
+
+	var r0 []models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) ([]models.Music, error)); ok {
+		return rf(ctx, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) []models.Music); ok {
+		r0 = rf(ctx, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVersesAfter provides a mock function with given fields: ctx, musicID, filters, afterNumber, limit
+func (_m *MusicRepository) GetVersesAfter(ctx context.Context, musicID string, filters models.VerseFilters, afterNumber int, limit int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, filters, afterNumber, limit)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVersesAfter")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VerseFilters, int, int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, filters, afterNumber, limit)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VerseFilters, int, int) *models.Music); ok {
+		r0 = rf(ctx, musicID, filters, afterNumber, limit)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.VerseFilters, int, int) error); ok {
+		r1 = rf(ctx, musicID, filters, afterNumber, limit)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertVerse provides a mock function with given fields: ctx, musicID, verse, expectedVersion
+func (_m *MusicRepository) InsertVerse(ctx context.Context, musicID string, verse models.VerseInsert, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, verse, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertVerse")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VerseInsert, *int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, verse, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VerseInsert, *int) *models.Music); ok {
+		r0 = rf(ctx, musicID, verse, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.VerseInsert, *int) error); ok {
+		r1 = rf(ctx, musicID, verse, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MergeMusic provides a mock function with given fields: ctx, survivorID, mergeIDs, expectedVersion
+func (_m *MusicRepository) MergeMusic(ctx context.Context, survivorID string, mergeIDs []string, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, survivorID, mergeIDs, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeMusic")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, *int) (*models.Music, error)); ok {
+		return rf(ctx, survivorID, mergeIDs, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, *int) *models.Music); ok {
+		r0 = rf(ctx, survivorID, mergeIDs, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, *int) error); ok {
+		r1 = rf(ctx, survivorID, mergeIDs, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchMusic provides a mock function with given fields: ctx, musicID, patch, expectedVersion
+func (_m *MusicRepository) PatchMusic(ctx context.Context, musicID string, patch models.MusicPatch, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, patch, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchMusic")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.MusicPatch, *int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, patch, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.MusicPatch, *int) *models.Music); ok {
+		r0 = rf(ctx, musicID, patch, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.MusicPatch, *int) error); ok {
+		r1 = rf(ctx, musicID, patch, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeDeletedMusic provides a mock function with given fields: ctx, deletedBefore
+func (_m *MusicRepository) PurgeDeletedMusic(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	ret := _m.Called(ctx, deletedBefore)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeDeletedMusic")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) (int64, error)); ok {
+		return rf(ctx, deletedBefore)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time) int64); ok {
+		r0 = rf(ctx, deletedBefore)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, time.Time) error); ok {
+		r1 = rf(ctx, deletedBefore)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReorderVerses provides a mock function with given fields: ctx, musicID, order, expectedVersion
+func (_m *MusicRepository) ReorderVerses(ctx context.Context, musicID string, order []int, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, order, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderVerses")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, *int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, order, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, *int) *models.Music); ok {
+		r0 = rf(ctx, musicID, order, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []int, *int) error); ok {
+		r1 = rf(ctx, musicID, order, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReplaceDuplicateClusters provides a mock function with given fields: ctx, clusters
+func (_m *MusicRepository) ReplaceDuplicateClusters(ctx context.Context, clusters []models.DuplicateCluster) error {
+	ret := _m.Called(ctx, clusters)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceDuplicateClusters")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []models.DuplicateCluster) error); ok {
+		r0 = rf(ctx, clusters)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// ReplaceVerses provides a mock function with given fields: ctx, musicID, verses, expectedVersion
+func (_m *MusicRepository) ReplaceVerses(ctx context.Context, musicID string, verses []models.Verse, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, verses, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReplaceVerses")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []models.Verse, *int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, verses, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []models.Verse, *int) *models.Music); ok {
+		r0 = rf(ctx, musicID, verses, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []models.Verse, *int) error); ok {
+		r1 = rf(ctx, musicID, verses, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreMusic provides a mock function with given fields: ctx, musicID
+func (_m *MusicRepository) RestoreMusic(ctx context.Context, musicID string) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreMusic")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Music, error)); ok {
+		return rf(ctx, musicID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Music); ok {
+		r0 = rf(ctx, musicID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, musicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreRevision provides a mock function with given fields: ctx, musicID, revision, expectedVersion
+func (_m *MusicRepository) RestoreRevision(ctx context.Context, musicID string, revision int, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, revision, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreRevision")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, revision, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *int) *models.Music); ok {
+		r0 = rf(ctx, musicID, revision, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, *int) error); ok {
+		r1 = rf(ctx, musicID, revision, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // SaveMusic provides a mock function with given fields: ctx, music
 func (_m *MusicRepository) SaveMusic(ctx context.Context, music *models.Music) (*models.Music, error) {
 	ret := _m.Called(ctx, music)
@@ -152,9 +824,57 @@ func (_m *MusicRepository) SaveMusic(ctx context.Context, music *models.Music) (
 	return r0, r1
 }
 
-// UpdateMusic provides a mock function with given fields: ctx, music
-func (_m *MusicRepository) UpdateMusic(ctx context.Context, music models.Music) (models.Music, error) {
-	ret := _m.Called(ctx, music)
+// SearchMusic provides a mock function with given fields: ctx, query, limit, offset
+func (_m *MusicRepository) SearchMusic(ctx context.Context, query string, limit int, offset int) ([]models.SearchResult, error) {
+	ret := _m.Called(ctx, query, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchMusic")
+	}
+
+	var r0 []models.SearchResult
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) ([]models.SearchResult, error)); ok {
+		return rf(ctx, query, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) []models.SearchResult); ok {
+		r0 = rf(ctx, query, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.SearchResult)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, query, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SetSearchLanguages provides a mock function with given fields: ctx, languages
+func (_m *MusicRepository) SetSearchLanguages(ctx context.Context, languages []string) error {
+	ret := _m.Called(ctx, languages)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SetSearchLanguages")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []string) error); ok {
+		r0 = rf(ctx, languages)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpdateMusic provides a mock function with given fields: ctx, music, expectedVersion
+func (_m *MusicRepository) UpdateMusic(ctx context.Context, music models.Music, expectedVersion *int) (models.Music, error) {
+	ret := _m.Called(ctx, music, expectedVersion)
 
 	if len(ret) == 0 {
 		panic("no return value specified for UpdateMusic")
@@ -162,17 +882,17 @@ func (_m *MusicRepository) UpdateMusic(ctx context.Context, music models.Music)
 
 	var r0 models.Music
 	var r1 error
-	if rf, ok := ret.Get(0).(func(context.Context, models.Music) (models.Music, error)); ok {
-		return rf(ctx, music)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Music, *int) (models.Music, error)); ok {
+		return rf(ctx, music, expectedVersion)
 	}
-	if rf, ok := ret.Get(0).(func(context.Context, models.Music) models.Music); ok {
-		r0 = rf(ctx, music)
+	if rf, ok := ret.Get(0).(func(context.Context, models.Music, *int) models.Music); ok {
+		r0 = rf(ctx, music, expectedVersion)
 	} else {
 		r0 = ret.Get(0).(models.Music)
 	}
 
-	if rf, ok := ret.Get(1).(func(context.Context, models.Music) error); ok {
-		r1 = rf(ctx, music)
+	if rf, ok := ret.Get(1).(func(context.Context, models.Music, *int) error); ok {
+		r1 = rf(ctx, music, expectedVersion)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -0,0 +1,731 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Seven11Eleven/music_library/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// MusicService is an autogenerated mock type for the MusicService type
+type MusicService struct {
+	mock.Mock
+}
+
+// DeleteMusic provides a mock function with given fields: ctx, musicID, expectedVersion
+func (_m *MusicService) DeleteMusic(ctx context.Context, musicID string, expectedVersion *int) error {
+	ret := _m.Called(ctx, musicID, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteMusic")
+	}
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, *int) error); ok {
+		r0 = rf(ctx, musicID, expectedVersion)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// DeleteVerse provides a mock function with given fields: ctx, musicID, number, expectedVersion
+func (_m *MusicService) DeleteVerse(ctx context.Context, musicID string, number int, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, number, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for DeleteVerse")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, number, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *int) *models.Music); ok {
+		r0 = rf(ctx, musicID, number, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, *int) error); ok {
+		r1 = rf(ctx, musicID, number, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExportLRC provides a mock function with given fields: ctx, musicID
+func (_m *MusicService) ExportLRC(ctx context.Context, musicID string) (string, error) {
+	ret := _m.Called(ctx, musicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ExportLRC")
+	}
+
+	var r0 string
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (string, error)); ok {
+		return rf(ctx, musicID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) string); ok {
+		r0 = rf(ctx, musicID)
+	} else {
+		r0 = ret.Get(0).(string)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, musicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetDuplicates provides a mock function with given fields: ctx
+func (_m *MusicService) GetDuplicates(ctx context.Context) ([]models.DuplicateCluster, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetDuplicates")
+	}
+
+	var r0 []models.DuplicateCluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.DuplicateCluster, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.DuplicateCluster); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DuplicateCluster)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMusicByID provides a mock function with given fields: ctx, musicID, withVerses
+func (_m *MusicService) GetMusicByID(ctx context.Context, musicID string, withVerses bool) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, withVerses)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMusicByID")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) (*models.Music, error)); ok {
+		return rf(ctx, musicID, withVerses)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, bool) *models.Music); ok {
+		r0 = rf(ctx, musicID, withVerses)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, bool) error); ok {
+		r1 = rf(ctx, musicID, withVerses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMusicTextWithPaginationByVerse provides a mock function with given fields: ctx, musicID, filters, limit, offset
+func (_m *MusicService) GetMusicTextWithPaginationByVerse(ctx context.Context, musicID string, filters models.VerseFilters, limit int, offset int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, filters, limit, offset)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMusicTextWithPaginationByVerse")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VerseFilters, int, int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, filters, limit, offset)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VerseFilters, int, int) *models.Music); ok {
+		r0 = rf(ctx, musicID, filters, limit, offset)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.VerseFilters, int, int) error); ok {
+		r1 = rf(ctx, musicID, filters, limit, offset)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMusicsByCursor provides a mock function with given fields: ctx, filters, cursor, pageSize
+func (_m *MusicService) GetMusicsByCursor(ctx context.Context, filters models.MusicFilters, cursor string, pageSize int) (*models.CursorPage[models.Music], error) {
+	ret := _m.Called(ctx, filters, cursor, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMusicsByCursor")
+	}
+
+	var r0 *models.CursorPage[models.Music]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.MusicFilters, string, int) (*models.CursorPage[models.Music], error)); ok {
+		return rf(ctx, filters, cursor, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.MusicFilters, string, int) *models.CursorPage[models.Music]); ok {
+		r0 = rf(ctx, filters, cursor, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.CursorPage[models.Music])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.MusicFilters, string, int) error); ok {
+		r1 = rf(ctx, filters, cursor, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMusicsByFilters provides a mock function with given fields: ctx, filters, page, pageSize
+func (_m *MusicService) GetMusicsByFilters(ctx context.Context, filters models.MusicFilters, page int, pageSize int) (*models.Page[models.Music], error) {
+	ret := _m.Called(ctx, filters, page, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMusicsByFilters")
+	}
+
+	var r0 *models.Page[models.Music]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.MusicFilters, int, int) (*models.Page[models.Music], error)); ok {
+		return rf(ctx, filters, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.MusicFilters, int, int) *models.Page[models.Music]); ok {
+		r0 = rf(ctx, filters, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Page[models.Music])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.MusicFilters, int, int) error); ok {
+		r1 = rf(ctx, filters, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevision provides a mock function with given fields: ctx, musicID, revision
+func (_m *MusicService) GetRevision(ctx context.Context, musicID string, revision int) (*models.Revision, error) {
+	ret := _m.Called(ctx, musicID, revision)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevision")
+	}
+
+	var r0 *models.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) (*models.Revision, error)); ok {
+		return rf(ctx, musicID, revision)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) *models.Revision); ok {
+		r0 = rf(ctx, musicID, revision)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, musicID, revision)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetRevisions provides a mock function with given fields: ctx, musicID
+func (_m *MusicService) GetRevisions(ctx context.Context, musicID string) ([]models.Revision, error) {
+	ret := _m.Called(ctx, musicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetRevisions")
+	}
+
+	var r0 []models.Revision
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) ([]models.Revision, error)); ok {
+		return rf(ctx, musicID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) []models.Revision); ok {
+		r0 = rf(ctx, musicID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Revision)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, musicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTrash provides a mock function with given fields: ctx, page, pageSize
+func (_m *MusicService) GetTrash(ctx context.Context, page int, pageSize int) (*models.Page[models.Music], error) {
+	ret := _m.Called(ctx, page, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTrash")
+	}
+
+	var r0 *models.Page[models.Music]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*models.Page[models.Music], error)); ok {
+		return rf(ctx, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *models.Page[models.Music]); ok {
+		r0 = rf(ctx, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Page[models.Music])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetVersesByCursor provides a mock function with given fields: ctx, musicID, filters, cursor, pageSize
+func (_m *MusicService) GetVersesByCursor(ctx context.Context, musicID string, filters models.VerseFilters, cursor string, pageSize int) (*models.VersePage, error) {
+	ret := _m.Called(ctx, musicID, filters, cursor, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetVersesByCursor")
+	}
+
+	var r0 *models.VersePage
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VerseFilters, string, int) (*models.VersePage, error)); ok {
+		return rf(ctx, musicID, filters, cursor, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VerseFilters, string, int) *models.VersePage); ok {
+		r0 = rf(ctx, musicID, filters, cursor, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.VersePage)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.VerseFilters, string, int) error); ok {
+		r1 = rf(ctx, musicID, filters, cursor, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ImportLRC provides a mock function with given fields: ctx, musicID, lrc, expectedVersion
+func (_m *MusicService) ImportLRC(ctx context.Context, musicID string, lrc string, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, lrc, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ImportLRC")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, lrc, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, *int) *models.Music); ok {
+		r0 = rf(ctx, musicID, lrc, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, *int) error); ok {
+		r1 = rf(ctx, musicID, lrc, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// InsertVerse provides a mock function with given fields: ctx, musicID, verse, expectedVersion
+func (_m *MusicService) InsertVerse(ctx context.Context, musicID string, verse models.VerseInsert, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, verse, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for InsertVerse")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VerseInsert, *int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, verse, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.VerseInsert, *int) *models.Music); ok {
+		r0 = rf(ctx, musicID, verse, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.VerseInsert, *int) error); ok {
+		r1 = rf(ctx, musicID, verse, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// MergeMusic provides a mock function with given fields: ctx, survivorID, mergeIDs, expectedVersion
+func (_m *MusicService) MergeMusic(ctx context.Context, survivorID string, mergeIDs []string, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, survivorID, mergeIDs, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for MergeMusic")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, *int) (*models.Music, error)); ok {
+		return rf(ctx, survivorID, mergeIDs, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string, *int) *models.Music); ok {
+		r0 = rf(ctx, survivorID, mergeIDs, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string, *int) error); ok {
+		r1 = rf(ctx, survivorID, mergeIDs, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PatchMusic provides a mock function with given fields: ctx, musicID, patchType, patch, expectedVersion
+func (_m *MusicService) PatchMusic(ctx context.Context, musicID string, patchType models.PatchType, patch []byte, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, patchType, patch, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PatchMusic")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PatchType, []byte, *int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, patchType, patch, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, models.PatchType, []byte, *int) *models.Music); ok {
+		r0 = rf(ctx, musicID, patchType, patch, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, models.PatchType, []byte, *int) error); ok {
+		r1 = rf(ctx, musicID, patchType, patch, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PurgeTrash provides a mock function with given fields: ctx
+func (_m *MusicService) PurgeTrash(ctx context.Context) (int64, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for PurgeTrash")
+	}
+
+	var r0 int64
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) (int64, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) int64); ok {
+		r0 = rf(ctx)
+	} else {
+		r0 = ret.Get(0).(int64)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ReorderVerses provides a mock function with given fields: ctx, musicID, order, expectedVersion
+func (_m *MusicService) ReorderVerses(ctx context.Context, musicID string, order []int, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, order, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ReorderVerses")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, *int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, order, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []int, *int) *models.Music); ok {
+		r0 = rf(ctx, musicID, order, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []int, *int) error); ok {
+		r1 = rf(ctx, musicID, order, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreMusic provides a mock function with given fields: ctx, musicID
+func (_m *MusicService) RestoreMusic(ctx context.Context, musicID string) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreMusic")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Music, error)); ok {
+		return rf(ctx, musicID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Music); ok {
+		r0 = rf(ctx, musicID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, musicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RestoreRevision provides a mock function with given fields: ctx, musicID, revision, expectedVersion
+func (_m *MusicService) RestoreRevision(ctx context.Context, musicID string, revision int, expectedVersion *int) (*models.Music, error) {
+	ret := _m.Called(ctx, musicID, revision, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RestoreRevision")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *int) (*models.Music, error)); ok {
+		return rf(ctx, musicID, revision, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, *int) *models.Music); ok {
+		r0 = rf(ctx, musicID, revision, expectedVersion)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, *int) error); ok {
+		r1 = rf(ctx, musicID, revision, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SaveMusic provides a mock function with given fields: ctx, music
+func (_m *MusicService) SaveMusic(ctx context.Context, music *models.MusicQuery) (*models.Music, error) {
+	ret := _m.Called(ctx, music)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SaveMusic")
+	}
+
+	var r0 *models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, *models.MusicQuery) (*models.Music, error)); ok {
+		return rf(ctx, music)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, *models.MusicQuery) *models.Music); ok {
+		r0 = rf(ctx, music)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Music)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, *models.MusicQuery) error); ok {
+		r1 = rf(ctx, music)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ScanDuplicates provides a mock function with given fields: ctx
+func (_m *MusicService) ScanDuplicates(ctx context.Context) ([]models.DuplicateCluster, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for ScanDuplicates")
+	}
+
+	var r0 []models.DuplicateCluster
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.DuplicateCluster, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.DuplicateCluster); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.DuplicateCluster)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// SearchMusic provides a mock function with given fields: ctx, query, page, pageSize
+func (_m *MusicService) SearchMusic(ctx context.Context, query string, page int, pageSize int) (*models.Page[models.SearchResult], error) {
+	ret := _m.Called(ctx, query, page, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for SearchMusic")
+	}
+
+	var r0 *models.Page[models.SearchResult]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) (*models.Page[models.SearchResult], error)); ok {
+		return rf(ctx, query, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, int, int) *models.Page[models.SearchResult]); ok {
+		r0 = rf(ctx, query, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Page[models.SearchResult])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, int, int) error); ok {
+		r1 = rf(ctx, query, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpdateMusic provides a mock function with given fields: ctx, music, expectedVersion
+func (_m *MusicService) UpdateMusic(ctx context.Context, music models.Music, expectedVersion *int) (models.Music, error) {
+	ret := _m.Called(ctx, music, expectedVersion)
+
+	if len(ret) == 0 {
+		panic("no return value specified for UpdateMusic")
+	}
+
+	var r0 models.Music
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, models.Music, *int) (models.Music, error)); ok {
+		return rf(ctx, music, expectedVersion)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, models.Music, *int) models.Music); ok {
+		r0 = rf(ctx, music, expectedVersion)
+	} else {
+		r0 = ret.Get(0).(models.Music)
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, models.Music, *int) error); ok {
+		r1 = rf(ctx, music, expectedVersion)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewMusicService creates a new instance of MusicService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewMusicService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *MusicService {
+	mock := &MusicService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,269 @@
+// Code generated by mockery v2.44.1. DO NOT EDIT.
+
+package mocks
+
+import (
+	context "context"
+
+	models "github.com/Seven11Eleven/music_library/internal/domain/models"
+	mock "github.com/stretchr/testify/mock"
+)
+
+// TagService is an autogenerated mock type for the TagService type
+type TagService struct {
+	mock.Mock
+}
+
+// AddMusicGenre provides a mock function with given fields: ctx, musicID, genre
+func (_m *TagService) AddMusicGenre(ctx context.Context, musicID string, genre string) (*models.MusicTags, error) {
+	ret := _m.Called(ctx, musicID, genre)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMusicGenre")
+	}
+
+	var r0 *models.MusicTags
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.MusicTags, error)); ok {
+		return rf(ctx, musicID, genre)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.MusicTags); ok {
+		r0 = rf(ctx, musicID, genre)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MusicTags)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, musicID, genre)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddMusicTags provides a mock function with given fields: ctx, musicID, tags
+func (_m *TagService) AddMusicTags(ctx context.Context, musicID string, tags []string) (*models.MusicTags, error) {
+	ret := _m.Called(ctx, musicID, tags)
+
+	if len(ret) == 0 {
+		panic("no return value specified for AddMusicTags")
+	}
+
+	var r0 *models.MusicTags
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) (*models.MusicTags, error)); ok {
+		return rf(ctx, musicID, tags)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, []string) *models.MusicTags); ok {
+		r0 = rf(ctx, musicID, tags)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MusicTags)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, []string) error); ok {
+		r1 = rf(ctx, musicID, tags)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CreateGenre provides a mock function with given fields: ctx, name
+func (_m *TagService) CreateGenre(ctx context.Context, name string) (*models.Genre, error) {
+	ret := _m.Called(ctx, name)
+
+	if len(ret) == 0 {
+		panic("no return value specified for CreateGenre")
+	}
+
+	var r0 *models.Genre
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.Genre, error)); ok {
+		return rf(ctx, name)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.Genre); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Genre)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetGenres provides a mock function with given fields: ctx
+func (_m *TagService) GetGenres(ctx context.Context) ([]models.Genre, error) {
+	ret := _m.Called(ctx)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetGenres")
+	}
+
+	var r0 []models.Genre
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context) ([]models.Genre, error)); ok {
+		return rf(ctx)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context) []models.Genre); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]models.Genre)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetMusicTags provides a mock function with given fields: ctx, musicID
+func (_m *TagService) GetMusicTags(ctx context.Context, musicID string) (*models.MusicTags, error) {
+	ret := _m.Called(ctx, musicID)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetMusicTags")
+	}
+
+	var r0 *models.MusicTags
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) (*models.MusicTags, error)); ok {
+		return rf(ctx, musicID)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string) *models.MusicTags); ok {
+		r0 = rf(ctx, musicID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MusicTags)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, musicID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// GetTags provides a mock function with given fields: ctx, page, pageSize
+func (_m *TagService) GetTags(ctx context.Context, page int, pageSize int) (*models.Page[models.Tag], error) {
+	ret := _m.Called(ctx, page, pageSize)
+
+	if len(ret) == 0 {
+		panic("no return value specified for GetTags")
+	}
+
+	var r0 *models.Page[models.Tag]
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) (*models.Page[models.Tag], error)); ok {
+		return rf(ctx, page, pageSize)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, int, int) *models.Page[models.Tag]); ok {
+		r0 = rf(ctx, page, pageSize)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.Page[models.Tag])
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, int, int) error); ok {
+		r1 = rf(ctx, page, pageSize)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMusicGenre provides a mock function with given fields: ctx, musicID, genre
+func (_m *TagService) RemoveMusicGenre(ctx context.Context, musicID string, genre string) (*models.MusicTags, error) {
+	ret := _m.Called(ctx, musicID, genre)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMusicGenre")
+	}
+
+	var r0 *models.MusicTags
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.MusicTags, error)); ok {
+		return rf(ctx, musicID, genre)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.MusicTags); ok {
+		r0 = rf(ctx, musicID, genre)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MusicTags)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, musicID, genre)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RemoveMusicTag provides a mock function with given fields: ctx, musicID, tag
+func (_m *TagService) RemoveMusicTag(ctx context.Context, musicID string, tag string) (*models.MusicTags, error) {
+	ret := _m.Called(ctx, musicID, tag)
+
+	if len(ret) == 0 {
+		panic("no return value specified for RemoveMusicTag")
+	}
+
+	var r0 *models.MusicTags
+	var r1 error
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) (*models.MusicTags, error)); ok {
+		return rf(ctx, musicID, tag)
+	}
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) *models.MusicTags); ok {
+		r0 = rf(ctx, musicID, tag)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*models.MusicTags)
+		}
+	}
+
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, musicID, tag)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// NewTagService creates a new instance of TagService. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+// The first argument is typically a *testing.T value.
+func NewTagService(t interface {
+	mock.TestingT
+	Cleanup(func())
+}) *TagService {
+	mock := &TagService{}
+	mock.Mock.Test(t)
+
+	t.Cleanup(func() { mock.AssertExpectations(t) })
+
+	return mock
+}
@@ -0,0 +1,61 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrAlbumExists        = NewError(ErrConflict, "album with this title already exists for the artist")
+	ErrTrackPositionTaken = NewError(ErrConflict, "album already has a track at this position")
+	ErrAlbumNotFound      = NewError(ErrNotFound, "album not found")
+)
+
+type Album struct {
+	ID    string `json:"id"`
+	Title string `json:"title"`
+	// GroupName is the name of the artist referenced by ArtistID. On create it can be given instead of ArtistID.
+	GroupName   string       `json:"group_name,omitempty"`
+	ArtistID    string       `json:"artist_id,omitempty"`
+	ReleaseDate *time.Time   `json:"release_date,omitempty"`
+	Link        string       `json:"link,omitempty"`
+	Tracks      []AlbumTrack `json:"tracks,omitempty"`
+}
+
+// AlbumTrack is a song on an album. A TrackNumber of zero means the position is unknown.
+type AlbumTrack struct {
+	MusicID     string `json:"music_id"`
+	SongName    string `json:"song_name,omitempty"`
+	DiscNumber  int    `json:"disc_number"`
+	TrackNumber int    `json:"track_number,omitempty"`
+}
+
+// AlbumRef is the album a song was found on during enrichment.
+type AlbumRef struct {
+	ID          string `json:"id,omitempty"`
+	Title       string `json:"title"`
+	Link        string `json:"link,omitempty"`
+	TrackNumber int    `json:"track_number,omitempty"`
+}
+
+type AlbumFilters struct {
+	Title    *string
+	ArtistID *string
+}
+
+type AlbumRepository interface {
+	CreateAlbum(ctx context.Context, album *Album) (*Album, error)
+	GetAlbum(ctx context.Context, albumID string) (*Album, error)
+	GetAlbums(ctx context.Context, filters AlbumFilters, page, pageSize int) ([]Album, error)
+	CountAlbums(ctx context.Context, filters AlbumFilters) (int, error)
+	AddTrack(ctx context.Context, albumID string, track AlbumTrack) (*AlbumTrack, error)
+	GetTracks(ctx context.Context, albumID string) ([]AlbumTrack, error)
+}
+
+type AlbumService interface {
+	CreateAlbum(ctx context.Context, album *Album) (*Album, error)
+	GetAlbum(ctx context.Context, albumID string) (*Album, error)
+	GetAlbums(ctx context.Context, filters AlbumFilters, page, pageSize int) (*Page[Album], error)
+	AddTrack(ctx context.Context, albumID string, track AlbumTrack) (*AlbumTrack, error)
+	GetTracks(ctx context.Context, albumID string) ([]AlbumTrack, error)
+}
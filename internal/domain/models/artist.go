@@ -0,0 +1,63 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+var (
+	ErrArtistExists   = NewError(ErrConflict, "artist with this name already exists")
+	ErrArtistInUse    = NewError(ErrConflict, "artist still has music")
+	ErrArtistNotFound = NewError(ErrNotFound, "artist not found")
+)
+
+type ArtistRole string
+
+const (
+	RolePrimary  ArtistRole = "primary"
+	RoleFeatured ArtistRole = "featured"
+	RoleComposer ArtistRole = "composer"
+	RoleLyricist ArtistRole = "lyricist"
+)
+
+var ArtistRoles = []ArtistRole{RolePrimary, RoleFeatured, RoleComposer, RoleLyricist}
+
+// MusicArtist credits an artist on a song. Either ArtistID or Name identifies the artist when saving.
+type MusicArtist struct {
+	ArtistID string     `json:"artist_id,omitempty"`
+	Name     string     `json:"name"`
+	Role     ArtistRole `json:"role"`
+}
+
+type Artist struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Country     string    `json:"country,omitempty"`
+	FormedYear  *int      `json:"formed_year,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
+
+type ArtistFilters struct {
+	Name    *string
+	Country *string
+}
+
+type ArtistRepository interface {
+	CreateArtist(ctx context.Context, artist *Artist) (*Artist, error)
+	GetArtist(ctx context.Context, artistID string) (*Artist, error)
+	GetArtistByName(ctx context.Context, name string) (*Artist, error)
+	GetArtists(ctx context.Context, filters ArtistFilters, page, pageSize int) ([]Artist, error)
+	CountArtists(ctx context.Context, filters ArtistFilters) (int, error)
+	UpdateArtist(ctx context.Context, artist Artist) (*Artist, error)
+	DeleteArtist(ctx context.Context, artistID string) error
+}
+
+type ArtistService interface {
+	CreateArtist(ctx context.Context, artist *Artist) (*Artist, error)
+	GetArtist(ctx context.Context, artistID string) (*Artist, error)
+	GetArtists(ctx context.Context, filters ArtistFilters, page, pageSize int) (*Page[Artist], error)
+	UpdateArtist(ctx context.Context, artist Artist) (*Artist, error)
+	DeleteArtist(ctx context.Context, artistID string) error
+}
@@ -0,0 +1,26 @@
+package models
+
+import "time"
+
+// DuplicatePair is a pair of songs with similar names found by a duplicate scan.
+// LyricsSimilarity is nil unless both songs have lyrics.
+type DuplicatePair struct {
+	MusicID          string
+	OtherID          string
+	TitleSimilarity  float64
+	GroupSimilarity  float64
+	LyricsSimilarity *float64
+}
+
+// DuplicateCluster is a group of songs that are likely the same track.
+type DuplicateCluster struct {
+	ID         string    `json:"id"`
+	Music      []Music   `json:"music"`
+	Score      float64   `json:"score"`
+	DetectedAt time.Time `json:"detected_at"`
+}
+
+type MergeRequest struct {
+	// MergeIDs are the songs merged into the surviving one and moved to the trash afterwards.
+	MergeIDs []string `json:"merge_ids" validate:"required,min=1,max=50,dive,required"`
+}
@@ -0,0 +1,87 @@
+package models
+
+import (
+	"errors"
+	"strings"
+)
+
+// Kinds of domain errors. Every error of a kind matches it with errors.Is, so that the API can choose
+// a status code without knowing each error.
+var (
+	ErrNotFound   = errors.New("not found")
+	ErrValidation = errors.New("validation failed")
+	ErrConflict   = errors.New("conflict")
+	ErrUpstream   = errors.New("upstream service failed")
+)
+
+// kindError is an error of one of the kinds above.
+type kindError struct {
+	kind error
+	msg  string
+	err  error
+}
+
+// NewError returns an error of the given kind with message msg.
+func NewError(kind error, msg string) error {
+	return &kindError{kind: kind, msg: msg}
+}
+
+// WrapError marks err as an error of the given kind without changing its message.
+func WrapError(kind error, err error) error {
+	return &kindError{kind: kind, msg: err.Error(), err: err}
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+func (e *kindError) Unwrap() error {
+	return e.err
+}
+
+// FieldError describes why a field of a request is invalid.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationError is an ErrValidation listing the invalid fields.
+type ValidationError struct {
+	Fields []FieldError
+	err    error
+}
+
+// NewValidationError returns a validation error of a single field.
+func NewValidationError(field, message string) error {
+	return NewFieldError(field, errors.New(message))
+}
+
+// NewFieldError returns a validation error of a single field described by err, which it still matches.
+func NewFieldError(field string, err error) error {
+	return &ValidationError{Fields: []FieldError{{Field: field, Message: err.Error()}}, err: err}
+}
+
+// NewValidationErrors returns a validation error listing all invalid fields of a request.
+func NewValidationErrors(fields []FieldError) error {
+	return &ValidationError{Fields: fields}
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, 0, len(e.Fields))
+	for _, field := range e.Fields {
+		messages = append(messages, field.Message)
+	}
+	return strings.Join(messages, "; ")
+}
+
+func (e *ValidationError) Is(target error) bool {
+	return target == ErrValidation
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.err
+}
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+var ErrJobNotFound = NewError(ErrNotFound, "job not found")
+
+type JobStatus string
+
+const (
+	JobPending   JobStatus = "pending"
+	JobRunning   JobStatus = "running"
+	JobSucceeded JobStatus = "succeeded"
+	JobDead      JobStatus = "dead"
+)
+
+type EnrichmentJob struct {
+	ID          string     `json:"id"`
+	Status      JobStatus  `json:"status"`
+	Query       MusicQuery `json:"query"`
+	MusicID     *string    `json:"music_id,omitempty"`
+	Result      *Music     `json:"result,omitempty"`
+	Attempts    int        `json:"attempts"`
+	MaxAttempts int        `json:"max_attempts"`
+	LastError   string     `json:"last_error,omitempty"`
+	RunAt       time.Time  `json:"run_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+	UpdatedAt   time.Time  `json:"updated_at"`
+}
+
+type JobRepository interface {
+	CreateJob(ctx context.Context, query MusicQuery, maxAttempts int) (*EnrichmentJob, error)
+	GetJob(ctx context.Context, jobID string) (*EnrichmentJob, error)
+	ClaimJob(ctx context.Context, lease time.Duration) (*EnrichmentJob, error)
+	CompleteJob(ctx context.Context, jobID string, result *Music) error
+	FailJob(ctx context.Context, jobID string, jobErr string, retryAt *time.Time) error
+	// ReleaseJob returns a claimed job to the queue without counting the attempt.
+	ReleaseJob(ctx context.Context, jobID string) error
+}
+
+type JobService interface {
+	EnqueueMusic(ctx context.Context, music *MusicQuery) (*EnrichmentJob, error)
+	GetJob(ctx context.Context, jobID string) (*EnrichmentJob, error)
+}
@@ -2,26 +2,136 @@ package models
 
 import (
 	"context"
+	"fmt"
 	"time"
 )
 
+var (
+	ErrMusicNotFound     = NewError(ErrNotFound, "music not found")
+	ErrVerseNotFound     = NewError(ErrNotFound, "verse not found")
+	ErrInvalidVerseOrder = NewError(ErrValidation, "order must list every verse number of the song exactly once")
+)
+
+type SectionType string
+
+const (
+	SectionVerse     SectionType = "verse"
+	SectionPreChorus SectionType = "pre-chorus"
+	SectionChorus    SectionType = "chorus"
+	SectionHook      SectionType = "hook"
+	SectionBridge    SectionType = "bridge"
+	SectionIntro     SectionType = "intro"
+	SectionOutro     SectionType = "outro"
+	SectionInterlude SectionType = "interlude"
+	SectionOther     SectionType = "other"
+)
+
+var SectionTypes = []SectionType{
+	SectionVerse, SectionPreChorus, SectionChorus, SectionHook, SectionBridge,
+	SectionIntro, SectionOutro, SectionInterlude, SectionOther,
+}
+
+// TimedWord is a word of an enhanced LRC line with its own start time.
+type TimedWord struct {
+	StartMs int64  `json:"start_ms"`
+	Text    string `json:"text"`
+}
+
+// LyricLine is a single line of a verse with the playback offset it starts at.
+type LyricLine struct {
+	Number  int         `json:"number"`
+	StartMs int64       `json:"start_ms"`
+	Text    string      `json:"text"`
+	Words   []TimedWord `json:"words,omitempty"`
+}
+
 type Verse struct {
-	Text   string `json:"text"`
-	Number int    `json:"number"`
+	Text         string      `json:"text" validate:"required,max=5000"`
+	Number       int         `json:"number"`
+	SectionType  SectionType `json:"section_type,omitempty" validate:"omitempty,oneof=verse pre-chorus chorus hook bridge intro outro interlude other"`
+	SectionLabel string      `json:"section_label,omitempty"`
+	// RepeatOf is the number of the verse this one repeats, e.g. the first occurrence of a chorus.
+	RepeatOf *int        `json:"repeat_of,omitempty"`
+	Lines    []LyricLine `json:"lines,omitempty"`
+}
+
+// VerseInsert is a verse to insert before the verse at Position. Without a position it is appended.
+type VerseInsert struct {
+	Position     int         `json:"position,omitempty" validate:"min=0"`
+	Text         string      `json:"text" validate:"required,max=5000"`
+	SectionType  SectionType `json:"section_type,omitempty" validate:"omitempty,oneof=verse pre-chorus chorus hook bridge intro outro interlude other"`
+	SectionLabel string      `json:"section_label,omitempty"`
+}
+
+// VerseOrder lists the current verse numbers of a song in their new order.
+type VerseOrder struct {
+	Order []int `json:"order" validate:"required,max=500,dive,min=1"`
 }
 
 type Music struct {
 	ID          string     `json:"id,omitempty"`
-	ReleaseDate *time.Time `json:"release_date,omitempty"`
-	Verses      []Verse    `json:"verses,omitempty"`
-	Link        string     `json:"link,omitempty"`
-	SongName    string     `json:"song_name"`
-	GroupName   string     `json:"group_name"`
+	ReleaseDate *time.Time `json:"release_date,omitempty" validate:"omitempty,lte"`
+	Verses      []Verse    `json:"verses,omitempty" validate:"max=500,dive"`
+	Link        string     `json:"link,omitempty" validate:"omitempty,url,max=2048"`
+	SongName    string     `json:"song_name" validate:"max=255"`
+	// GroupName is the name of the artist referenced by ArtistID.
+	GroupName string        `json:"group_name" validate:"max=255"`
+	ArtistID  string        `json:"artist_id,omitempty"`
+	Artists   []MusicArtist `json:"artists,omitempty"`
+	Album     *AlbumRef     `json:"album,omitempty"`
+	Genres    []string      `json:"genres,omitempty"`
+	Tags      []string      `json:"tags,omitempty"`
+	// Version is incremented on every change and is sent as the ETag of the song.
+	Version   int        `json:"version,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+	// DeletedAt is set for songs in the trash.
+	DeletedAt *time.Time `json:"deleted_at,omitempty"`
+	// ReleaseDatePrecision tells which part of ReleaseDate is known, so that a date known to the year
+	// is not taken for January 1st.
+	ReleaseDatePrecision DatePrecision `json:"release_date_precision,omitempty" validate:"omitempty,oneof=year month day"`
+}
+
+// SearchMatch is a verse matching a full-text search with the matched words highlighted.
+type SearchMatch struct {
+	VerseNumber int    `json:"verse_number"`
+	Snippet     string `json:"snippet"`
+}
+
+type SearchResult struct {
+	Music
+	Rank    float64       `json:"rank"`
+	Matches []SearchMatch `json:"matches"`
 }
 
 type MusicQuery struct {
-	GroupName string `json:"group_name"`
-	SongName  string `json:"song_name"`
+	GroupName string `json:"group_name" validate:"required_without=ArtistID,max=255"`
+	// ArtistID can be given instead of GroupName to save the song for an existing artist.
+	ArtistID string `json:"artist_id,omitempty"`
+	// Artists are credited in addition to the ones parsed from GroupName, e.g. composers.
+	Artists  []MusicArtist `json:"artists,omitempty" validate:"max=20"`
+	SongName string        `json:"song_name" validate:"required,max=255"`
+	// Force saves the song even if similar songs already exist.
+	Force bool `json:"force,omitempty"`
+}
+
+// SimilarMusic is an existing song whose title and group resemble a song being saved.
+type SimilarMusic struct {
+	Music
+	Similarity float64 `json:"similarity"`
+}
+
+// DuplicatesError is returned when saving a song that probably already exists under a
+// slightly different title or group name.
+type DuplicatesError struct {
+	Candidates []SimilarMusic
+}
+
+func (e *DuplicatesError) Error() string {
+	return fmt.Sprintf("found %d possible duplicates, set force to save anyway", len(e.Candidates))
+}
+
+func (e *DuplicatesError) Is(target error) bool {
+	return target == ErrConflict
 }
 
 type MusicFilters struct {
@@ -29,21 +139,84 @@ type MusicFilters struct {
 	Link        *string
 	SongName    *string
 	GroupName   *string
+	ArtistID    *string
+	Genre       *string
+	// Tags match songs carrying all of them, or any of them with TagModeAny.
+	Tags    []string
+	TagMode TagMode
+	// Sort orders the songs by these keys; without it they are ordered by their IDs.
+	Sort []SortKey
+	// ReleaseDateFrom and ReleaseDateTo bound the release date inclusively.
+	ReleaseDateFrom *time.Time
+	ReleaseDateTo   *time.Time
+	// Year and Decade (e.g. 1990) are narrowed into ReleaseDateFrom and ReleaseDateTo by the service.
+	Year   *int
+	Decade *int
+}
+
+type VerseFilters struct {
+	SectionType *SectionType
+	// AtMs selects the single line active at this playback offset.
+	AtMs *int64
 }
 
 type MusicRepository interface {
 	SaveMusic(ctx context.Context, music *Music) (*Music, error)
 	GetMusic(ctx context.Context, musicName, groupName string) (*Music, error)
+	GetMusicByID(ctx context.Context, musicID string, withVerses bool) (*Music, error)
 	GetMusicsByFilters(ctx context.Context, filters MusicFilters, page, pageSize int) ([]Music, error)
-	GetMusicTextWithPaginationByVerse(ctx context.Context, musicID string, limit, offset int) (*Music, error)
-	DeleteMusic(ctx context.Context, musicID string) error
-	UpdateMusic(ctx context.Context, music Music) (Music, error)
+	GetMusicsAfter(ctx context.Context, filters MusicFilters, after Cursor, limit int) ([]Music, error)
+	CountMusicsByFilters(ctx context.Context, filters MusicFilters) (int, error)
+	GetMusicTextWithPaginationByVerse(ctx context.Context, musicID string, filters VerseFilters, limit, offset int) (*Music, error)
+	GetVersesAfter(ctx context.Context, musicID string, filters VerseFilters, afterNumber, limit int) (*Music, error)
+	DeleteMusic(ctx context.Context, musicID string, expectedVersion *int) error
+	UpdateMusic(ctx context.Context, music Music, expectedVersion *int) (Music, error)
+	PatchMusic(ctx context.Context, musicID string, patch MusicPatch, expectedVersion *int) (*Music, error)
+	GetTimedLyrics(ctx context.Context, musicID string) (*Music, error)
+	ReplaceVerses(ctx context.Context, musicID string, verses []Verse, expectedVersion *int) (*Music, error)
+	InsertVerse(ctx context.Context, musicID string, verse VerseInsert, expectedVersion *int) (*Music, error)
+	DeleteVerse(ctx context.Context, musicID string, number int, expectedVersion *int) (*Music, error)
+	ReorderVerses(ctx context.Context, musicID string, order []int, expectedVersion *int) (*Music, error)
+	SearchMusic(ctx context.Context, query string, limit, offset int) ([]SearchResult, error)
+	CountSearchResults(ctx context.Context, query string) (int, error)
+	SetSearchLanguages(ctx context.Context, languages []string) error
+	FindSimilarMusic(ctx context.Context, songName, groupName string, threshold float64, limit int) ([]SimilarMusic, error)
+	FindDuplicatePairs(ctx context.Context, threshold float64) ([]DuplicatePair, error)
+	ReplaceDuplicateClusters(ctx context.Context, clusters []DuplicateCluster) error
+	GetDuplicateClusters(ctx context.Context) ([]DuplicateCluster, error)
+	MergeMusic(ctx context.Context, survivorID string, mergeIDs []string, expectedVersion *int) (*Music, error)
+	GetRevisions(ctx context.Context, musicID string) ([]Revision, error)
+	GetRevision(ctx context.Context, musicID string, revision int) (*Revision, error)
+	RestoreRevision(ctx context.Context, musicID string, revision int, expectedVersion *int) (*Music, error)
+	GetTrash(ctx context.Context, page, pageSize int) ([]Music, error)
+	CountTrash(ctx context.Context) (int, error)
+	RestoreMusic(ctx context.Context, musicID string) (*Music, error)
+	PurgeDeletedMusic(ctx context.Context, deletedBefore time.Time) (int64, error)
 }
 
 type MusicService interface {
 	SaveMusic(ctx context.Context, music *MusicQuery) (*Music, error)
-	GetMusicsByFilters(ctx context.Context, filters MusicFilters, page, pageSize int) ([]Music, error)
-	GetMusicTextWithPaginationByVerse(ctx context.Context, musicID string, limit, offset int) (*Music, error)
-	DeleteMusic(ctx context.Context, musicID string) error
-	UpdateMusic(ctx context.Context, music Music) (Music, error)
+	GetMusicByID(ctx context.Context, musicID string, withVerses bool) (*Music, error)
+	GetMusicsByFilters(ctx context.Context, filters MusicFilters, page, pageSize int) (*Page[Music], error)
+	GetMusicsByCursor(ctx context.Context, filters MusicFilters, cursor string, pageSize int) (*CursorPage[Music], error)
+	GetMusicTextWithPaginationByVerse(ctx context.Context, musicID string, filters VerseFilters, limit, offset int) (*Music, error)
+	GetVersesByCursor(ctx context.Context, musicID string, filters VerseFilters, cursor string, pageSize int) (*VersePage, error)
+	DeleteMusic(ctx context.Context, musicID string, expectedVersion *int) error
+	UpdateMusic(ctx context.Context, music Music, expectedVersion *int) (Music, error)
+	PatchMusic(ctx context.Context, musicID string, patchType PatchType, patch []byte, expectedVersion *int) (*Music, error)
+	ImportLRC(ctx context.Context, musicID string, lrc string, expectedVersion *int) (*Music, error)
+	ExportLRC(ctx context.Context, musicID string) (string, error)
+	InsertVerse(ctx context.Context, musicID string, verse VerseInsert, expectedVersion *int) (*Music, error)
+	DeleteVerse(ctx context.Context, musicID string, number int, expectedVersion *int) (*Music, error)
+	ReorderVerses(ctx context.Context, musicID string, order []int, expectedVersion *int) (*Music, error)
+	SearchMusic(ctx context.Context, query string, page, pageSize int) (*Page[SearchResult], error)
+	ScanDuplicates(ctx context.Context) ([]DuplicateCluster, error)
+	GetDuplicates(ctx context.Context) ([]DuplicateCluster, error)
+	MergeMusic(ctx context.Context, survivorID string, mergeIDs []string, expectedVersion *int) (*Music, error)
+	GetRevisions(ctx context.Context, musicID string) ([]Revision, error)
+	GetRevision(ctx context.Context, musicID string, revision int) (*Revision, error)
+	RestoreRevision(ctx context.Context, musicID string, revision int, expectedVersion *int) (*Music, error)
+	GetTrash(ctx context.Context, page, pageSize int) (*Page[Music], error)
+	RestoreMusic(ctx context.Context, musicID string) (*Music, error)
+	PurgeTrash(ctx context.Context) (int64, error)
 }
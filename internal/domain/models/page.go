@@ -0,0 +1,45 @@
+package models
+
+var ErrInvalidCursor = NewError(ErrValidation, "invalid cursor")
+
+// Page is one page of a list together with the total number of items in the list.
+type Page[T any] struct {
+	Items    []T `json:"items"`
+	Total    int `json:"total"`
+	Page     int `json:"page"`
+	PageSize int `json:"page_size"`
+	// Next and Prev link to the neighbouring pages and are empty on the last and first page.
+	Next string `json:"next,omitempty"`
+	Prev string `json:"prev,omitempty"`
+}
+
+// HasNext reports whether there are items after this page.
+func (p *Page[T]) HasNext() bool {
+	return p.Page*p.PageSize < p.Total
+}
+
+// Cursor is the key of the last item of a page; the next page starts after it.
+// Clients only see it encoded as an opaque string.
+type Cursor struct {
+	ID int `json:"id"`
+	// Sort is the sort the cursor was created for and Values are the values of its keys
+	// for the last item.
+	Sort   string   `json:"sort,omitempty"`
+	Values []string `json:"values,omitempty"`
+}
+
+// CursorPage is one page of a list paginated with cursors. NextCursor and Next are empty on the last page.
+type CursorPage[T any] struct {
+	Items      []T    `json:"items"`
+	PageSize   int    `json:"page_size"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+}
+
+// VersePage is a song with one page of its verses paginated with cursors.
+type VersePage struct {
+	*Music
+	PageSize   int    `json:"page_size"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	Next       string `json:"next,omitempty"`
+}
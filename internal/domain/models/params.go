@@ -0,0 +1,72 @@
+package models
+
+// MusicListParams are the query parameters of the music list. They are validated as a whole before
+// being turned into MusicFilters.
+type MusicListParams struct {
+	// Release date in format YYYY-MM-DD
+	ReleaseDate string `json:"release_date" query:"release_date" validate:"omitempty,release_day"`
+	// Earliest release date in format YYYY, YYYY-MM or YYYY-MM-DD
+	ReleaseDateFrom string `json:"release_date_from" query:"release_date_from" validate:"omitempty,release_date"`
+	// Latest release date in format YYYY, YYYY-MM or YYYY-MM-DD; a year or a month includes all of it
+	ReleaseDateTo string `json:"release_date_to" query:"release_date_to" validate:"omitempty,release_date"`
+	// Release year
+	Year *int `json:"year" query:"year" validate:"omitempty,min=1,max=9999"`
+	// Release decade, e.g. 1990 or 1990s
+	Decade string `json:"decade" query:"decade" validate:"omitempty,decade"`
+	// Music link
+	Link string `json:"link" query:"link" validate:"omitempty,url,max=2048"`
+	// Name of the song
+	SongName string `json:"song_name" query:"song_name" validate:"max=255"`
+	// Name of any of the song's artists
+	GroupName string `json:"group_name" query:"group_name" validate:"max=255"`
+	// ID of any of the song's artists
+	ArtistID string `json:"artist_id" query:"artist_id"`
+	// Tags of the song; repeat or separate with commas for several
+	Tags []string `json:"tag" query:"tag" validate:"max=20,dive,max=64"`
+	// Whether songs must have all of the tags or any of them
+	TagMode TagMode `json:"tag_mode" query:"tag_mode" validate:"omitempty,oneof=all any"`
+	// Genre of the song
+	Genre string `json:"genre" query:"genre" validate:"max=64"`
+	// Comma-separated fields to sort by, descending if prefixed with a minus, e.g. -release_date,title
+	Sort string `json:"sort" query:"sort" validate:"omitempty,sort" example:"-release_date,title"`
+	// Page number, 1 by default
+	Page int `json:"page" query:"page" validate:"min=0"`
+	// Number of records per page, 20 by default and at most 100
+	PageSize int `json:"page_size" query:"page_size" validate:"min=0"`
+	// Cursor of the page; an empty cursor starts from the first song
+	Cursor string `json:"cursor" query:"cursor"`
+}
+
+// VerseListParams are the query parameters of the verses of a song.
+type VerseListParams struct {
+	// Music ID
+	MusicID string `json:"music_id" query:"music_id" validate:"required"`
+	// Only return sections of this type
+	SectionType SectionType `json:"section_type" query:"section_type" validate:"omitempty,oneof=verse pre-chorus chorus hook bridge intro outro interlude other"`
+	// Playback offset in milliseconds; only the verse and line active at this offset are returned
+	AtMs *int64 `json:"at_ms" query:"at_ms" validate:"omitempty,min=0"`
+	// Page number, 1 by default
+	Page int `json:"page" query:"page" validate:"min=0"`
+	// Number of records per page, 20 by default and at most 100
+	PageSize int `json:"page_size" query:"page_size" validate:"min=0"`
+	// Cursor of the page; an empty cursor starts from the first verse
+	Cursor string `json:"cursor" query:"cursor"`
+}
+
+// PageParams are the query parameters of lists that are only paginated.
+type PageParams struct {
+	// Page number, 1 by default
+	Page int `json:"page" query:"page" validate:"min=0"`
+	// Number of records per page, 20 by default and at most 100
+	PageSize int `json:"page_size" query:"page_size" validate:"min=0"`
+}
+
+// SearchParams are the query parameters of the lyrics search.
+type SearchParams struct {
+	// Search query; supports quoted phrases, OR and -word
+	Query string `json:"q" query:"q" validate:"required,max=255"`
+	// Page number, 1 by default
+	Page int `json:"page" query:"page" validate:"min=0"`
+	// Number of records per page, 20 by default and at most 100
+	PageSize int `json:"page_size" query:"page_size" validate:"min=0"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+var (
+	ErrInvalidPatch     = NewError(ErrValidation, "patch cannot be applied to the music")
+	ErrUnsupportedPatch = NewError(ErrValidation, "unsupported patch format")
+)
+
+type PatchType string
+
+const (
+	// PatchMerge is an RFC 7396 JSON Merge Patch.
+	PatchMerge PatchType = "application/merge-patch+json"
+	// PatchJSON is an RFC 6902 JSON Patch.
+	PatchJSON PatchType = "application/json-patch+json"
+)
+
+// MusicDocument is the representation of a song that PATCH requests are applied to. Its optional
+// fields are null when unset, so that a patch can clear them.
+type MusicDocument struct {
+	SongName  string `json:"song_name"`
+	GroupName string `json:"group_name"`
+	// ReleaseDate is formatted as YYYY-MM-DD, or as YYYY or YYYY-MM if only the year or the month is known.
+	ReleaseDate *string         `json:"release_date"`
+	Link        *string         `json:"link"`
+	Verses      []VerseDocument `json:"verses"`
+}
+
+type VerseDocument struct {
+	Text         string      `json:"text"`
+	SectionType  SectionType `json:"section_type"`
+	SectionLabel string      `json:"section_label"`
+	// RepeatOf is the position of an earlier verse of the document that this one repeats, counted from 1.
+	RepeatOf *int `json:"repeat_of"`
+}
+
+// MusicPatch sets every field of a song, clearing ReleaseDate and Link if they are nil.
+// The verses are only replaced if ReplaceVerses is set.
+type MusicPatch struct {
+	SongName      string
+	GroupName     string
+	ReleaseDate   *time.Time
+	Link          *string
+	ReplaceVerses bool
+	Verses        []Verse
+	// ReleaseDatePrecision is the precision ReleaseDate was given with.
+	ReleaseDatePrecision DatePrecision
+}
@@ -0,0 +1,5 @@
+package models
+
+// ErrVersionMismatch is returned by changes of a song given an expected version the song is no longer
+// at. A nil expected version matches any version.
+var ErrVersionMismatch = NewError(ErrConflict, "music was changed by someone else, reload it and try again")
@@ -0,0 +1,60 @@
+package models
+
+import "time"
+
+var ErrInvalidReleaseDate = NewError(ErrValidation, "release date must be formatted as YYYY, YYYY-MM or YYYY-MM-DD")
+
+// DatePrecision is how much of a release date is known. A date known to the year is stored
+// as January 1st of that year and a date known to the month as the first day of the month.
+type DatePrecision string
+
+const (
+	PrecisionYear  DatePrecision = "year"
+	PrecisionMonth DatePrecision = "month"
+	PrecisionDay   DatePrecision = "day"
+)
+
+var releaseDateLayouts = []struct {
+	layout    string
+	precision DatePrecision
+}{
+	{"2006-01-02", PrecisionDay},
+	{"2006-01", PrecisionMonth},
+	{"2006", PrecisionYear},
+}
+
+// ParseReleaseDate parses a release date known to the day, the month or the year.
+func ParseReleaseDate(s string) (time.Time, DatePrecision, error) {
+	for _, layout := range releaseDateLayouts {
+		if t, err := time.Parse(layout.layout, s); err == nil {
+			return t, layout.precision, nil
+		}
+	}
+	return time.Time{}, "", ErrInvalidReleaseDate
+}
+
+// FormatReleaseDate formats a release date with only the known part of it. Dates without a precision
+// are known to the day.
+func FormatReleaseDate(t time.Time, precision DatePrecision) string {
+	switch precision {
+	case PrecisionYear:
+		return t.Format("2006")
+	case PrecisionMonth:
+		return t.Format("2006-01")
+	default:
+		return t.Format("2006-01-02")
+	}
+}
+
+// ReleaseDateEnd returns the last day of the period a release date stands for, e.g. December 31st
+// for a date known to the year.
+func ReleaseDateEnd(t time.Time, precision DatePrecision) time.Time {
+	switch precision {
+	case PrecisionYear:
+		return t.AddDate(1, 0, -1)
+	case PrecisionMonth:
+		return t.AddDate(0, 1, -1)
+	default:
+		return t
+	}
+}
@@ -0,0 +1,68 @@
+package models
+
+import (
+	"context"
+	"time"
+)
+
+var ErrRevisionNotFound = NewError(ErrNotFound, "revision not found")
+
+type authorKey struct{}
+
+// ContextWithAuthor remembers who makes the changes done with ctx, so that they end up in the revision history.
+func ContextWithAuthor(ctx context.Context, author string) context.Context {
+	return context.WithValue(ctx, authorKey{}, author)
+}
+
+// AuthorFromContext returns the author stored by ContextWithAuthor, or an empty string.
+func AuthorFromContext(ctx context.Context) string {
+	author, _ := ctx.Value(authorKey{}).(string)
+	return author
+}
+
+// MusicSnapshot is the editable state of a song at some revision.
+type MusicSnapshot struct {
+	SongName    string          `json:"song_name"`
+	GroupName   string          `json:"group_name"`
+	ArtistID    string          `json:"artist_id,omitempty"`
+	ReleaseDate *time.Time      `json:"release_date,omitempty"`
+	Link        string          `json:"link,omitempty"`
+	Verses      []VerseSnapshot `json:"verses"`
+	// ReleaseDatePrecision is empty in snapshots taken before it was recorded, which were known to the day.
+	ReleaseDatePrecision DatePrecision `json:"release_date_precision,omitempty"`
+}
+
+// VerseSnapshot is a verse at some revision. SectionType is empty in snapshots taken before sections
+// were recorded.
+type VerseSnapshot struct {
+	Number       int         `json:"number"`
+	Text         string      `json:"text"`
+	SectionType  SectionType `json:"section_type,omitempty"`
+	SectionLabel string      `json:"section_label,omitempty"`
+	RepeatOf     *int        `json:"repeat_of,omitempty"`
+}
+
+// FieldChange is a song field changed by a revision, with values formatted as text.
+type FieldChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// VerseChange is a verse changed by a revision. Old is nil for added verses and New for removed ones.
+type VerseChange struct {
+	Number int     `json:"number"`
+	Old    *string `json:"old"`
+	New    *string `json:"new"`
+}
+
+type Revision struct {
+	MusicID      string         `json:"music_id"`
+	Number       int            `json:"revision"`
+	Author       string         `json:"author,omitempty"`
+	CreatedAt    time.Time      `json:"created_at"`
+	RestoredFrom *int           `json:"restored_from,omitempty"`
+	Changes      []FieldChange  `json:"changes"`
+	VerseChanges []VerseChange  `json:"verse_changes,omitempty"`
+	Snapshot     *MusicSnapshot `json:"snapshot,omitempty"`
+}
@@ -0,0 +1,21 @@
+package models
+
+var ErrInvalidSort = NewError(ErrValidation, "invalid sort")
+
+// SortField is a field the music list can be sorted by.
+type SortField string
+
+const (
+	SortReleaseDate SortField = "release_date"
+	SortTitle       SortField = "title"
+	SortGroupName   SortField = "group_name"
+	SortCreatedAt   SortField = "created_at"
+)
+
+var SortFields = []SortField{SortReleaseDate, SortTitle, SortGroupName, SortCreatedAt}
+
+// SortKey is one key of a multi-field sort. Songs equal by every key are ordered by their IDs.
+type SortKey struct {
+	Field SortField
+	Desc  bool
+}
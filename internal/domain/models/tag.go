@@ -0,0 +1,69 @@
+package models
+
+import "context"
+
+var (
+	ErrGenreExists   = NewError(ErrConflict, "genre already exists")
+	ErrGenreNotFound = NewError(ErrNotFound, "genre not found")
+)
+
+// TagMode tells whether a song has to carry all of the requested tags or any of them.
+type TagMode string
+
+const (
+	TagModeAll TagMode = "all"
+	TagModeAny TagMode = "any"
+)
+
+// TagSource records who attached a tag to a song.
+type TagSource string
+
+const (
+	TagSourceCurator TagSource = "curator"
+	TagSourceLastFM  TagSource = "lastfm"
+)
+
+type Genre struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	MusicCount int    `json:"music_count"`
+}
+
+type Tag struct {
+	Name       string `json:"name"`
+	MusicCount int    `json:"music_count"`
+}
+
+// MusicTags are the genres and tags of a song.
+type MusicTags struct {
+	MusicID string   `json:"music_id"`
+	Genres  []string `json:"genres"`
+	Tags    []string `json:"tags"`
+}
+
+type TagsRequest struct {
+	Tags []string `json:"tags"`
+}
+
+type TagRepository interface {
+	GetTags(ctx context.Context, page, pageSize int) ([]Tag, error)
+	CountTags(ctx context.Context) (int, error)
+	GetGenres(ctx context.Context) ([]Genre, error)
+	CreateGenre(ctx context.Context, name string) (*Genre, error)
+	GetMusicTags(ctx context.Context, musicID string) (*MusicTags, error)
+	AddMusicTags(ctx context.Context, musicID string, tags []string) (*MusicTags, error)
+	RemoveMusicTag(ctx context.Context, musicID, tag string) (*MusicTags, error)
+	AddMusicGenre(ctx context.Context, musicID, genre string) (*MusicTags, error)
+	RemoveMusicGenre(ctx context.Context, musicID, genre string) (*MusicTags, error)
+}
+
+type TagService interface {
+	GetTags(ctx context.Context, page, pageSize int) (*Page[Tag], error)
+	GetGenres(ctx context.Context) ([]Genre, error)
+	CreateGenre(ctx context.Context, name string) (*Genre, error)
+	GetMusicTags(ctx context.Context, musicID string) (*MusicTags, error)
+	AddMusicTags(ctx context.Context, musicID string, tags []string) (*MusicTags, error)
+	RemoveMusicTag(ctx context.Context, musicID, tag string) (*MusicTags, error)
+	AddMusicGenre(ctx context.Context, musicID, genre string) (*MusicTags, error)
+	RemoveMusicGenre(ctx context.Context, musicID, genre string) (*MusicTags, error)
+}
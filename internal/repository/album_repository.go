@@ -0,0 +1,274 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+)
+
+const albumColumns = `
+	al.id, al.title, COALESCE(a.name, ''), COALESCE(al.artist_id::TEXT, ''), al.release_date, COALESCE(al.link, '')
+`
+
+type albumRepository struct {
+	pool *pgxpool.Pool
+}
+
+func scanAlbum(row pgx.Row) (*models.Album, error) {
+	var album models.Album
+	err := row.Scan(&album.ID, &album.Title, &album.GroupName, &album.ArtistID, &album.ReleaseDate, &album.Link)
+	if err != nil {
+		return nil, err
+	}
+	return &album, nil
+}
+
+// ensureAlbum returns the id of the artist's album with the given title, case-insensitively, creating it if needed.
+func ensureAlbum(ctx context.Context, q querier, artistID *string, title, link string) (int, error) {
+	query := `
+		INSERT INTO albums (title, artist_id, link) VALUES ($1, $2, $3)
+		ON CONFLICT ((COALESCE(artist_id, 0)), (lower(title)))
+		DO UPDATE SET link = COALESCE(albums.link, EXCLUDED.link)
+		RETURNING id
+	`
+
+	var albumID int
+	err := q.QueryRow(ctx, query, title, artistID, nullableString(link)).Scan(&albumID)
+	return albumID, err
+}
+
+func (a albumRepository) CreateAlbum(ctx context.Context, album *models.Album) (*models.Album, error) {
+	log.Infof("Creating album: %s", album.Title)
+
+	tx, err := a.pool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Error beginning transaction: %v", err)
+		return nil, err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			log.Warnf("Error rolling back transaction: %v", err)
+		}
+	}(tx, ctx)
+
+	var artistID *string
+	if album.ArtistID != "" {
+		artistID = &album.ArtistID
+	} else if album.GroupName != "" {
+		id, err := ensureArtist(ctx, tx, album.GroupName)
+		if err != nil {
+			log.Errorf("Error saving artist %s: %v", album.GroupName, err)
+			return nil, err
+		}
+		album.ArtistID = strconv.Itoa(id)
+		artistID = &album.ArtistID
+	}
+
+	query := `
+		WITH inserted AS (
+			INSERT INTO albums (title, artist_id, release_date, link)
+			VALUES ($1, $2, $3, $4)
+			RETURNING *
+		)
+		SELECT ` + albumColumns + `
+		FROM inserted al
+		LEFT JOIN artists a ON a.id = al.artist_id
+	`
+
+	created, err := scanAlbum(tx.QueryRow(ctx, query, album.Title, artistID, album.ReleaseDate, nullableString(album.Link)))
+	if isPgError(err, pgUniqueViolation) {
+		log.Warnf("Album %s already exists", album.Title)
+		return nil, models.ErrAlbumExists
+	}
+	if isPgError(err, pgForeignKeyViolation) {
+		log.Warnf("Artist %s not found", album.ArtistID)
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf("Error creating album: %v", err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Errorf("Error committing transaction: %v", err)
+		return nil, err
+	}
+
+	log.Infof("Album created with ID: %s", created.ID)
+	return created, nil
+}
+
+func (a albumRepository) GetAlbum(ctx context.Context, albumID string) (*models.Album, error) {
+	query := `
+		SELECT ` + albumColumns + `
+		FROM albums al
+		LEFT JOIN artists a ON a.id = al.artist_id
+		WHERE al.id = $1
+	`
+
+	album, err := scanAlbum(a.pool.QueryRow(ctx, query, albumID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf("Error fetching album %s: %v", albumID, err)
+		return nil, err
+	}
+
+	album.Tracks, err = a.GetTracks(ctx, albumID)
+	if err != nil {
+		return nil, err
+	}
+
+	return album, nil
+}
+
+func (a albumRepository) CountAlbums(ctx context.Context, filters models.AlbumFilters) (int, error) {
+	query := `
+		SELECT count(*)
+		FROM albums al
+		WHERE
+			($1::TEXT IS NULL OR al.title ILIKE '%' || $1::TEXT || '%')
+			AND ($2::INT IS NULL OR al.artist_id = $2::INT)
+	`
+
+	var total int
+	err := a.pool.QueryRow(ctx, query, filters.Title, filters.ArtistID).Scan(&total)
+	if err != nil {
+		log.Errorf("Error counting albums: %v", err)
+		return 0, err
+	}
+	return total, nil
+}
+
+func (a albumRepository) GetAlbums(ctx context.Context, filters models.AlbumFilters, page, pageSize int) ([]models.Album, error) {
+	log.Infof("Fetching album list with filters: %+v", filters)
+	query := `
+		SELECT ` + albumColumns + `
+		FROM albums al
+		LEFT JOIN artists a ON a.id = al.artist_id
+		WHERE
+			($1::TEXT IS NULL OR al.title ILIKE '%' || $1::TEXT || '%')
+			AND ($2::INT IS NULL OR al.artist_id = $2::INT)
+		ORDER BY al.release_date NULLS LAST, al.title, al.id
+		LIMIT $3 OFFSET $4
+	`
+	offset := (page - 1) * pageSize
+
+	rows, err := a.pool.Query(ctx, query, filters.Title, filters.ArtistID, pageSize, offset)
+	if err != nil {
+		log.Errorf("Error fetching album list: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	albums := []models.Album{}
+	for rows.Next() {
+		album, err := scanAlbum(rows)
+		if err != nil {
+			log.Errorf("Error scanning album row: %v", err)
+			return nil, err
+		}
+		albums = append(albums, *album)
+	}
+
+	log.Infof("Successfully fetched %d albums", len(albums))
+	return albums, rows.Err()
+}
+
+// AddTrack puts a song on an album, or moves it if it is already there. It returns nil if the album or the song does not exist.
+func (a albumRepository) AddTrack(ctx context.Context, albumID string, track models.AlbumTrack) (*models.AlbumTrack, error) {
+	log.Infof("Adding music %s to album %s", track.MusicID, albumID)
+	query := `
+		WITH upserted AS (
+			INSERT INTO album_tracks (album_id, music_id, disc_number, track_number)
+			SELECT $1, id, $3, NULLIF($4, 0) FROM music WHERE id = $2 AND deleted_at IS NULL
+			ON CONFLICT (album_id, music_id)
+			DO UPDATE SET disc_number = EXCLUDED.disc_number, track_number = EXCLUDED.track_number
+			RETURNING *
+		)
+		SELECT u.music_id, m.title, u.disc_number, COALESCE(u.track_number, 0)
+		FROM upserted u
+		JOIN music m ON m.id = u.music_id
+	`
+
+	var added models.AlbumTrack
+	err := a.pool.QueryRow(ctx, query, albumID, track.MusicID, track.DiscNumber, track.TrackNumber).
+		Scan(&added.MusicID, &added.SongName, &added.DiscNumber, &added.TrackNumber)
+	if errors.Is(err, pgx.ErrNoRows) || isPgError(err, pgForeignKeyViolation) {
+		log.Warnf("Album %s or music %s not found", albumID, track.MusicID)
+		return nil, nil
+	}
+	if isPgError(err, pgUniqueViolation) {
+		log.Warnf("Album %s already has a track at disc %d, position %d", albumID, track.DiscNumber, track.TrackNumber)
+		return nil, models.ErrTrackPositionTaken
+	}
+	if err != nil {
+		log.Errorf("Error adding music %s to album %s: %v", track.MusicID, albumID, err)
+		return nil, err
+	}
+
+	log.Infof("Music %s added to album %s", track.MusicID, albumID)
+	return &added, nil
+}
+
+// GetTracks returns the track listing of an album, or nil if the album does not exist.
+func (a albumRepository) GetTracks(ctx context.Context, albumID string) ([]models.AlbumTrack, error) {
+	query := `
+		SELECT t.music_id, m.title, t.disc_number, COALESCE(t.track_number, 0)
+		FROM albums al
+		LEFT JOIN (album_tracks t JOIN music m ON m.id = t.music_id AND m.deleted_at IS NULL) ON t.album_id = al.id
+		WHERE al.id = $1
+		ORDER BY t.disc_number, t.track_number NULLS LAST, m.title
+	`
+
+	rows, err := a.pool.Query(ctx, query, albumID)
+	if err != nil {
+		log.Errorf("Error fetching tracks of album %s: %v", albumID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tracks []models.AlbumTrack
+	found := false
+	for rows.Next() {
+		found = true
+		var musicID, songName *string
+		var discNumber, trackNumber *int
+		if err := rows.Scan(&musicID, &songName, &discNumber, &trackNumber); err != nil {
+			log.Errorf("Error scanning album track row: %v", err)
+			return nil, err
+		}
+		if musicID == nil {
+			continue
+		}
+		tracks = append(tracks, models.AlbumTrack{
+			MusicID:     *musicID,
+			SongName:    *songName,
+			DiscNumber:  *discNumber,
+			TrackNumber: *trackNumber,
+		})
+	}
+	if err := rows.Err(); err != nil {
+		log.Errorf("Error iterating album track rows: %v", err)
+		return nil, err
+	}
+
+	if !found {
+		return nil, nil
+	}
+	if tracks == nil {
+		tracks = []models.AlbumTrack{}
+	}
+	return tracks, nil
+}
+
+func NewAlbumRepository(pool *pgxpool.Pool) models.AlbumRepository {
+	log.Info("Creating new album repository")
+	return &albumRepository{pool: pool}
+}
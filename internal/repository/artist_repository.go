@@ -0,0 +1,232 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgxpool"
+	log "github.com/sirupsen/logrus"
+)
+
+const artistColumns = `
+	id, name, COALESCE(country, ''), formed_year, COALESCE(description, ''), created_at, updated_at
+`
+
+const (
+	pgUniqueViolation     = "23505"
+	pgForeignKeyViolation = "23503"
+)
+
+type artistRepository struct {
+	pool *pgxpool.Pool
+}
+
+func isPgError(err error, code string) bool {
+	var pgErr *pgconn.PgError
+	return errors.As(err, &pgErr) && pgErr.Code == code
+}
+
+func scanArtist(row pgx.Row) (*models.Artist, error) {
+	var artist models.Artist
+	err := row.Scan(&artist.ID, &artist.Name, &artist.Country, &artist.FormedYear, &artist.Description, &artist.CreatedAt, &artist.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	return &artist, nil
+}
+
+// ensureArtist returns the id of the artist with the given name, case-insensitively, creating it if needed.
+func ensureArtist(ctx context.Context, q querier, name string) (int, error) {
+	query := `
+		INSERT INTO artists (name) VALUES ($1)
+		ON CONFLICT ((lower(name))) DO UPDATE SET name = artists.name
+		RETURNING id
+	`
+
+	var artistID int
+	err := q.QueryRow(ctx, query, name).Scan(&artistID)
+	return artistID, err
+}
+
+func nullableString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func (a artistRepository) CreateArtist(ctx context.Context, artist *models.Artist) (*models.Artist, error) {
+	log.Infof("Creating artist: %s", artist.Name)
+	query := `
+		INSERT INTO artists (name, country, formed_year, description)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + artistColumns
+
+	created, err := scanArtist(a.pool.QueryRow(ctx, query, artist.Name, nullableString(artist.Country), artist.FormedYear, nullableString(artist.Description)))
+	if isPgError(err, pgUniqueViolation) {
+		log.Warnf("Artist %s already exists", artist.Name)
+		return nil, models.ErrArtistExists
+	}
+	if err != nil {
+		log.Errorf("Error creating artist: %v", err)
+		return nil, err
+	}
+
+	log.Infof("Artist created with ID: %s", created.ID)
+	return created, nil
+}
+
+func (a artistRepository) GetArtist(ctx context.Context, artistID string) (*models.Artist, error) {
+	query := `SELECT ` + artistColumns + ` FROM artists WHERE id = $1`
+
+	artist, err := scanArtist(a.pool.QueryRow(ctx, query, artistID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf("Error fetching artist %s: %v", artistID, err)
+		return nil, err
+	}
+
+	return artist, nil
+}
+
+func (a artistRepository) GetArtistByName(ctx context.Context, name string) (*models.Artist, error) {
+	query := `SELECT ` + artistColumns + ` FROM artists WHERE lower(name) = lower($1)`
+
+	artist, err := scanArtist(a.pool.QueryRow(ctx, query, name))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf("Error fetching artist %s: %v", name, err)
+		return nil, err
+	}
+
+	return artist, nil
+}
+
+func (a artistRepository) CountArtists(ctx context.Context, filters models.ArtistFilters) (int, error) {
+	query := `
+		SELECT count(*)
+		FROM artists
+		WHERE
+			($1::TEXT IS NULL OR name ILIKE '%' || $1::TEXT || '%')
+			AND ($2::TEXT IS NULL OR country = $2::TEXT)
+	`
+
+	var total int
+	err := a.pool.QueryRow(ctx, query, filters.Name, filters.Country).Scan(&total)
+	if err != nil {
+		log.Errorf("Error counting artists: %v", err)
+		return 0, err
+	}
+	return total, nil
+}
+
+func (a artistRepository) GetArtists(ctx context.Context, filters models.ArtistFilters, page, pageSize int) ([]models.Artist, error) {
+	log.Infof("Fetching artist list with filters: %+v", filters)
+	query := `
+		SELECT ` + artistColumns + `
+		FROM artists
+		WHERE
+			($1::TEXT IS NULL OR name ILIKE '%' || $1::TEXT || '%')
+			AND ($2::TEXT IS NULL OR country = $2::TEXT)
+		ORDER BY name, id
+		LIMIT $3 OFFSET $4
+	`
+	offset := (page - 1) * pageSize
+
+	rows, err := a.pool.Query(ctx, query, filters.Name, filters.Country, pageSize, offset)
+	if err != nil {
+		log.Errorf("Error fetching artist list: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	artists := []models.Artist{}
+	for rows.Next() {
+		artist, err := scanArtist(rows)
+		if err != nil {
+			log.Errorf("Error scanning artist row: %v", err)
+			return nil, err
+		}
+		artists = append(artists, *artist)
+	}
+
+	log.Infof("Successfully fetched %d artists", len(artists))
+	return artists, rows.Err()
+}
+
+// UpdateArtist changes the non-empty fields of artist. Renaming an artist renames it for all of its music.
+func (a artistRepository) UpdateArtist(ctx context.Context, artist models.Artist) (*models.Artist, error) {
+	log.Infof("Updating artist with ID: %s", artist.ID)
+	query := `UPDATE artists SET `
+	params := []interface{}{}
+	paramCount := 1
+
+	if artist.Name != "" {
+		query += fmt.Sprintf("name = $%d, ", paramCount)
+		paramCount++
+		params = append(params, artist.Name)
+	}
+	if artist.Country != "" {
+		query += fmt.Sprintf("country = $%d, ", paramCount)
+		paramCount++
+		params = append(params, artist.Country)
+	}
+	if artist.FormedYear != nil {
+		query += fmt.Sprintf("formed_year = $%d, ", paramCount)
+		paramCount++
+		params = append(params, artist.FormedYear)
+	}
+	if artist.Description != "" {
+		query += fmt.Sprintf("description = $%d, ", paramCount)
+		paramCount++
+		params = append(params, artist.Description)
+	}
+
+	query += fmt.Sprintf("updated_at = now() WHERE id = $%d RETURNING %s", paramCount, artistColumns)
+	params = append(params, artist.ID)
+
+	updated, err := scanArtist(a.pool.QueryRow(ctx, query, params...))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if isPgError(err, pgUniqueViolation) {
+		log.Warnf("Artist %s already exists", artist.Name)
+		return nil, models.ErrArtistExists
+	}
+	if err != nil {
+		log.Errorf("Error updating artist with ID %s: %v", artist.ID, err)
+		return nil, err
+	}
+
+	log.Infof("Artist with ID %s updated successfully", artist.ID)
+	return updated, nil
+}
+
+func (a artistRepository) DeleteArtist(ctx context.Context, artistID string) error {
+	log.Infof("Deleting artist with ID: %s", artistID)
+
+	_, err := a.pool.Exec(ctx, `DELETE FROM artists WHERE id = $1`, artistID)
+	if isPgError(err, pgForeignKeyViolation) {
+		log.Warnf("Artist with ID %s still has music", artistID)
+		return models.ErrArtistInUse
+	}
+	if err != nil {
+		log.Errorf("Error deleting artist with ID %s: %v", artistID, err)
+		return err
+	}
+
+	log.Infof("Artist with ID %s deleted successfully", artistID)
+	return nil
+}
+
+func NewArtistRepository(pool *pgxpool.Pool) models.ArtistRepository {
+	log.Info("Creating new artist repository")
+	return &artistRepository{pool: pool}
+}
@@ -0,0 +1,201 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"time"
+)
+
+const jobColumns = `
+	id, status, song_name, group_name, force, music_id, result,
+	attempts, max_attempts, COALESCE(last_error, ''), run_at, created_at, updated_at
+`
+
+type jobRepository struct {
+	pool *pgxpool.Pool
+}
+
+func scanJob(row pgx.Row) (*models.EnrichmentJob, error) {
+	var job models.EnrichmentJob
+	var musicID *int
+	var result []byte
+
+	err := row.Scan(
+		&job.ID, &job.Status, &job.Query.SongName, &job.Query.GroupName, &job.Query.Force, &musicID, &result,
+		&job.Attempts, &job.MaxAttempts, &job.LastError, &job.RunAt, &job.CreatedAt, &job.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if musicID != nil {
+		id := strconv.Itoa(*musicID)
+		job.MusicID = &id
+	}
+	if result != nil {
+		job.Result = new(models.Music)
+		if err := json.Unmarshal(result, job.Result); err != nil {
+			return nil, err
+		}
+	}
+
+	return &job, nil
+}
+
+func (j jobRepository) CreateJob(ctx context.Context, query models.MusicQuery, maxAttempts int) (*models.EnrichmentJob, error) {
+	log.Infof("Enqueueing enrichment job for %s by %s", query.SongName, query.GroupName)
+	sqlQuery := `
+		INSERT INTO enrichment_jobs (song_name, group_name, force, max_attempts)
+		VALUES ($1, $2, $3, $4)
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(j.pool.QueryRow(ctx, sqlQuery, query.SongName, query.GroupName, query.Force, maxAttempts))
+	if err != nil {
+		log.Errorf("Error creating enrichment job: %v", err)
+		return nil, err
+	}
+
+	log.Infof("Enrichment job created with ID: %s", job.ID)
+	return job, nil
+}
+
+func (j jobRepository) GetJob(ctx context.Context, jobID string) (*models.EnrichmentJob, error) {
+	sqlQuery := `SELECT ` + jobColumns + ` FROM enrichment_jobs WHERE id = $1`
+
+	job, err := scanJob(j.pool.QueryRow(ctx, sqlQuery, jobID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf("Error fetching enrichment job %s: %v", jobID, err)
+		return nil, err
+	}
+
+	return job, nil
+}
+
+// ClaimJob locks the next due job for the duration of lease. Jobs whose lease expired
+// (for example because the instance processing them died) are picked up again.
+func (j jobRepository) ClaimJob(ctx context.Context, lease time.Duration) (*models.EnrichmentJob, error) {
+	sqlQuery := `
+		UPDATE enrichment_jobs
+		SET
+			status = 'running',
+			attempts = attempts + 1,
+			locked_until = now() + make_interval(secs => $1),
+			updated_at = now()
+		WHERE id = (
+			SELECT id
+			FROM enrichment_jobs
+			WHERE
+				(status = 'pending' AND run_at <= now())
+				OR (status = 'running' AND locked_until < now())
+			ORDER BY run_at
+			LIMIT 1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING ` + jobColumns
+
+	job, err := scanJob(j.pool.QueryRow(ctx, sqlQuery, lease.Seconds()))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf("Error claiming enrichment job: %v", err)
+		return nil, err
+	}
+
+	log.Infof("Claimed enrichment job %s, attempt %d of %d", job.ID, job.Attempts, job.MaxAttempts)
+	return job, nil
+}
+
+func (j jobRepository) CompleteJob(ctx context.Context, jobID string, result *models.Music) error {
+	payload, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+
+	sqlQuery := `
+		UPDATE enrichment_jobs
+		SET
+			status = 'succeeded',
+			music_id = $2,
+			result = $3,
+			last_error = NULL,
+			locked_until = NULL,
+			updated_at = now()
+		WHERE id = $1
+	`
+
+	var musicID *string
+	if result != nil && result.ID != "" {
+		musicID = &result.ID
+	}
+
+	_, err = j.pool.Exec(ctx, sqlQuery, jobID, musicID, payload)
+	if err != nil {
+		log.Errorf("Error completing enrichment job %s: %v", jobID, err)
+		return err
+	}
+
+	log.Infof("Enrichment job %s succeeded", jobID)
+	return nil
+}
+
+func (j jobRepository) FailJob(ctx context.Context, jobID string, jobErr string, retryAt *time.Time) error {
+	sqlQuery := `
+		UPDATE enrichment_jobs
+		SET
+			status = CASE WHEN $3::TIMESTAMPTZ IS NULL THEN 'dead' ELSE 'pending' END,
+			run_at = COALESCE($3::TIMESTAMPTZ, run_at),
+			last_error = $2,
+			locked_until = NULL,
+			updated_at = now()
+		WHERE id = $1
+	`
+
+	_, err := j.pool.Exec(ctx, sqlQuery, jobID, jobErr, retryAt)
+	if err != nil {
+		log.Errorf("Error failing enrichment job %s: %v", jobID, err)
+		return err
+	}
+
+	if retryAt == nil {
+		log.Warnf("Enrichment job %s moved to dead-letter state: %s", jobID, jobErr)
+	} else {
+		log.Infof("Enrichment job %s will be retried at %s", jobID, retryAt.Format(time.RFC3339))
+	}
+	return nil
+}
+
+func (j jobRepository) ReleaseJob(ctx context.Context, jobID string) error {
+	sqlQuery := `
+		UPDATE enrichment_jobs
+		SET
+			status = 'pending',
+			attempts = GREATEST(attempts - 1, 0),
+			locked_until = NULL,
+			updated_at = now()
+		WHERE id = $1 AND status = 'running'
+	`
+
+	_, err := j.pool.Exec(ctx, sqlQuery, jobID)
+	if err != nil {
+		log.Errorf("Error releasing enrichment job %s: %v", jobID, err)
+		return err
+	}
+
+	log.Infof("Enrichment job %s returned to the queue", jobID)
+	return nil
+}
+
+func NewJobRepository(pool *pgxpool.Pool) models.JobRepository {
+	log.Info("Creating new job repository")
+	return &jobRepository{pool: pool}
+}
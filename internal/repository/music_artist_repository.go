@@ -0,0 +1,103 @@
+package repository
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"strconv"
+)
+
+type execer interface {
+	Exec(ctx context.Context, sql string, args ...any) (pgconn.CommandTag, error)
+}
+
+// insertMusicArtists credits the song with its primary artist first and then with every given
+// credit, creating artists referenced only by name. Repeated credits are ignored.
+func insertMusicArtists(ctx context.Context, tx pgx.Tx, musicID int, primaryArtistID *string, credits []models.MusicArtist) error {
+	query := `
+		INSERT INTO music_artists (music_id, artist_id, role, position)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT DO NOTHING
+	`
+
+	if primaryArtistID != nil {
+		if _, err := tx.Exec(ctx, query, musicID, *primaryArtistID, models.RolePrimary, 0); err != nil {
+			return err
+		}
+	}
+
+	for i, credit := range credits {
+		artistID := credit.ArtistID
+		if artistID == "" {
+			id, err := ensureArtist(ctx, tx, credit.Name)
+			if err != nil {
+				return err
+			}
+			artistID = strconv.Itoa(id)
+		}
+		if _, err := tx.Exec(ctx, query, musicID, artistID, credit.Role, i+1); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// setPrimaryArtist makes artistID the only primary artist of the song.
+func setPrimaryArtist(ctx context.Context, e execer, musicID, artistID string) error {
+	_, err := e.Exec(ctx, `DELETE FROM music_artists WHERE music_id = $1 AND role = $2 AND artist_id <> $3`, musicID, models.RolePrimary, artistID)
+	if err != nil {
+		return err
+	}
+
+	_, err = e.Exec(ctx, `
+		INSERT INTO music_artists (music_id, artist_id, role, position)
+		VALUES ($1, $2, $3, 0)
+		ON CONFLICT DO NOTHING
+	`, musicID, artistID, models.RolePrimary)
+	return err
+}
+
+// loadMusicArtists returns the credits of the given songs keyed by song id, primary artists first.
+func loadMusicArtists(ctx context.Context, q querier, musicIDs []string) (map[string][]models.MusicArtist, error) {
+	artists := map[string][]models.MusicArtist{}
+	if len(musicIDs) == 0 {
+		return artists, nil
+	}
+
+	ids, err := parseMusicIDs(musicIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT ma.music_id::TEXT, ma.artist_id::TEXT, a.name, ma.role
+		FROM music_artists ma
+		JOIN artists a ON a.id = ma.artist_id
+		WHERE ma.music_id = ANY($1)
+		ORDER BY ma.music_id, array_position($2::TEXT[], ma.role), ma.position
+	`
+
+	roles := make([]string, 0, len(models.ArtistRoles))
+	for _, role := range models.ArtistRoles {
+		roles = append(roles, string(role))
+	}
+
+	rows, err := q.Query(ctx, query, ids, roles)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var musicID string
+		var artist models.MusicArtist
+		if err := rows.Scan(&musicID, &artist.ArtistID, &artist.Name, &artist.Role); err != nil {
+			return nil, err
+		}
+		artists[musicID] = append(artists[musicID], artist)
+	}
+
+	return artists, rows.Err()
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+)
+
+// PatchMusic sets every field of a song to the patched value, including clearing the ones that are
+// nil, and replaces its verses if the patch changed them. It returns nil if the song does not exist.
+func (m musicRepository) PatchMusic(ctx context.Context, musicID string, patch models.MusicPatch, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Patching music with ID: %s", musicID)
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Error beginning transaction: %v", err)
+		return nil, err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			log.Warnf("Error rolling back transaction: %v", err)
+		}
+	}(tx, ctx)
+
+	before, err := loadSnapshot(ctx, tx, musicID, true)
+	if err != nil {
+		log.Errorf("Error fetching music with ID %s: %v", musicID, err)
+		return nil, err
+	}
+	if before == nil {
+		log.Warnf("Music with ID %s not found", musicID)
+		return nil, nil
+	}
+	err = checkVersion(ctx, tx, musicID, expectedVersion)
+	if errors.Is(err, models.ErrVersionMismatch) {
+		log.Warnf("Music with ID %s was changed concurrently", musicID)
+		return nil, err
+	}
+	if err != nil {
+		log.Errorf("Error checking version of music with ID %s: %v", musicID, err)
+		return nil, err
+	}
+
+	artistID, err := ensureArtist(ctx, tx, patch.GroupName)
+	if err != nil {
+		log.Errorf("Error saving artist %s: %v", patch.GroupName, err)
+		return nil, err
+	}
+
+	query := `
+		UPDATE music
+		SET title = $2, artist_id = $3, release_date = $4, release_date_precision = $5, link = $6, version = version + 1
+		WHERE id = $1
+	`
+	_, err = tx.Exec(ctx, query, musicID, patch.SongName, artistID, patch.ReleaseDate, datePrecision(patch.ReleaseDatePrecision), patch.Link)
+	if err != nil {
+		log.Errorf("Error patching music with ID %s: %v", musicID, err)
+		return nil, err
+	}
+	err = setPrimaryArtist(ctx, tx, musicID, strconv.Itoa(artistID))
+	if err != nil {
+		log.Errorf("Error updating primary artist of music ID %s: %v", musicID, err)
+		return nil, err
+	}
+
+	if patch.ReplaceVerses {
+		_, err = tx.Exec(ctx, `DELETE FROM verses WHERE music_id = $1`, musicID)
+		if err != nil {
+			log.Errorf("Error deleting verses of music ID %s: %v", musicID, err)
+			return nil, err
+		}
+		err = insertVerses(ctx, tx, musicID, patch.Verses)
+		if err != nil {
+			log.Errorf("Error saving verses of music ID %s: %v", musicID, err)
+			return nil, err
+		}
+	}
+
+	after, err := loadSnapshot(ctx, tx, musicID, false)
+	if err != nil {
+		log.Errorf("Error fetching patched music with ID %s: %v", musicID, err)
+		return nil, err
+	}
+	err = insertRevision(ctx, tx, musicID, before, after, nil)
+	if err != nil {
+		log.Errorf("Error recording revision of music ID %s: %v", musicID, err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Errorf("Error committing transaction: %v", err)
+		return nil, err
+	}
+
+	log.Infof("Music with ID %s patched successfully", musicID)
+	return m.GetTimedLyrics(ctx, musicID)
+}
@@ -2,79 +2,233 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/Seven11Eleven/music_library/internal/domain/models"
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgxpool"
 	log "github.com/sirupsen/logrus"
+	"strconv"
 	"strings"
 )
 
+// verseColumns selects a verse of alias v together with the number of the verse it repeats (joined as r).
+const verseColumns = `v.verse_text, v.verse_number, v.section_type, COALESCE(v.section_label, ''), r.verse_number`
+
+var defaultSearchLanguages = []string{"russian", "english"}
+
 type musicRepository struct {
 	pool *pgxpool.Pool
 }
 
+type querier interface {
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+// insertVerses stores verses numbered from 1 in the given order and links repeated sections
+// to the verse they repeat.
+func insertVerses(ctx context.Context, tx pgx.Tx, musicID string, verses []models.Verse) error {
+	if len(verses) == 0 {
+		return nil
+	}
+
+	values := []string{}
+	args := []interface{}{}
+	positions := map[int]int{}
+
+	for i, verse := range verses {
+		placeholdersIndex := len(args) + 1
+		values = append(values, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d)", placeholdersIndex, placeholdersIndex+1, placeholdersIndex+2, placeholdersIndex+3, placeholdersIndex+4))
+
+		sectionType := verse.SectionType
+		if sectionType == "" {
+			sectionType = models.SectionVerse
+		}
+		var sectionLabel *string
+		if verse.SectionLabel != "" {
+			sectionLabel = &verse.SectionLabel
+		}
+
+		args = append(args, musicID, verse.Text, i+1, sectionType, sectionLabel)
+		positions[verse.Number] = i + 1
+	}
+
+	query := fmt.Sprintf("INSERT INTO verses (music_id, verse_text, verse_number, section_type, section_label) VALUES %s RETURNING id, verse_number", strings.Join(values, ", "))
+	rows, err := tx.Query(ctx, query, args...)
+	if err != nil {
+		return err
+	}
+
+	verseIDs := map[int]int{}
+	for rows.Next() {
+		var verseID, verseNumber int
+		if err := rows.Scan(&verseID, &verseNumber); err != nil {
+			rows.Close()
+			return err
+		}
+		verseIDs[verseNumber] = verseID
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	repeatQuery := `
+		UPDATE verses
+		SET repeat_of_verse_id = (SELECT id FROM verses WHERE music_id = $1 AND verse_number = $2)
+		WHERE music_id = $1 AND verse_number = $3
+	`
+	for i, verse := range verses {
+		if verse.RepeatOf == nil {
+			continue
+		}
+		repeated, ok := positions[*verse.RepeatOf]
+		if !ok || repeated >= i+1 {
+			continue
+		}
+		if _, err := tx.Exec(ctx, repeatQuery, musicID, repeated, i+1); err != nil {
+			return err
+		}
+	}
+
+	for i, verse := range verses {
+		if err := insertLines(ctx, tx, verseIDs[i+1], verse.Lines); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertLines(ctx context.Context, tx pgx.Tx, verseID int, lines []models.LyricLine) error {
+	if len(lines) == 0 {
+		return nil
+	}
+
+	values := []string{}
+	args := []interface{}{}
+
+	for i, line := range lines {
+		placeholdersIndex := len(args) + 1
+		values = append(values, fmt.Sprintf("($%d,$%d,$%d,$%d,$%d)", placeholdersIndex, placeholdersIndex+1, placeholdersIndex+2, placeholdersIndex+3, placeholdersIndex+4))
+
+		var words []byte
+		if len(line.Words) > 0 {
+			var err error
+			words, err = json.Marshal(line.Words)
+			if err != nil {
+				return err
+			}
+		}
+
+		args = append(args, verseID, i+1, line.StartMs, line.Text, words)
+	}
+
+	query := fmt.Sprintf("INSERT INTO lyric_lines (verse_id, line_number, start_ms, line_text, words) VALUES %s", strings.Join(values, ", "))
+	_, err := tx.Exec(ctx, query, args...)
+	return err
+}
+
+func scanLine(rows pgx.Rows, verseID *int) (models.LyricLine, error) {
+	var line models.LyricLine
+	var words []byte
+
+	if err := rows.Scan(verseID, &line.Number, &line.StartMs, &line.Text, &words); err != nil {
+		return line, err
+	}
+	if words != nil {
+		if err := json.Unmarshal(words, &line.Words); err != nil {
+			return line, err
+		}
+	}
+	return line, nil
+}
+
+// loadLines returns the timed lines of the given verses keyed by verse id.
+func loadLines(ctx context.Context, q querier, verseIDs []int) (map[int][]models.LyricLine, error) {
+	lines := map[int][]models.LyricLine{}
+	if len(verseIDs) == 0 {
+		return lines, nil
+	}
+
+	query := `
+		SELECT verse_id, line_number, start_ms, line_text, words
+		FROM lyric_lines
+		WHERE verse_id = ANY($1)
+		ORDER BY verse_id, line_number
+	`
+
+	rows, err := q.Query(ctx, query, verseIDs)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var verseID int
+		line, err := scanLine(rows, &verseID)
+		if err != nil {
+			return nil, err
+		}
+		lines[verseID] = append(lines[verseID], line)
+	}
+
+	return lines, rows.Err()
+}
+
 func (m musicRepository) GetMusic(ctx context.Context, musicName, groupName string) (*models.Music, error) {
 	query := `
 		SELECT 
-			m.id, m.title, m.group_name, m.release_date, m.link, 
-			v.verse_text, v.verse_number
+			m.id, m.title, COALESCE(a.name, ''), COALESCE(m.artist_id::TEXT, ''), m.release_date, m.release_date_precision, COALESCE(m.link, ''), m.version
 		FROM 
 			music m
 		LEFT JOIN 
-			verses v ON m.id = v.music_id
+			artists a ON a.id = m.artist_id
 		WHERE 
-			m.title = $1 AND m.group_name = $2
+			m.title = $1 AND lower(COALESCE(a.name, '')) = lower($2) AND m.deleted_at IS NULL
 		ORDER BY 
-			v.verse_number;
+			m.id
+		LIMIT 1;
 	`
 
-	rows, err := m.pool.Query(ctx, query, musicName, groupName)
+	var music models.Music
+	err := m.pool.QueryRow(ctx, query, musicName, groupName).Scan(&music.ID, &music.SongName, &music.GroupName, &music.ArtistID, &music.ReleaseDate, &music.ReleaseDatePrecision, &music.Link, &music.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
 	if err != nil {
 		log.Printf("Error querying music: %v", err)
 		return nil, err
 	}
-	defer rows.Close()
-
-	var music models.Music
-	var verses []models.Verse
-	isFirstRow := true
-
-	for rows.Next() {
-		var verse models.Verse
-		if isFirstRow {
-			err := rows.Scan(&music.ID, &music.SongName, &music.GroupName, &music.ReleaseDate, &music.Link, &verse.Text, &verse.Number)
-			if err != nil {
-				log.Printf("Error scanning row: %v", err)
-				return nil, err
-			}
-			isFirstRow = false
-		} else {
-			err := rows.Scan(nil, nil, nil, nil, nil, &verse.Text, &verse.Number)
-			if err != nil {
-				log.Printf("Error scanning verse row: %v", err)
-				return nil, err
-			}
-		}
-		verses = append(verses, verse)
-	}
 
-	if isFirstRow {
-		return nil, nil
+	// Куплеты читаются отдельно, чтобы песня без куплетов не давала строку из NULL
+	music.Verses, err = loadVerses(ctx, m.pool, music.ID)
+	if err != nil {
+		log.Printf("Error querying verses: %v", err)
+		return nil, err
 	}
 
-	music.Verses = verses
-
 	return &music, nil
 }
 
+// datePrecision returns the precision stored with a release date; dates are known to the day unless
+// told otherwise.
+func datePrecision(precision models.DatePrecision) string {
+	if precision == "" {
+		return string(models.PrecisionDay)
+	}
+	return string(precision)
+}
+
 func (m musicRepository) SaveMusic(ctx context.Context, music *models.Music) (*models.Music, error) {
 	log.Infof("Saving new music: %s by %s", music.SongName, music.GroupName)
 
 	var musicID int
 	query := `
-	INSERT INTO music (release_date, title, group_name, link) 
-	VALUES ($1, $2, $3, $4)
+	INSERT INTO music (release_date, title, artist_id, link, release_date_precision) 
+	VALUES ($1, $2, $3, $4, $5)
 	RETURNING id
 	`
 
@@ -90,7 +244,20 @@ func (m musicRepository) SaveMusic(ctx context.Context, music *models.Music) (*m
 		}
 	}(tx, ctx)
 
-	err = tx.QueryRow(ctx, query, music.ReleaseDate, music.SongName, music.GroupName, music.Link).Scan(&musicID)
+	var artistID *string
+	if music.ArtistID != "" {
+		artistID = &music.ArtistID
+	} else if music.GroupName != "" {
+		id, err := ensureArtist(ctx, tx, music.GroupName)
+		if err != nil {
+			log.Errorf("Error saving artist %s: %v", music.GroupName, err)
+			return nil, err
+		}
+		music.ArtistID = strconv.Itoa(id)
+		artistID = &music.ArtistID
+	}
+
+	err = tx.QueryRow(ctx, query, music.ReleaseDate, music.SongName, artistID, music.Link, datePrecision(music.ReleaseDatePrecision)).Scan(&musicID)
 	if err != nil {
 		log.Errorf("Error saving music: %v", err)
 		return nil, err
@@ -98,23 +265,78 @@ func (m musicRepository) SaveMusic(ctx context.Context, music *models.Music) (*m
 
 	log.Infof("Music saved with ID: %d", musicID)
 
-	values := []string{}
-	args := []interface{}{}
-	placeholdersIndex := 1
+	log.Infof("Saving %d verses for music ID %d", len(music.Verses), musicID)
+
+	err = insertVerses(ctx, tx, strconv.Itoa(musicID), music.Verses)
+	if err != nil {
+		log.Errorf("Error saving music verses: %v", err)
+		return nil, err
+	}
 
-	for _, verse := range music.Verses {
-		values = append(values, fmt.Sprintf("($%d,$%d,$%d)", placeholdersIndex, 1+placeholdersIndex, placeholdersIndex+2))
-		args = append(args, musicID, verse.Text, placeholdersIndex/3+1)
-		placeholdersIndex += 3
+	err = insertMusicArtists(ctx, tx, musicID, artistID, music.Artists)
+	if err != nil {
+		log.Errorf("Error saving artists of music ID %d: %v", musicID, err)
+		return nil, err
 	}
 
-	query = fmt.Sprintf("INSERT INTO verses (music_id, verse_text, verse_number) VALUES %s", strings.Join(values, ", "))
+	artists, err := loadMusicArtists(ctx, tx, []string{strconv.Itoa(musicID)})
+	if err != nil {
+		log.Errorf("Error fetching artists of music ID %d: %v", musicID, err)
+		return nil, err
+	}
+	music.Artists = artists[strconv.Itoa(musicID)]
 
-	log.Infof("Saving %d verses for music ID %d", len(music.Verses), musicID)
+	// Теги при сохранении приходят только из обогащения
+	err = insertMusicTags(ctx, tx, musicID, music.Tags, models.TagSourceLastFM)
+	if err != nil {
+		log.Errorf("Error saving tags of music ID %d: %v", musicID, err)
+		return nil, err
+	}
+	err = insertMatchingGenres(ctx, tx, musicID, music.Tags)
+	if err != nil {
+		log.Errorf("Error saving genres of music ID %d: %v", musicID, err)
+		return nil, err
+	}
 
-	_, err = tx.Exec(ctx, query, args...)
+	tags, err := loadMusicTags(ctx, tx, []string{strconv.Itoa(musicID)})
 	if err != nil {
-		log.Errorf("Error saving music verses: %v", err)
+		log.Errorf("Error fetching tags of music ID %d: %v", musicID, err)
+		return nil, err
+	}
+	music.Genres = tags[strconv.Itoa(musicID)].Genres
+	music.Tags = tags[strconv.Itoa(musicID)].Tags
+
+	if music.Album != nil && music.Album.Title != "" {
+		albumID, err := ensureAlbum(ctx, tx, artistID, music.Album.Title, music.Album.Link)
+		if err != nil {
+			log.Errorf("Error saving album %s: %v", music.Album.Title, err)
+			return nil, err
+		}
+
+		// Позиция может быть уже занята другой песней, тогда песня попадает в альбом без номера
+		trackQuery := `
+			INSERT INTO album_tracks (album_id, music_id, track_number)
+			VALUES ($1, $2, CASE
+				WHEN EXISTS (SELECT 1 FROM album_tracks WHERE album_id = $1 AND disc_number = 1 AND track_number = $3) THEN NULL
+				ELSE NULLIF($3, 0)
+			END)
+		`
+		_, err = tx.Exec(ctx, trackQuery, albumID, musicID, music.Album.TrackNumber)
+		if err != nil {
+			log.Errorf("Error adding music %d to album %d: %v", musicID, albumID, err)
+			return nil, err
+		}
+		music.Album.ID = strconv.Itoa(albumID)
+	}
+
+	snapshot, err := loadSnapshot(ctx, tx, strconv.Itoa(musicID), false)
+	if err != nil {
+		log.Errorf("Error fetching saved music ID %d: %v", musicID, err)
+		return nil, err
+	}
+	err = insertRevision(ctx, tx, strconv.Itoa(musicID), nil, snapshot, nil)
+	if err != nil {
+		log.Errorf("Error recording revision of music ID %d: %v", musicID, err)
 		return nil, err
 	}
 
@@ -124,32 +346,124 @@ func (m musicRepository) SaveMusic(ctx context.Context, music *models.Music) (*m
 		return nil, err
 	}
 
+	music.ID = strconv.Itoa(musicID)
+	music.Version = 1
+	music.ReleaseDatePrecision = models.DatePrecision(datePrecision(music.ReleaseDatePrecision))
 	log.Infof("Music and verses saved successfully for ID: %d", musicID)
 	return music, nil
 }
 
-func (m musicRepository) GetMusicsByFilters(ctx context.Context, filters models.MusicFilters, page, pageSize int) ([]models.Music, error) {
-	log.Infof("Fetching music list with filters: %+v", filters)
-	query := `
-				SELECT 
-				    	id, release_date, title, group_name, link
-				FROM 
-				    	music
-				WHERE
-				    	1=1
+// musicFilterConditions filters songs of alias m by models.MusicFilters given as the arguments
+// from musicFilterArgs.
+const musicFilterConditions = `
+				    	m.deleted_at IS NULL
 					AND
-					    	($1::DATE IS NULL OR release_date = $1::DATE)
+					    	($1::DATE IS NULL OR m.release_date = $1::DATE)
 					AND	
-					    	($2::TEXT IS NULL OR title ILIKE '%' || $2::TEXT || '%')
+					    	($2::TEXT IS NULL OR m.title ILIKE '%' || $2::TEXT || '%')
 					AND	
-					    	($3::TEXT IS NULL OR group_name ILIKE '%' || $3::TEXT || '%')
+					    	($3::TEXT IS NULL OR EXISTS (
+					    		SELECT 1 FROM music_artists ma JOIN artists x ON x.id = ma.artist_id
+					    		WHERE ma.music_id = m.id AND x.name ILIKE '%' || $3::TEXT || '%'
+					    	))
+					AND
+					    	($4::TEXT IS NULL OR m.link = $4::TEXT)
+					AND
+					    	($5::INT IS NULL OR EXISTS (
+					    		SELECT 1 FROM music_artists ma WHERE ma.music_id = m.id AND ma.artist_id = $5::INT
+					    	))
 					AND
-					    	($4::TEXT IS NULL OR link = $4::TEXT)
-				LIMIT $5 OFFSET $6	
+					    	($6::TEXT[] IS NULL OR (
+					    		SELECT count(DISTINCT t.name) FROM music_tags mt JOIN tags t ON t.id = mt.tag_id
+					    		WHERE mt.music_id = m.id AND t.name = ANY($6::TEXT[])
+					    	) >= CASE WHEN $7::TEXT = 'any' THEN 1 ELSE cardinality($6::TEXT[]) END)
+					AND
+					    	($8::TEXT IS NULL OR EXISTS (
+					    		SELECT 1 FROM music_genres mg JOIN genres g ON g.id = mg.genre_id
+					    		WHERE mg.music_id = m.id AND lower(g.name) = lower($8::TEXT)
+					    	))
+					AND
+					    	($9::DATE IS NULL OR m.release_date >= $9::DATE)
+					AND
+					    	($10::DATE IS NULL OR m.release_date <= $10::DATE)`
+
+func musicFilterArgs(filters models.MusicFilters) []interface{} {
+	return []interface{}{
+		filters.ReleaseDate, filters.SongName, filters.GroupName, filters.Link,
+		filters.ArtistID, filters.Tags, string(filters.TagMode), filters.Genre,
+		filters.ReleaseDateFrom, filters.ReleaseDateTo,
+	}
+}
+
+func (m musicRepository) CountMusicsByFilters(ctx context.Context, filters models.MusicFilters) (int, error) {
+	query := `SELECT count(*) FROM music m WHERE` + musicFilterConditions
+
+	var total int
+	err := m.pool.QueryRow(ctx, query, musicFilterArgs(filters)...).Scan(&total)
+	if err != nil {
+		log.Errorf("Error counting music: %v", err)
+		return 0, err
+	}
+	return total, nil
+}
+
+// musicListQuery selects the songs matching musicFilterConditions.
+const musicListQuery = `
+				SELECT 
+				    	m.id, m.release_date, m.release_date_precision, m.title, COALESCE(a.name, ''), COALESCE(m.artist_id::TEXT, ''), COALESCE(m.link, ''), m.version, m.created_at
+				FROM 
+				    	music m
+				LEFT JOIN
+				    	artists a ON a.id = m.artist_id
+				WHERE` + musicFilterConditions
+
+func (m musicRepository) GetMusicsByFilters(ctx context.Context, filters models.MusicFilters, page, pageSize int) ([]models.Music, error) {
+	log.Infof("Fetching music list with filters: %+v", filters)
+	orderBy, err := musicOrderBy(filters.Sort)
+	if err != nil {
+		log.Warnf("Invalid sort %v: %v", filters.Sort, err)
+		return nil, err
+	}
+	query := musicListQuery + `
+				ORDER BY ` + orderBy + `
+				LIMIT $11 OFFSET $12
 `
 	offset := (page - 1) * pageSize
 
-	rows, err := m.pool.Query(ctx, query, filters.ReleaseDate, filters.SongName, filters.GroupName, filters.Link, pageSize, offset)
+	args := append(musicFilterArgs(filters), pageSize, offset)
+	return m.listMusics(ctx, query, args)
+}
+
+// GetMusicsAfter returns up to limit songs matching filters that follow after in the order of
+// filters.Sort. Unlike GetMusicsByFilters it does not skip the previous pages, so deep pages are
+// as fast as the first one.
+func (m musicRepository) GetMusicsAfter(ctx context.Context, filters models.MusicFilters, after models.Cursor, limit int) ([]models.Music, error) {
+	log.Infof("Fetching music list after ID %d with filters: %+v", after.ID, filters)
+	orderBy, err := musicOrderBy(filters.Sort)
+	if err != nil {
+		log.Warnf("Invalid sort %v: %v", filters.Sort, err)
+		return nil, err
+	}
+	args := musicFilterArgs(filters)
+	condition, keysetArgs, err := musicKeysetCondition(filters.Sort, after, len(args)+1)
+	if err != nil {
+		log.Warnf("Invalid cursor %+v: %v", after, err)
+		return nil, err
+	}
+	args = append(append(args, keysetArgs...), limit)
+
+	query := musicListQuery + `
+				AND ` + condition + `
+				ORDER BY ` + orderBy + `
+				LIMIT $` + strconv.Itoa(len(args)) + `
+`
+	return m.listMusics(ctx, query, args)
+}
+
+// listMusics runs a query selecting the columns of musicListQuery and loads the artists and tags
+// of the songs it returns.
+func (m musicRepository) listMusics(ctx context.Context, query string, args []interface{}) ([]models.Music, error) {
+	rows, err := m.pool.Query(ctx, query, args...)
 	if err != nil {
 		log.Errorf("Error fetching music list: %v", err)
 		return nil, err
@@ -159,137 +473,482 @@ func (m musicRepository) GetMusicsByFilters(ctx context.Context, filters models.
 	var musics []models.Music
 	for rows.Next() {
 		var music models.Music
-		err := rows.Scan(&music.ID, &music.ReleaseDate, &music.SongName, &music.GroupName, &music.Link)
+		err := rows.Scan(&music.ID, &music.ReleaseDate, &music.ReleaseDatePrecision, &music.SongName, &music.GroupName, &music.ArtistID, &music.Link, &music.Version, &music.CreatedAt)
 		if err != nil {
 			log.Errorf("Error scanning music row: %v", err)
 			return nil, err
 		}
 		musics = append(musics, music)
 	}
+	if err := rows.Err(); err != nil {
+		log.Errorf("Error iterating music rows: %v", err)
+		return nil, err
+	}
+
+	musicIDs := make([]string, 0, len(musics))
+	for _, music := range musics {
+		musicIDs = append(musicIDs, music.ID)
+	}
+	artists, err := loadMusicArtists(ctx, m.pool, musicIDs)
+	if err != nil {
+		log.Errorf("Error fetching artists of music list: %v", err)
+		return nil, err
+	}
+	tags, err := loadMusicTags(ctx, m.pool, musicIDs)
+	if err != nil {
+		log.Errorf("Error fetching tags of music list: %v", err)
+		return nil, err
+	}
+	for i := range musics {
+		musics[i].Artists = artists[musics[i].ID]
+		musics[i].Genres = tags[musics[i].ID].Genres
+		musics[i].Tags = tags[musics[i].ID].Tags
+	}
 
 	log.Infof("Successfully fetched %d music records", len(musics))
 	return musics, nil
 }
 
-func (m musicRepository) GetMusicTextWithPaginationByVerse(ctx context.Context, musicID string, limit, offset int) (*models.Music, error) {
+func (m musicRepository) GetMusicTextWithPaginationByVerse(ctx context.Context, musicID string, filters models.VerseFilters, limit, offset int) (*models.Music, error) {
+	if filters.AtMs != nil {
+		return m.getActiveLine(ctx, musicID, *filters.AtMs)
+	}
+
 	log.Infof("Fetching verses for music ID: %s with pagination limit %d, offset %d", musicID, limit, offset)
+	return m.getVerses(ctx, musicID, filters, 0, limit, offset)
+}
+
+// GetVersesAfter returns the song with up to limit of its verses numbered after afterNumber, or
+// models.ErrMusicNotFound if the song does not exist or is in the trash.
+func (m musicRepository) GetVersesAfter(ctx context.Context, musicID string, filters models.VerseFilters, afterNumber, limit int) (*models.Music, error) {
+	log.Infof("Fetching verses for music ID: %s after verse %d with limit %d", musicID, afterNumber, limit)
+	return m.getVerses(ctx, musicID, filters, afterNumber, limit, 0)
+}
+
+func (m musicRepository) getVerses(ctx context.Context, musicID string, filters models.VerseFilters, afterNumber, limit, offset int) (*models.Music, error) {
+	musicVerses, err := loadMusicHeader(ctx, m.pool, musicID)
+	if err != nil {
+		return nil, err
+	}
+
 	query := `
         SELECT 
-            m.id AS music_id,
-            m.title,
-            m.release_date,
-            m.group_name,
-            m.link,
-            v.verse_text,
-            v.verse_number
+            v.id,
+            ` + verseColumns + `
         FROM 
-            music m
-        JOIN 
-            verses v ON m.id = v.music_id
+            verses v
+        LEFT JOIN 
+            verses r ON r.id = v.repeat_of_verse_id
         WHERE 
-            m.id = $1
+            v.music_id = $1
+            AND ($4::TEXT IS NULL OR v.section_type = $4::TEXT)
+            AND v.verse_number > $5
         ORDER BY 
             v.verse_number
         LIMIT $2 OFFSET $3;
     `
 
-	rows, err := m.pool.Query(ctx, query, musicID, limit, offset)
+	rows, err := m.pool.Query(ctx, query, musicID, limit, offset, filters.SectionType, afterNumber)
 	if err != nil {
 		log.Errorf("Error fetching verses: %v", err)
 		return nil, err
 	}
 	defer rows.Close()
 
-	var musicVerses models.Music
 	var verses []models.Verse
+	var verseIDs []int
 
 	for rows.Next() {
 		var verse models.Verse
-		if err := rows.Scan(&musicVerses.ID, &musicVerses.SongName, &musicVerses.ReleaseDate, &musicVerses.GroupName, &musicVerses.Link, &verse.Text, &verse.Number); err != nil {
+		var verseID int
+		if err := rows.Scan(&verseID, &verse.Text, &verse.Number, &verse.SectionType, &verse.SectionLabel, &verse.RepeatOf); err != nil {
 			log.Errorf("Error scanning verse row: %v", err)
 			return nil, err
 		}
 		verses = append(verses, verse)
+		verseIDs = append(verseIDs, verseID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		log.Errorf("Error fetching verses: %v", err)
+		return nil, err
+	}
+
+	lines, err := loadLines(ctx, m.pool, verseIDs)
+	if err != nil {
+		log.Errorf("Error fetching timed lines for music ID %s: %v", musicID, err)
+		return nil, err
+	}
+	for i := range verses {
+		verses[i].Lines = lines[verseIDs[i]]
 	}
 
 	musicVerses.Verses = verses
 	log.Infof("Successfully fetched %d verses for music ID: %s", len(verses), musicID)
-	return &musicVerses, nil
+	return musicVerses, nil
 }
 
-func (m musicRepository) DeleteMusic(ctx context.Context, musicID string) error {
-	log.Infof("Deleting music with ID: %s", musicID)
-	tx, err := m.pool.BeginTx(ctx, pgx.TxOptions{
-		IsoLevel: pgx.Serializable,
-	})
+// getActiveLine returns the song with only the verse and the line that is playing at atMs.
+func (m musicRepository) getActiveLine(ctx context.Context, musicID string, atMs int64) (*models.Music, error) {
+	log.Infof("Fetching line active at %dms for music ID: %s", atMs, musicID)
+	music, err := loadMusicHeader(ctx, m.pool, musicID)
 	if err != nil {
-		log.Errorf("Error beginning transaction for delete: %v", err)
-		return err
+		return nil, err
 	}
-	defer func(tx pgx.Tx, ctx context.Context) {
-		err := tx.Rollback(ctx)
-		if err != nil && err != pgx.ErrTxClosed {
-			log.Warnf("Error rolling back transaction: %v", err)
-		}
-	}(tx, ctx)
 
-	query := `DELETE FROM music WHERE id = $1;`
+	query := `
+		SELECT
+			` + verseColumns + `,
+			l.line_number, l.start_ms, l.line_text, l.words
+		FROM
+			lyric_lines l
+		JOIN
+			verses v ON v.id = l.verse_id
+		LEFT JOIN
+			verses r ON r.id = v.repeat_of_verse_id
+		WHERE
+			v.music_id = $1 AND l.start_ms <= $2
+		ORDER BY
+			l.start_ms DESC, v.verse_number DESC, l.line_number DESC
+		LIMIT 1
+	`
 
-	_, err = tx.Exec(ctx, query, musicID)
+	var verse models.Verse
+	var words []byte
+	var line models.LyricLine
+	err = m.pool.QueryRow(ctx, query, musicID, atMs).Scan(
+		&verse.Text, &verse.Number, &verse.SectionType, &verse.SectionLabel, &verse.RepeatOf,
+		&line.Number, &line.StartMs, &line.Text, &words,
+	)
+	if errors.Is(err, pgx.ErrNoRows) {
+		// Ещё ни одна строка не началась
+		return music, nil
+	}
 	if err != nil {
-		log.Errorf("Error deleting music with ID %s: %v", musicID, err)
-		return err
+		log.Errorf("Error fetching active line: %v", err)
+		return nil, err
 	}
-
-	if err := tx.Commit(ctx); err != nil {
-		log.Errorf("Error committing delete transaction: %v", err)
-		return err
+	if words != nil {
+		if err := json.Unmarshal(words, &line.Words); err != nil {
+			return nil, err
+		}
 	}
 
-	log.Infof("Music with ID %s deleted successfully", musicID)
-	return nil
+	verse.Lines = []models.LyricLine{line}
+	music.Verses = []models.Verse{verse}
+	return music, nil
 }
 
-func (m musicRepository) UpdateMusic(ctx context.Context, music models.Music) (models.Music, error) {
-	log.Infof("Updating music with ID: %s", music.ID)
-	// Обновление данных музыки
-	query := `UPDATE music SET `
-	params := []interface{}{}
-	paramCount := 1
+// loadMusicHeader returns a song without its verses, artists and tags, or models.ErrMusicNotFound if
+// it does not exist or is in the trash.
+func loadMusicHeader(ctx context.Context, q querier, musicID string) (*models.Music, error) {
+	query := `
+		SELECT m.id, m.title, m.release_date, m.release_date_precision, COALESCE(a.name, ''), COALESCE(m.artist_id::TEXT, ''), COALESCE(m.link, ''), m.version
+		FROM music m
+		LEFT JOIN artists a ON a.id = m.artist_id
+		WHERE m.id = $1 AND m.deleted_at IS NULL
+	`
 
-	if music.ReleaseDate != nil {
-		query += fmt.Sprintf("release_date = $%d, ", paramCount)
-		paramCount++
-		params = append(params, music.ReleaseDate)
+	var music models.Music
+	err := q.QueryRow(ctx, query, musicID).Scan(&music.ID, &music.SongName, &music.ReleaseDate, &music.ReleaseDatePrecision, &music.GroupName, &music.ArtistID, &music.Link, &music.Version)
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Warnf("Music with ID %s not found", musicID)
+		return nil, models.ErrMusicNotFound
 	}
-	if music.Link != "" {
-		query += fmt.Sprintf("link = $%d, ", paramCount)
-		paramCount++
-		params = append(params, music.Link)
+	if err != nil {
+		log.Errorf("Error fetching music with ID %s: %v", musicID, err)
+		return nil, err
 	}
-	if music.SongName != "" {
+	return &music, nil
+}
+
+// loadVerses returns every verse of a song with its timed lines.
+func loadVerses(ctx context.Context, q querier, musicID string) ([]models.Verse, error) {
+	query := `
+		SELECT v.id, ` + verseColumns + `
+		FROM verses v
+		LEFT JOIN verses r ON r.id = v.repeat_of_verse_id
+		WHERE v.music_id = $1
+		ORDER BY v.verse_number
+	`
+
+	rows, err := q.Query(ctx, query, musicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var verses []models.Verse
+	var verseIDs []int
+	for rows.Next() {
+		var verse models.Verse
+		var verseID int
+		if err := rows.Scan(&verseID, &verse.Text, &verse.Number, &verse.SectionType, &verse.SectionLabel, &verse.RepeatOf); err != nil {
+			return nil, err
+		}
+		verses = append(verses, verse)
+		verseIDs = append(verseIDs, verseID)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	lines, err := loadLines(ctx, q, verseIDs)
+	if err != nil {
+		return nil, err
+	}
+	for i := range verses {
+		verses[i].Lines = lines[verseIDs[i]]
+	}
+
+	return verses, nil
+}
+
+func (m musicRepository) GetTimedLyrics(ctx context.Context, musicID string) (*models.Music, error) {
+	log.Infof("Fetching timed lyrics for music ID: %s", musicID)
+	return m.GetMusicByID(ctx, musicID, true)
+}
+
+// GetMusicByID returns a song with its artists, genres and tags, and its verses with their timed
+// lines if withVerses is set. It returns nil if the song does not exist.
+func (m musicRepository) GetMusicByID(ctx context.Context, musicID string, withVerses bool) (*models.Music, error) {
+	log.Infof("Fetching music with ID: %s", musicID)
+
+	var music models.Music
+	query := `
+		SELECT m.id, m.title, COALESCE(a.name, ''), COALESCE(m.artist_id::TEXT, ''), m.release_date, m.release_date_precision, COALESCE(m.link, ''), m.version, m.created_at
+		FROM music m
+		LEFT JOIN artists a ON a.id = m.artist_id
+		WHERE m.id = $1 AND m.deleted_at IS NULL
+	`
+	err := m.pool.QueryRow(ctx, query, musicID).Scan(&music.ID, &music.SongName, &music.GroupName, &music.ArtistID, &music.ReleaseDate, &music.ReleaseDatePrecision, &music.Link, &music.Version, &music.CreatedAt)
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Warnf("Music with ID %s not found", musicID)
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf("Error fetching music with ID %s: %v", musicID, err)
+		return nil, err
+	}
+
+	if withVerses {
+		music.Verses, err = loadVerses(ctx, m.pool, musicID)
+		if err != nil {
+			log.Errorf("Error fetching verses for music ID %s: %v", musicID, err)
+			return nil, err
+		}
+	}
+
+	artists, err := loadMusicArtists(ctx, m.pool, []string{music.ID})
+	if err != nil {
+		log.Errorf("Error fetching artists for music ID %s: %v", musicID, err)
+		return nil, err
+	}
+	music.Artists = artists[music.ID]
+
+	tags, err := loadMusicTags(ctx, m.pool, []string{music.ID})
+	if err != nil {
+		log.Errorf("Error fetching tags for music ID %s: %v", musicID, err)
+		return nil, err
+	}
+	music.Genres = tags[music.ID].Genres
+	music.Tags = tags[music.ID].Tags
+
+	log.Infof("Successfully fetched music with ID %s and %d verses", musicID, len(music.Verses))
+	return &music, nil
+}
+
+func (m musicRepository) ReplaceVerses(ctx context.Context, musicID string, verses []models.Verse, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Replacing verses of music ID: %s with %d verses", musicID, len(verses))
+
+	res, err := m.editVerses(ctx, musicID, expectedVersion, func(tx pgx.Tx) error {
+		_, err := tx.Exec(ctx, `DELETE FROM verses WHERE music_id = $1`, musicID)
+		if err != nil {
+			log.Errorf("Error deleting verses of music ID %s: %v", musicID, err)
+			return err
+		}
+
+		err = insertVerses(ctx, tx, musicID, verses)
+		if err != nil {
+			log.Errorf("Error saving verses of music ID %s: %v", musicID, err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res != nil {
+		log.Infof("Verses of music ID %s replaced successfully", musicID)
+	}
+	return res, nil
+}
+
+// checkVersion locks the song and fails with models.ErrVersionMismatch unless it is at expectedVersion.
+// A nil expectedVersion matches any version. A song that does not exist or is in the trash gives pgx.ErrNoRows.
+func checkVersion(ctx context.Context, q querier, musicID string, expectedVersion *int) error {
+	var version int
+	err := q.QueryRow(ctx, `SELECT version FROM music WHERE id = $1 AND deleted_at IS NULL FOR UPDATE`, musicID).Scan(&version)
+	if err != nil {
+		return err
+	}
+
+	if expectedVersion != nil && *expectedVersion != version {
+		return models.ErrVersionMismatch
+	}
+	return nil
+}
+
+// bumpVersion checks the version of the song like checkVersion and increments it.
+func bumpVersion(ctx context.Context, tx pgx.Tx, musicID string, expectedVersion *int) error {
+	if err := checkVersion(ctx, tx, musicID, expectedVersion); err != nil {
+		return err
+	}
+	_, err := tx.Exec(ctx, `UPDATE music SET version = version + 1 WHERE id = $1`, musicID)
+	return err
+}
+
+func (m musicRepository) DeleteMusic(ctx context.Context, musicID string, expectedVersion *int) error {
+	log.Infof("Deleting music with ID: %s", musicID)
+	tx, err := m.pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel: pgx.Serializable,
+	})
+	if err != nil {
+		log.Errorf("Error beginning transaction for delete: %v", err)
+		return err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			log.Warnf("Error rolling back transaction: %v", err)
+		}
+	}(tx, ctx)
+
+	err = checkVersion(ctx, tx, musicID, expectedVersion)
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Warnf("Music with ID %s not found", musicID)
+		return models.ErrMusicNotFound
+	}
+	if errors.Is(err, models.ErrVersionMismatch) {
+		log.Warnf("Music with ID %s was changed concurrently", musicID)
+		return err
+	}
+	if err != nil {
+		log.Errorf("Error locking music with ID %s: %v", musicID, err)
+		return err
+	}
+
+	// Песня попадает в корзину, стихи и связи остаются до очистки корзины
+	query := `UPDATE music SET deleted_at = now(), version = version + 1 WHERE id = $1 AND deleted_at IS NULL;`
+
+	_, err = tx.Exec(ctx, query, musicID)
+	if err != nil {
+		log.Errorf("Error deleting music with ID %s: %v", musicID, err)
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Errorf("Error committing delete transaction: %v", err)
+		return err
+	}
+
+	log.Infof("Music with ID %s moved to trash", musicID)
+	return nil
+}
+
+func (m musicRepository) UpdateMusic(ctx context.Context, music models.Music, expectedVersion *int) (models.Music, error) {
+	log.Infof("Updating music with ID: %s", music.ID)
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Error beginning transaction: %v", err)
+		return models.Music{}, err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			log.Warnf("Error rolling back transaction: %v", err)
+		}
+	}(tx, ctx)
+
+	before, err := loadSnapshot(ctx, tx, music.ID, true)
+	if err != nil {
+		log.Errorf("Error fetching music with ID %s: %v", music.ID, err)
+		return models.Music{}, err
+	}
+	if before == nil {
+		log.Warnf("Music with ID %s not found", music.ID)
+		return models.Music{}, models.ErrMusicNotFound
+	}
+	err = checkVersion(ctx, tx, music.ID, expectedVersion)
+	if errors.Is(err, models.ErrVersionMismatch) {
+		log.Warnf("Music with ID %s was changed concurrently", music.ID)
+		return models.Music{}, err
+	}
+	if err != nil {
+		log.Errorf("Error checking version of music with ID %s: %v", music.ID, err)
+		return models.Music{}, err
+	}
+
+	// Обновление данных музыки
+	query := `UPDATE music SET `
+	params := []interface{}{}
+	paramCount := 1
+
+	if music.ReleaseDate != nil {
+		query += fmt.Sprintf("release_date = $%d, release_date_precision = $%d, ", paramCount, paramCount+1)
+		paramCount += 2
+		params = append(params, music.ReleaseDate, datePrecision(music.ReleaseDatePrecision))
+	}
+	if music.Link != "" {
+		query += fmt.Sprintf("link = $%d, ", paramCount)
+		paramCount++
+		params = append(params, music.Link)
+	}
+	if music.SongName != "" {
 		query += fmt.Sprintf("title = $%d, ", paramCount)
 		paramCount++
 		params = append(params, music.SongName)
 	}
-	if music.GroupName != "" {
-		query += fmt.Sprintf("group_name = $%d, ", paramCount)
+	if music.ArtistID == "" && music.GroupName != "" {
+		artistID, err := ensureArtist(ctx, tx, music.GroupName)
+		if err != nil {
+			log.Errorf("Error saving artist %s: %v", music.GroupName, err)
+			return models.Music{}, err
+		}
+		music.ArtistID = strconv.Itoa(artistID)
+	}
+	if music.ArtistID != "" {
+		query += fmt.Sprintf("artist_id = $%d, ", paramCount)
 		paramCount++
-		params = append(params, music.GroupName)
+		params = append(params, music.ArtistID)
 	}
 
-	query = query[:len(query)-2]
-	query += fmt.Sprintf(" WHERE id = $%d RETURNING id, release_date, title, group_name, link", paramCount)
+	query += "version = version + 1"
+	query += fmt.Sprintf(" WHERE id = $%d RETURNING id, release_date, release_date_precision, title, artist_id, link, version", paramCount)
 	params = append(params, music.ID)
 
+	// Название группы берётся у исполнителя, на которого ссылается песня
+	query = `
+		WITH updated AS (` + query + `)
+		SELECT u.id, u.release_date, u.release_date_precision, u.title, COALESCE(a.name, ''), COALESCE(u.artist_id::TEXT, ''), COALESCE(u.link, ''), u.version
+		FROM updated u
+		LEFT JOIN artists a ON a.id = u.artist_id
+	`
+
 	var updatedMusic models.Music
 
-	err := m.pool.QueryRow(ctx, query, params...).Scan(
+	err = tx.QueryRow(ctx, query, params...).Scan(
 		&updatedMusic.ID,
 		&updatedMusic.ReleaseDate,
+		&updatedMusic.ReleaseDatePrecision,
 		&updatedMusic.SongName,
 		&updatedMusic.GroupName,
+		&updatedMusic.ArtistID,
 		&updatedMusic.Link,
+		&updatedMusic.Version,
 	)
 	if err != nil {
 		log.Errorf("Error updating music with ID %s: %v", music.ID, err)
@@ -298,10 +957,26 @@ func (m musicRepository) UpdateMusic(ctx context.Context, music models.Music) (m
 
 	log.Infof("Music with ID %s updated successfully", music.ID)
 
+	if music.ArtistID != "" {
+		err = setPrimaryArtist(ctx, tx, updatedMusic.ID, updatedMusic.ArtistID)
+		if err != nil {
+			log.Errorf("Error updating primary artist of music ID %s: %v", music.ID, err)
+			return models.Music{}, err
+		}
+	}
+
 	if len(music.Verses) > 0 {
 		for _, verse := range music.Verses {
-			verseUpdateQuery := `UPDATE verses SET verse_text = $1 WHERE music_id = $2 AND verse_number = $3`
-			_, err := m.pool.Exec(ctx, verseUpdateQuery, verse.Text, music.ID, verse.Number)
+			// Timed lines no longer match a verse whose text was rewritten, so they are dropped with it
+			verseUpdateQuery := `
+				WITH updated AS (
+					UPDATE verses SET verse_text = $1
+					WHERE music_id = $2 AND verse_number = $3 AND verse_text IS DISTINCT FROM $1
+					RETURNING id
+				)
+				DELETE FROM lyric_lines WHERE verse_id IN (SELECT id FROM updated)
+			`
+			_, err := tx.Exec(ctx, verseUpdateQuery, verse.Text, music.ID, verse.Number)
 			if err != nil {
 				log.Errorf("Error updating verse for music ID %s: %v", music.ID, err)
 				return models.Music{}, err
@@ -309,28 +984,620 @@ func (m musicRepository) UpdateMusic(ctx context.Context, music models.Music) (m
 		}
 	}
 
-	versesQuery := `SELECT verse_text, verse_number FROM verses WHERE music_id = $1 ORDER BY verse_number`
-	rows, err := m.pool.Query(ctx, versesQuery, updatedMusic.ID)
+	after, err := loadSnapshot(ctx, tx, music.ID, false)
+	if err != nil {
+		log.Errorf("Error fetching updated music with ID %s: %v", music.ID, err)
+		return models.Music{}, err
+	}
+	err = insertRevision(ctx, tx, music.ID, before, after, nil)
+	if err != nil {
+		log.Errorf("Error recording revision of music ID %s: %v", music.ID, err)
+		return models.Music{}, err
+	}
+
+	verses, err := loadVerses(ctx, tx, updatedMusic.ID)
 	if err != nil {
 		log.Errorf("Error fetching updated verses for music ID %s: %v", updatedMusic.ID, err)
 		return models.Music{}, err
 	}
+
+	updatedMusic.Verses = verses
+
+	artists, err := loadMusicArtists(ctx, tx, []string{updatedMusic.ID})
+	if err != nil {
+		log.Errorf("Error fetching artists for music ID %s: %v", updatedMusic.ID, err)
+		return models.Music{}, err
+	}
+	updatedMusic.Artists = artists[updatedMusic.ID]
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Errorf("Error committing transaction: %v", err)
+		return models.Music{}, err
+	}
+	log.Infof("Music with ID %s and verses updated successfully", updatedMusic.ID)
+
+	return updatedMusic, nil
+}
+
+// CountSearchResults returns the number of songs with verses matching query.
+func (m musicRepository) CountSearchResults(ctx context.Context, query string) (int, error) {
+	sqlQuery := `
+		SELECT count(DISTINCT v.music_id)
+		FROM verses v
+		JOIN music m ON m.id = v.music_id AND m.deleted_at IS NULL
+		WHERE v.search_vector @@ lyrics_tsquery($1)
+	`
+
+	var total int
+	err := m.pool.QueryRow(ctx, sqlQuery, query).Scan(&total)
+	if err != nil {
+		log.Errorf("Error counting search results: %v", err)
+		return 0, err
+	}
+	return total, nil
+}
+
+// SearchMusic ranks songs by the summed relevance of their verses matching query and returns
+// the matching verses of each song with the matched words highlighted.
+func (m musicRepository) SearchMusic(ctx context.Context, query string, limit, offset int) ([]models.SearchResult, error) {
+	log.Infof("Searching lyrics for: %s", query)
+	sqlQuery := `
+		WITH q AS (
+			SELECT lyrics_tsquery($1) AS query, (SELECT languages[1] FROM search_settings) AS lang
+		),
+		matched AS (
+			SELECT v.music_id, v.verse_number, v.verse_text, ts_rank_cd(v.search_vector, q.query) AS rank
+			FROM verses v
+			JOIN music m ON m.id = v.music_id AND m.deleted_at IS NULL
+			CROSS JOIN q
+			WHERE v.search_vector @@ q.query
+		),
+		ranked AS (
+			SELECT music_id, SUM(rank) AS rank
+			FROM matched
+			GROUP BY music_id
+			ORDER BY rank DESC, music_id
+			LIMIT $2 OFFSET $3
+		)
+		SELECT
+			m.id, m.release_date, m.release_date_precision, m.title, COALESCE(a.name, ''), COALESCE(m.artist_id::TEXT, ''), COALESCE(m.link, ''), r.rank,
+			mt.verse_number,
+			ts_headline(q.lang, mt.verse_text, q.query, 'StartSel=<b>, StopSel=</b>, MaxFragments=2, MaxWords=20, MinWords=5')
+		FROM ranked r
+		JOIN music m ON m.id = r.music_id
+		LEFT JOIN artists a ON a.id = m.artist_id
+		JOIN matched mt ON mt.music_id = r.music_id
+		CROSS JOIN q
+		ORDER BY r.rank DESC, m.id, mt.rank DESC, mt.verse_number
+	`
+
+	rows, err := m.pool.Query(ctx, sqlQuery, query, limit, offset)
+	if err != nil {
+		log.Errorf("Error searching lyrics: %v", err)
+		return nil, err
+	}
 	defer rows.Close()
 
-	var verses []models.Verse
+	results := []models.SearchResult{}
 	for rows.Next() {
-		var verse models.Verse
-		if err := rows.Scan(&verse.Text, &verse.Number); err != nil {
-			log.Errorf("Error scanning updated verse for music ID %s: %v", updatedMusic.ID, err)
-			return models.Music{}, err
+		var result models.SearchResult
+		var match models.SearchMatch
+		err := rows.Scan(&result.ID, &result.ReleaseDate, &result.ReleaseDatePrecision, &result.SongName, &result.GroupName, &result.ArtistID, &result.Link, &result.Rank, &match.VerseNumber, &match.Snippet)
+		if err != nil {
+			log.Errorf("Error scanning search row: %v", err)
+			return nil, err
 		}
-		verses = append(verses, verse)
+
+		if len(results) == 0 || results[len(results)-1].ID != result.ID {
+			results = append(results, result)
+		}
+		last := &results[len(results)-1]
+		last.Matches = append(last.Matches, match)
+	}
+	if err := rows.Err(); err != nil {
+		log.Errorf("Error iterating search rows: %v", err)
+		return nil, err
 	}
 
-	updatedMusic.Verses = verses
-	log.Infof("Music with ID %s and verses updated successfully", updatedMusic.ID)
+	log.Infof("Found %d songs matching: %s", len(results), query)
+	return results, nil
+}
 
-	return updatedMusic, nil
+// SetSearchLanguages stores the text search configurations used to index lyrics and
+// rebuilds the index of every verse when they changed.
+func (m musicRepository) SetSearchLanguages(ctx context.Context, languages []string) error {
+	if len(languages) == 0 {
+		languages = defaultSearchLanguages
+	}
+	log.Infof("Setting lyrics search languages: %v", languages)
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Error beginning transaction: %v", err)
+		return err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			log.Warnf("Error rolling back transaction: %v", err)
+		}
+	}(tx, ctx)
+
+	tag, err := tx.Exec(ctx, `
+		UPDATE search_settings
+		SET languages = $1::REGCONFIG[]
+		WHERE languages IS DISTINCT FROM $1::REGCONFIG[]
+	`, languages)
+	if err != nil {
+		log.Errorf("Error updating search languages: %v", err)
+		return err
+	}
+
+	if tag.RowsAffected() > 0 {
+		log.Info("Search languages changed, reindexing verses")
+		_, err = tx.Exec(ctx, `UPDATE verses SET search_vector = lyrics_tsvector(verse_text)`)
+		if err != nil {
+			log.Errorf("Error reindexing verses: %v", err)
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+// FindSimilarMusic returns songs whose title and group name have a trigram similarity of at
+// least threshold to the given ones, most similar first. Titles are also matched by word
+// similarity, so that saving "Sonne" finds "Sonne (Remastered)".
+func (m musicRepository) FindSimilarMusic(ctx context.Context, songName, groupName string, threshold float64, limit int) ([]models.SimilarMusic, error) {
+	log.Infof("Looking for songs similar to %s by %s", songName, groupName)
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Error beginning transaction: %v", err)
+		return nil, err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			log.Warnf("Error rolling back transaction: %v", err)
+		}
+	}(tx, ctx)
+
+	// The % and <% operators compare against these settings, which lets them use the trigram indexes.
+	_, err = tx.Exec(ctx, `
+		SELECT
+			set_config('pg_trgm.similarity_threshold', $1::TEXT, true),
+			set_config('pg_trgm.word_similarity_threshold', $1::TEXT, true)
+	`, strconv.FormatFloat(threshold, 'f', -1, 64))
+	if err != nil {
+		log.Errorf("Error setting similarity threshold: %v", err)
+		return nil, err
+	}
+
+	query := `
+		SELECT id, release_date, release_date_precision, title, group_name, artist_id, link, score
+		FROM (
+			SELECT
+				m.id, m.release_date, m.release_date_precision, m.title, COALESCE(a.name, '') AS group_name, COALESCE(m.artist_id::TEXT, '') AS artist_id, COALESCE(m.link, '') AS link,
+				(
+					GREATEST(similarity(m.title, $1), word_similarity($1, m.title), word_similarity(m.title, $1))
+					+ CASE WHEN $2 = '' THEN 1 ELSE similarity(a.name, $2) END
+				) / 2 AS score
+			FROM music m
+			LEFT JOIN artists a ON a.id = m.artist_id
+			WHERE
+				m.deleted_at IS NULL
+				AND (m.title % $1 OR $1 <% m.title)
+				AND ($2 = '' OR a.name % $2)
+		) candidates
+		WHERE score >= $3
+		ORDER BY score DESC, id
+		LIMIT $4
+	`
+
+	rows, err := tx.Query(ctx, query, songName, groupName, threshold, limit)
+	if err != nil {
+		log.Errorf("Error querying similar music: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	candidates := []models.SimilarMusic{}
+	for rows.Next() {
+		var candidate models.SimilarMusic
+		err := rows.Scan(&candidate.ID, &candidate.ReleaseDate, &candidate.ReleaseDatePrecision, &candidate.SongName, &candidate.GroupName, &candidate.ArtistID, &candidate.Link, &candidate.Similarity)
+		if err != nil {
+			log.Errorf("Error scanning similar music row: %v", err)
+			return nil, err
+		}
+		candidates = append(candidates, candidate)
+	}
+	if err := rows.Err(); err != nil {
+		log.Errorf("Error iterating similar music rows: %v", err)
+		return nil, err
+	}
+
+	log.Infof("Found %d songs similar to %s by %s", len(candidates), songName, groupName)
+	return candidates, nil
+}
+
+func parseMusicIDs(musicIDs []string) ([]int, error) {
+	ids := make([]int, 0, len(musicIDs))
+	for _, musicID := range musicIDs {
+		id, err := strconv.Atoi(musicID)
+		if err != nil {
+			return nil, fmt.Errorf("invalid music id %q", musicID)
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
+
+// FindDuplicatePairs returns every pair of songs whose normalized titles have a trigram
+// similarity of at least threshold, together with the similarity of their groups and lyrics.
+func (m musicRepository) FindDuplicatePairs(ctx context.Context, threshold float64) ([]models.DuplicatePair, error) {
+	log.Info("Looking for duplicate song pairs")
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Error beginning transaction: %v", err)
+		return nil, err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			log.Warnf("Error rolling back transaction: %v", err)
+		}
+	}(tx, ctx)
+
+	_, err = tx.Exec(ctx, `SELECT set_config('pg_trgm.similarity_threshold', $1::TEXT, true)`, strconv.FormatFloat(threshold, 'f', -1, 64))
+	if err != nil {
+		log.Errorf("Error setting similarity threshold: %v", err)
+		return nil, err
+	}
+
+	query := `
+		WITH lyrics AS (
+			SELECT music_id, string_agg(verse_text, E'\n' ORDER BY verse_number) AS body
+			FROM verses
+			GROUP BY music_id
+		)
+		SELECT
+			a.id, b.id,
+			similarity(normalize_name(a.title), normalize_name(b.title)),
+			CASE
+				WHEN a.artist_id = b.artist_id THEN 1
+				ELSE similarity(normalize_name(COALESCE(ga.name, '')), normalize_name(COALESCE(gb.name, '')))
+			END,
+			similarity(la.body, lb.body)
+		FROM music a
+		JOIN music b ON b.id > a.id AND b.deleted_at IS NULL AND normalize_name(b.title) % normalize_name(a.title)
+		LEFT JOIN artists ga ON ga.id = a.artist_id
+		LEFT JOIN artists gb ON gb.id = b.artist_id
+		LEFT JOIN lyrics la ON la.music_id = a.id
+		LEFT JOIN lyrics lb ON lb.music_id = b.id
+		WHERE a.deleted_at IS NULL
+		ORDER BY a.id, b.id
+	`
+
+	rows, err := tx.Query(ctx, query)
+	if err != nil {
+		log.Errorf("Error querying duplicate pairs: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var pairs []models.DuplicatePair
+	for rows.Next() {
+		var pair models.DuplicatePair
+		err := rows.Scan(&pair.MusicID, &pair.OtherID, &pair.TitleSimilarity, &pair.GroupSimilarity, &pair.LyricsSimilarity)
+		if err != nil {
+			log.Errorf("Error scanning duplicate pair row: %v", err)
+			return nil, err
+		}
+		pairs = append(pairs, pair)
+	}
+	if err := rows.Err(); err != nil {
+		log.Errorf("Error iterating duplicate pair rows: %v", err)
+		return nil, err
+	}
+
+	log.Infof("Found %d candidate duplicate pairs", len(pairs))
+	return pairs, nil
+}
+
+// ReplaceDuplicateClusters stores the result of a duplicate scan in place of the previous one.
+func (m musicRepository) ReplaceDuplicateClusters(ctx context.Context, clusters []models.DuplicateCluster) error {
+	log.Infof("Saving %d duplicate clusters", len(clusters))
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Error beginning transaction: %v", err)
+		return err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			log.Warnf("Error rolling back transaction: %v", err)
+		}
+	}(tx, ctx)
+
+	_, err = tx.Exec(ctx, `DELETE FROM duplicate_clusters`)
+	if err != nil {
+		log.Errorf("Error clearing duplicate clusters: %v", err)
+		return err
+	}
+
+	for _, cluster := range clusters {
+		musicIDs := make([]string, 0, len(cluster.Music))
+		for _, music := range cluster.Music {
+			musicIDs = append(musicIDs, music.ID)
+		}
+		ids, err := parseMusicIDs(musicIDs)
+		if err != nil {
+			return err
+		}
+
+		_, err = tx.Exec(ctx, `INSERT INTO duplicate_clusters (music_ids, score) VALUES ($1, $2)`, ids, cluster.Score)
+		if err != nil {
+			log.Errorf("Error saving duplicate cluster: %v", err)
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func (m musicRepository) GetDuplicateClusters(ctx context.Context) ([]models.DuplicateCluster, error) {
+	log.Info("Fetching duplicate clusters")
+	query := `
+		SELECT
+			c.id, c.score, c.detected_at,
+			m.id, m.release_date, m.release_date_precision, m.title, COALESCE(a.name, ''), COALESCE(m.artist_id::TEXT, ''), COALESCE(m.link, '')
+		FROM duplicate_clusters c
+		CROSS JOIN LATERAL unnest(c.music_ids) WITH ORDINALITY AS u(music_id, position)
+		JOIN music m ON m.id = u.music_id AND m.deleted_at IS NULL
+		LEFT JOIN artists a ON a.id = m.artist_id
+		ORDER BY c.score DESC, c.id, u.position
+	`
+
+	rows, err := m.pool.Query(ctx, query)
+	if err != nil {
+		log.Errorf("Error fetching duplicate clusters: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	clusters := []models.DuplicateCluster{}
+	for rows.Next() {
+		var cluster models.DuplicateCluster
+		var music models.Music
+		err := rows.Scan(&cluster.ID, &cluster.Score, &cluster.DetectedAt, &music.ID, &music.ReleaseDate, &music.ReleaseDatePrecision, &music.SongName, &music.GroupName, &music.ArtistID, &music.Link)
+		if err != nil {
+			log.Errorf("Error scanning duplicate cluster row: %v", err)
+			return nil, err
+		}
+
+		if len(clusters) == 0 || clusters[len(clusters)-1].ID != cluster.ID {
+			clusters = append(clusters, cluster)
+		}
+		last := &clusters[len(clusters)-1]
+		last.Music = append(last.Music, music)
+	}
+	if err := rows.Err(); err != nil {
+		log.Errorf("Error iterating duplicate cluster rows: %v", err)
+		return nil, err
+	}
+
+	// Songs deleted since the scan leave clusters that are no longer duplicates.
+	result := clusters[:0]
+	for _, cluster := range clusters {
+		if len(cluster.Music) > 1 {
+			result = append(result, cluster)
+		}
+	}
+
+	log.Infof("Successfully fetched %d duplicate clusters", len(result))
+	return result, nil
+}
+
+// MergeMusic merges the given songs into the surviving one in a single transaction: missing
+// metadata is taken from the merged songs in the given order, their lyrics are adopted if the
+// survivor has none, their album tracks are moved to it, and the merged songs are moved to the trash.
+// The change to the survivor is recorded as a revision. It returns nil if any of the songs does not
+// exist and fails with models.ErrVersionMismatch unless the survivor is at expectedVersion.
+func (m musicRepository) MergeMusic(ctx context.Context, survivorID string, mergeIDs []string, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Merging music %v into %s", mergeIDs, survivorID)
+
+	ids, err := parseMusicIDs(append([]string{survivorID}, mergeIDs...))
+	if err != nil {
+		return nil, err
+	}
+	survivor, merged := ids[0], ids[1:]
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Error beginning transaction: %v", err)
+		return nil, err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			log.Warnf("Error rolling back transaction: %v", err)
+		}
+	}(tx, ctx)
+
+	var found int
+	err = tx.QueryRow(ctx, `
+		SELECT count(*) FROM (SELECT id FROM music WHERE id = ANY($1) AND deleted_at IS NULL ORDER BY id FOR UPDATE) locked
+	`, ids).Scan(&found)
+	if err != nil {
+		log.Errorf("Error locking music for merge: %v", err)
+		return nil, err
+	}
+	if found != len(ids) {
+		log.Warnf("Some of the music %v does not exist", ids)
+		return nil, nil
+	}
+
+	err = checkVersion(ctx, tx, survivorID, expectedVersion)
+	if errors.Is(err, models.ErrVersionMismatch) {
+		log.Warnf("Music with ID %d was changed concurrently", survivor)
+		return nil, err
+	}
+	if err != nil {
+		log.Errorf("Error checking version of music with ID %d: %v", survivor, err)
+		return nil, err
+	}
+
+	before, err := loadSnapshot(ctx, tx, survivorID, false)
+	if err != nil {
+		log.Errorf("Error fetching music %d: %v", survivor, err)
+		return nil, err
+	}
+
+	metadataQuery := `
+		WITH donors AS (
+			SELECT m.release_date, m.release_date_precision, NULLIF(m.link, '') AS link, u.position
+			FROM unnest($2::INT[]) WITH ORDINALITY AS u(id, position)
+			JOIN music m ON m.id = u.id
+		)
+		UPDATE music
+		SET
+			release_date = COALESCE(release_date, (SELECT release_date FROM donors WHERE release_date IS NOT NULL ORDER BY position LIMIT 1)),
+			release_date_precision = CASE
+				WHEN release_date IS NOT NULL THEN release_date_precision
+				ELSE COALESCE((SELECT release_date_precision FROM donors WHERE release_date IS NOT NULL ORDER BY position LIMIT 1), release_date_precision)
+			END,
+			link = COALESCE(NULLIF(link, ''), (SELECT link FROM donors WHERE link IS NOT NULL ORDER BY position LIMIT 1), link),
+			version = version + 1
+		WHERE id = $1
+	`
+	_, err = tx.Exec(ctx, metadataQuery, survivor, merged)
+	if err != nil {
+		log.Errorf("Error merging metadata into music %d: %v", survivor, err)
+		return nil, err
+	}
+
+	versesQuery := `
+		UPDATE verses
+		SET music_id = $1
+		WHERE
+			music_id = (
+				SELECT v.music_id
+				FROM unnest($2::INT[]) WITH ORDINALITY AS u(id, position)
+				JOIN verses v ON v.music_id = u.id
+				ORDER BY u.position
+				LIMIT 1
+			)
+			AND NOT EXISTS (SELECT 1 FROM verses WHERE music_id = $1)
+	`
+	tag, err := tx.Exec(ctx, versesQuery, survivor, merged)
+	if err != nil {
+		log.Errorf("Error moving verses into music %d: %v", survivor, err)
+		return nil, err
+	}
+	log.Infof("Moved %d verses into music %d", tag.RowsAffected(), survivor)
+
+	albumsQuery := `
+		UPDATE album_tracks t
+		SET music_id = $1
+		FROM (
+			SELECT DISTINCT ON (album_id) album_id, music_id
+			FROM album_tracks
+			WHERE music_id = ANY($2::INT[])
+			ORDER BY album_id, track_number NULLS LAST
+		) moved
+		WHERE
+			t.album_id = moved.album_id AND t.music_id = moved.music_id
+			AND NOT EXISTS (SELECT 1 FROM album_tracks s WHERE s.album_id = t.album_id AND s.music_id = $1)
+	`
+	_, err = tx.Exec(ctx, albumsQuery, survivor, merged)
+	if err != nil {
+		log.Errorf("Error moving album tracks into music %d: %v", survivor, err)
+		return nil, err
+	}
+
+	// Primary artists of merged songs become featured ones so that the survivor keeps a single primary artist
+	artistsQuery := `
+		INSERT INTO music_artists (music_id, artist_id, role, position)
+		SELECT DISTINCT ON (ma.artist_id, r.role) $1::INT, ma.artist_id, r.role, ma.position
+		FROM music_artists ma
+		CROSS JOIN LATERAL (SELECT CASE WHEN ma.role = 'primary' THEN 'featured' ELSE ma.role END AS role) r
+		WHERE
+			ma.music_id = ANY($2::INT[])
+			AND NOT EXISTS (SELECT 1 FROM music_artists s WHERE s.music_id = $1 AND s.artist_id = ma.artist_id)
+		ORDER BY ma.artist_id, r.role, ma.position
+		ON CONFLICT DO NOTHING
+	`
+	_, err = tx.Exec(ctx, artistsQuery, survivor, merged)
+	if err != nil {
+		log.Errorf("Error moving artists into music %d: %v", survivor, err)
+		return nil, err
+	}
+
+	tagsQuery := `
+		INSERT INTO music_tags (music_id, tag_id, source)
+		SELECT DISTINCT ON (tag_id) $1::INT, tag_id, source
+		FROM music_tags
+		WHERE music_id = ANY($2::INT[])
+		ORDER BY tag_id, source
+		ON CONFLICT DO NOTHING
+	`
+	_, err = tx.Exec(ctx, tagsQuery, survivor, merged)
+	if err != nil {
+		log.Errorf("Error moving tags into music %d: %v", survivor, err)
+		return nil, err
+	}
+
+	genresQuery := `
+		INSERT INTO music_genres (music_id, genre_id)
+		SELECT DISTINCT $1::INT, genre_id FROM music_genres WHERE music_id = ANY($2::INT[])
+		ON CONFLICT DO NOTHING
+	`
+	_, err = tx.Exec(ctx, genresQuery, survivor, merged)
+	if err != nil {
+		log.Errorf("Error moving genres into music %d: %v", survivor, err)
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `UPDATE enrichment_jobs SET music_id = $1 WHERE music_id = ANY($2::INT[])`, survivor, merged)
+	if err != nil {
+		log.Errorf("Error relinking enrichment jobs: %v", err)
+		return nil, err
+	}
+
+	// Объединённые песни попадают в корзину, откуда их можно восстановить до её очистки
+	_, err = tx.Exec(ctx, `UPDATE music SET deleted_at = now(), version = version + 1 WHERE id = ANY($1::INT[])`, merged)
+	if err != nil {
+		log.Errorf("Error moving merged music to trash: %v", err)
+		return nil, err
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM duplicate_clusters WHERE music_ids && $1::INT[]`, merged)
+	if err != nil {
+		log.Errorf("Error removing merged duplicate clusters: %v", err)
+		return nil, err
+	}
+
+	after, err := loadSnapshot(ctx, tx, survivorID, false)
+	if err != nil {
+		log.Errorf("Error fetching merged music %d: %v", survivor, err)
+		return nil, err
+	}
+	err = insertRevision(ctx, tx, survivorID, before, after, nil)
+	if err != nil {
+		log.Errorf("Error recording revision of music %d: %v", survivor, err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Errorf("Error committing merge transaction: %v", err)
+		return nil, err
+	}
+
+	log.Infof("Merged music %v into %s", mergeIDs, survivorID)
+	return m.GetTimedLyrics(ctx, survivorID)
 }
 
 func NewMusicRepository(pool *pgxpool.Pool) models.MusicRepository {
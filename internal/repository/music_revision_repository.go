@@ -0,0 +1,376 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+)
+
+const revisionColumns = `music_id, revision, COALESCE(author, ''), created_at, restored_from, changes, verse_changes`
+
+// loadSnapshot returns the editable state of a song, or nil if it does not exist. With forUpdate the
+// song row stays locked until the end of the transaction, which also serializes revision numbers.
+func loadSnapshot(ctx context.Context, q querier, musicID string, forUpdate bool) (*models.MusicSnapshot, error) {
+	query := `
+		SELECT m.title, COALESCE(a.name, ''), COALESCE(m.artist_id::TEXT, ''), m.release_date, m.release_date_precision, COALESCE(m.link, '')
+		FROM music m
+		LEFT JOIN artists a ON a.id = m.artist_id
+		WHERE m.id = $1 AND m.deleted_at IS NULL
+	`
+	if forUpdate {
+		query += ` FOR UPDATE OF m`
+	}
+
+	var snapshot models.MusicSnapshot
+	err := q.QueryRow(ctx, query, musicID).Scan(&snapshot.SongName, &snapshot.GroupName, &snapshot.ArtistID, &snapshot.ReleaseDate, &snapshot.ReleaseDatePrecision, &snapshot.Link)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	versesQuery := `
+		SELECT ` + verseColumns + `
+		FROM verses v
+		LEFT JOIN verses r ON r.id = v.repeat_of_verse_id
+		WHERE v.music_id = $1
+		ORDER BY v.verse_number
+	`
+	rows, err := q.Query(ctx, versesQuery, musicID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	snapshot.Verses = []models.VerseSnapshot{}
+	for rows.Next() {
+		var verse models.VerseSnapshot
+		if err := rows.Scan(&verse.Text, &verse.Number, &verse.SectionType, &verse.SectionLabel, &verse.RepeatOf); err != nil {
+			return nil, err
+		}
+		snapshot.Verses = append(snapshot.Verses, verse)
+	}
+
+	return &snapshot, rows.Err()
+}
+
+func formatSnapshotDate(snapshot *models.MusicSnapshot) string {
+	if snapshot.ReleaseDate == nil {
+		return ""
+	}
+	return models.FormatReleaseDate(*snapshot.ReleaseDate, snapshot.ReleaseDatePrecision)
+}
+
+// diffSnapshots lists the fields and verses that differ between two states of a song.
+// A nil before stands for a song that did not exist yet.
+func diffSnapshots(before, after *models.MusicSnapshot) ([]models.FieldChange, []models.VerseChange) {
+	if before == nil {
+		before = &models.MusicSnapshot{}
+	}
+
+	fields := []struct {
+		name     string
+		old, new string
+	}{
+		{"song_name", before.SongName, after.SongName},
+		{"group_name", before.GroupName, after.GroupName},
+		{"artist_id", before.ArtistID, after.ArtistID},
+		{"release_date", formatSnapshotDate(before), formatSnapshotDate(after)},
+		{"link", before.Link, after.Link},
+	}
+
+	changes := []models.FieldChange{}
+	for _, field := range fields {
+		if field.old != field.new {
+			changes = append(changes, models.FieldChange{Field: field.name, Old: field.old, New: field.new})
+		}
+	}
+
+	oldVerses := map[int]models.VerseSnapshot{}
+	for _, verse := range before.Verses {
+		oldVerses[verse.Number] = verse
+	}
+	newVerses := map[int]bool{}
+
+	var verseChanges []models.VerseChange
+	for _, verse := range after.Verses {
+		newVerses[verse.Number] = true
+		text := verse.Text
+		old, ok := oldVerses[verse.Number]
+		if !ok {
+			verseChanges = append(verseChanges, models.VerseChange{Number: verse.Number, New: &text})
+		} else if !sameVerse(old, verse) {
+			verseChanges = append(verseChanges, models.VerseChange{Number: verse.Number, Old: &old.Text, New: &text})
+		}
+	}
+	for _, verse := range before.Verses {
+		if !newVerses[verse.Number] {
+			text := verse.Text
+			verseChanges = append(verseChanges, models.VerseChange{Number: verse.Number, Old: &text})
+		}
+	}
+
+	return changes, verseChanges
+}
+
+// sameVerse reports whether a verse kept its text and section.
+func sameVerse(a, b models.VerseSnapshot) bool {
+	if a.Text != b.Text || a.SectionType != b.SectionType || a.SectionLabel != b.SectionLabel {
+		return false
+	}
+	if a.RepeatOf == nil || b.RepeatOf == nil {
+		return a.RepeatOf == b.RepeatOf
+	}
+	return *a.RepeatOf == *b.RepeatOf
+}
+
+// insertRevision records the change of a song from before to after by the author of ctx. Nothing is
+// recorded when an existing song did not change.
+func insertRevision(ctx context.Context, q querier, musicID string, before, after *models.MusicSnapshot, restoredFrom *int) error {
+	changes, verseChanges := diffSnapshots(before, after)
+	if before != nil && len(changes) == 0 && len(verseChanges) == 0 && restoredFrom == nil {
+		log.Infof("Music %s did not change, no revision recorded", musicID)
+		return nil
+	}
+	if verseChanges == nil {
+		verseChanges = []models.VerseChange{}
+	}
+
+	query := `
+		INSERT INTO music_revisions (music_id, revision, author, restored_from, changes, verse_changes, snapshot)
+		SELECT $1, COALESCE(max(revision), 0) + 1, $2, $3, $4, $5, $6
+		FROM music_revisions
+		WHERE music_id = $1
+		RETURNING revision
+	`
+
+	var revision int
+	err := q.QueryRow(ctx, query, musicID, nullableString(models.AuthorFromContext(ctx)), restoredFrom, changes, verseChanges, after).Scan(&revision)
+	if err != nil {
+		return err
+	}
+
+	log.Infof("Recorded revision %d of music %s", revision, musicID)
+	return nil
+}
+
+func scanRevision(row pgx.Row, dest ...any) (*models.Revision, error) {
+	var revision models.Revision
+	err := row.Scan(append([]any{
+		&revision.MusicID, &revision.Number, &revision.Author, &revision.CreatedAt,
+		&revision.RestoredFrom, &revision.Changes, &revision.VerseChanges,
+	}, dest...)...)
+	if err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+func (m musicRepository) GetRevisions(ctx context.Context, musicID string) ([]models.Revision, error) {
+	log.Infof("Fetching revisions of music %s", musicID)
+
+	query := `
+		SELECT ` + revisionColumns + `
+		FROM music_revisions
+		WHERE music_id = $1
+		ORDER BY revision DESC
+	`
+
+	rows, err := m.pool.Query(ctx, query, musicID)
+	if err != nil {
+		log.Errorf("Error fetching revisions of music %s: %v", musicID, err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var revisions []models.Revision
+	for rows.Next() {
+		revision, err := scanRevision(rows)
+		if err != nil {
+			log.Errorf("Error scanning revision row: %v", err)
+			return nil, err
+		}
+		revisions = append(revisions, *revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+func (m musicRepository) GetRevision(ctx context.Context, musicID string, revision int) (*models.Revision, error) {
+	log.Infof("Fetching revision %d of music %s", revision, musicID)
+
+	query := `
+		SELECT ` + revisionColumns + `, snapshot
+		FROM music_revisions
+		WHERE music_id = $1 AND revision = $2
+	`
+
+	var snapshot models.MusicSnapshot
+	res, err := scanRevision(m.pool.QueryRow(ctx, query, musicID, revision), &snapshot)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf("Error fetching revision %d of music %s: %v", revision, musicID, err)
+		return nil, err
+	}
+
+	res.Snapshot = &snapshot
+	return res, nil
+}
+
+// RestoreRevision brings a song back to its state at the given revision and records that as a new revision.
+func (m musicRepository) RestoreRevision(ctx context.Context, musicID string, revision int, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Restoring music %s to revision %d", musicID, revision)
+
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Error beginning transaction: %v", err)
+		return nil, err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			log.Warnf("Error rolling back transaction: %v", err)
+		}
+	}(tx, ctx)
+
+	before, err := loadSnapshot(ctx, tx, musicID, true)
+	if err != nil {
+		log.Errorf("Error fetching music %s: %v", musicID, err)
+		return nil, err
+	}
+	if before == nil {
+		log.Warnf("Music %s not found", musicID)
+		return nil, nil
+	}
+	err = checkVersion(ctx, tx, musicID, expectedVersion)
+	if errors.Is(err, models.ErrVersionMismatch) {
+		log.Warnf("Music %s was changed concurrently", musicID)
+		return nil, err
+	}
+	if err != nil {
+		log.Errorf("Error checking version of music %s: %v", musicID, err)
+		return nil, err
+	}
+
+	var target models.MusicSnapshot
+	err = tx.QueryRow(ctx, `SELECT snapshot FROM music_revisions WHERE music_id = $1 AND revision = $2`, musicID, revision).Scan(&target)
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Warnf("Revision %d of music %s not found", revision, musicID)
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf("Error fetching revision %d of music %s: %v", revision, musicID, err)
+		return nil, err
+	}
+
+	// Исполнитель мог быть удалён после этой ревизии, тогда он создаётся заново по имени
+	var artistID *string
+	if target.ArtistID != "" {
+		var exists bool
+		err = tx.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM artists WHERE id = $1)`, target.ArtistID).Scan(&exists)
+		if err != nil {
+			log.Errorf("Error checking artist %s: %v", target.ArtistID, err)
+			return nil, err
+		}
+		if exists {
+			artistID = &target.ArtistID
+		}
+	}
+	if artistID == nil && target.GroupName != "" {
+		id, err := ensureArtist(ctx, tx, target.GroupName)
+		if err != nil {
+			log.Errorf("Error saving artist %s: %v", target.GroupName, err)
+			return nil, err
+		}
+		restoredArtistID := strconv.Itoa(id)
+		artistID = &restoredArtistID
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE music
+		SET title = $2, artist_id = $3, release_date = $4, release_date_precision = $5, link = $6, version = version + 1
+		WHERE id = $1
+	`, musicID, target.SongName, artistID, target.ReleaseDate, datePrecision(target.ReleaseDatePrecision), nullableString(target.Link))
+	if err != nil {
+		log.Errorf("Error restoring music %s: %v", musicID, err)
+		return nil, err
+	}
+	if artistID != nil {
+		if err := setPrimaryArtist(ctx, tx, musicID, *artistID); err != nil {
+			log.Errorf("Error restoring primary artist of music %s: %v", musicID, err)
+			return nil, err
+		}
+	}
+
+	numbers := make([]int, 0, len(target.Verses))
+	for _, verse := range target.Verses {
+		numbers = append(numbers, verse.Number)
+
+		// Timed lines no longer match a verse whose text was rewritten, so they are dropped with it
+		verseQuery := `
+			WITH updated AS (
+				UPDATE verses SET verse_text = $3
+				WHERE music_id = $1 AND verse_number = $2 AND verse_text IS DISTINCT FROM $3
+				RETURNING id
+			), deleted AS (
+				DELETE FROM lyric_lines WHERE verse_id IN (SELECT id FROM updated)
+			)
+			INSERT INTO verses (music_id, verse_number, verse_text)
+			SELECT $1, $2, $3
+			WHERE NOT EXISTS (SELECT 1 FROM verses WHERE music_id = $1 AND verse_number = $2)
+		`
+		_, err = tx.Exec(ctx, verseQuery, musicID, verse.Number, verse.Text)
+		if err != nil {
+			log.Errorf("Error restoring verse %d of music %s: %v", verse.Number, musicID, err)
+			return nil, err
+		}
+	}
+
+	_, err = tx.Exec(ctx, `DELETE FROM verses WHERE music_id = $1 AND NOT (verse_number = ANY($2::INT[]))`, musicID, numbers)
+	if err != nil {
+		log.Errorf("Error removing verses of music %s: %v", musicID, err)
+		return nil, err
+	}
+
+	// Снимки, сделанные до появления секций, оставляют секции куплетов как есть
+	sectionQuery := `
+		UPDATE verses
+		SET section_type = $3, section_label = $4,
+			repeat_of_verse_id = (SELECT id FROM verses WHERE music_id = $1 AND verse_number = $5)
+		WHERE music_id = $1 AND verse_number = $2
+	`
+	for _, verse := range target.Verses {
+		if verse.SectionType == "" {
+			continue
+		}
+		_, err = tx.Exec(ctx, sectionQuery, musicID, verse.Number, verse.SectionType, nullableString(verse.SectionLabel), verse.RepeatOf)
+		if err != nil {
+			log.Errorf("Error restoring section of verse %d of music %s: %v", verse.Number, musicID, err)
+			return nil, err
+		}
+	}
+
+	after, err := loadSnapshot(ctx, tx, musicID, false)
+	if err != nil {
+		log.Errorf("Error fetching restored music %s: %v", musicID, err)
+		return nil, err
+	}
+	if err := insertRevision(ctx, tx, musicID, before, after, &revision); err != nil {
+		log.Errorf("Error recording revision of music %s: %v", musicID, err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Errorf("Error committing transaction: %v", err)
+		return nil, err
+	}
+
+	log.Infof("Music %s restored to revision %d", musicID, revision)
+	return m.GetTimedLyrics(ctx, musicID)
+}
@@ -0,0 +1,81 @@
+package repository
+
+import (
+	"fmt"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"strings"
+)
+
+// musicSortExpression returns the SQL expression the music list is sorted by for key and the type of
+// its values. Only whitelisted fields are mapped, so keys never reach the query as text. Songs without
+// a release date are sorted last in both directions.
+func musicSortExpression(key models.SortKey) (string, string, error) {
+	switch key.Field {
+	case models.SortReleaseDate:
+		if key.Desc {
+			return `COALESCE(m.release_date, '-infinity'::DATE)`, "DATE", nil
+		}
+		return `COALESCE(m.release_date, 'infinity'::DATE)`, "DATE", nil
+	case models.SortTitle:
+		return `m.title`, "TEXT", nil
+	case models.SortGroupName:
+		return `COALESCE(a.name, '')`, "TEXT", nil
+	case models.SortCreatedAt:
+		return `m.created_at`, "TIMESTAMPTZ", nil
+	default:
+		return "", "", fmt.Errorf("%w: unknown field %q", models.ErrInvalidSort, key.Field)
+	}
+}
+
+// musicOrderBy returns the ORDER BY list for keys. The ID of the song comes last to make the order total.
+func musicOrderBy(keys []models.SortKey) (string, error) {
+	terms := make([]string, 0, len(keys)+1)
+	for _, key := range keys {
+		expr, _, err := musicSortExpression(key)
+		if err != nil {
+			return "", err
+		}
+		if key.Desc {
+			expr += " DESC"
+		}
+		terms = append(terms, expr)
+	}
+	terms = append(terms, "m.id")
+	return strings.Join(terms, ", "), nil
+}
+
+// musicKeysetCondition returns the condition selecting the songs ordered by musicOrderBy after cursor
+// and its arguments, numbered from firstParam. The values of the keys are compared one by one, since
+// their directions may differ: a song follows the cursor if it is equal on the first keys and
+// sorted after it on the next one. An empty cursor selects every song.
+func musicKeysetCondition(keys []models.SortKey, cursor models.Cursor, firstParam int) (string, []interface{}, error) {
+	if cursor.ID == 0 {
+		return "TRUE", nil, nil
+	}
+	if len(cursor.Values) != len(keys) {
+		return "", nil, models.ErrInvalidCursor
+	}
+
+	var equal, terms []string
+	args := make([]interface{}, 0, len(keys)+1)
+	for i, key := range keys {
+		expr, typ, err := musicSortExpression(key)
+		if err != nil {
+			return "", nil, err
+		}
+		param := fmt.Sprintf("$%d::%s", firstParam+i, typ)
+		args = append(args, cursor.Values[i])
+
+		op := ">"
+		if key.Desc {
+			op = "<"
+		}
+		terms = append(terms, "("+strings.Join(append(equal, expr+" "+op+" "+param), " AND ")+")")
+		equal = append(equal, expr+" = "+param)
+	}
+	param := fmt.Sprintf("$%d::INT", firstParam+len(keys))
+	args = append(args, cursor.ID)
+	terms = append(terms, "("+strings.Join(append(equal, "m.id > "+param), " AND ")+")")
+
+	return "(" + strings.Join(terms, " OR ") + ")", args, nil
+}
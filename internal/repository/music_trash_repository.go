@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+// GetTrash returns deleted songs, most recently deleted first.
+func (m musicRepository) GetTrash(ctx context.Context, page, pageSize int) ([]models.Music, error) {
+	log.Infof("Fetching trash, page %d, page size %d", page, pageSize)
+
+	query := `
+		SELECT m.id, m.release_date, m.release_date_precision, m.title, COALESCE(a.name, ''), COALESCE(m.artist_id::TEXT, ''), COALESCE(m.link, ''), m.deleted_at
+		FROM music m
+		LEFT JOIN artists a ON a.id = m.artist_id
+		WHERE m.deleted_at IS NOT NULL
+		ORDER BY m.deleted_at DESC, m.id
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := m.pool.Query(ctx, query, pageSize, (page-1)*pageSize)
+	if err != nil {
+		log.Errorf("Error fetching trash: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var musics []models.Music
+	for rows.Next() {
+		var music models.Music
+		err := rows.Scan(&music.ID, &music.ReleaseDate, &music.ReleaseDatePrecision, &music.SongName, &music.GroupName, &music.ArtistID, &music.Link, &music.DeletedAt)
+		if err != nil {
+			log.Errorf("Error scanning trash row: %v", err)
+			return nil, err
+		}
+		musics = append(musics, music)
+	}
+
+	return musics, rows.Err()
+}
+
+func (m musicRepository) CountTrash(ctx context.Context) (int, error) {
+	var total int
+	err := m.pool.QueryRow(ctx, `SELECT count(*) FROM music WHERE deleted_at IS NOT NULL`).Scan(&total)
+	if err != nil {
+		log.Errorf("Error counting trash: %v", err)
+		return 0, err
+	}
+	return total, nil
+}
+
+// RestoreMusic takes a song out of the trash. It returns nil if the song is not in the trash.
+func (m musicRepository) RestoreMusic(ctx context.Context, musicID string) (*models.Music, error) {
+	log.Infof("Restoring music with ID %s from trash", musicID)
+
+	tag, err := m.pool.Exec(ctx, `UPDATE music SET deleted_at = NULL, version = version + 1 WHERE id = $1 AND deleted_at IS NOT NULL`, musicID)
+	if err != nil {
+		log.Errorf("Error restoring music with ID %s: %v", musicID, err)
+		return nil, err
+	}
+	if tag.RowsAffected() == 0 {
+		log.Warnf("Music with ID %s is not in trash", musicID)
+		return nil, nil
+	}
+
+	log.Infof("Music with ID %s restored from trash", musicID)
+	return m.GetTimedLyrics(ctx, musicID)
+}
+
+// PurgeDeletedMusic permanently deletes songs that went to the trash before deletedBefore,
+// together with their verses and other dependent rows.
+func (m musicRepository) PurgeDeletedMusic(ctx context.Context, deletedBefore time.Time) (int64, error) {
+	log.Infof("Purging music deleted before %s", deletedBefore.Format(time.RFC3339))
+
+	tag, err := m.pool.Exec(ctx, `DELETE FROM music WHERE deleted_at < $1`, deletedBefore)
+	if err != nil {
+		log.Errorf("Error purging trash: %v", err)
+		return 0, err
+	}
+
+	log.Infof("Purged %d songs from trash", tag.RowsAffected())
+	return tag.RowsAffected(), nil
+}
@@ -0,0 +1,197 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+// editVerses runs edit in a transaction holding the lock on the song, bumps the version of the song
+// and records the edit as a revision. It returns nil if the song does not exist and fails with
+// models.ErrVersionMismatch unless the song is at expectedVersion.
+func (m musicRepository) editVerses(ctx context.Context, musicID string, expectedVersion *int, edit func(tx pgx.Tx) error) (*models.Music, error) {
+	tx, err := m.pool.Begin(ctx)
+	if err != nil {
+		log.Errorf("Error beginning transaction: %v", err)
+		return nil, err
+	}
+	defer func(tx pgx.Tx, ctx context.Context) {
+		err := tx.Rollback(ctx)
+		if err != nil && err != pgx.ErrTxClosed {
+			log.Warnf("Error rolling back transaction: %v", err)
+		}
+	}(tx, ctx)
+
+	before, err := loadSnapshot(ctx, tx, musicID, true)
+	if err != nil {
+		log.Errorf("Error fetching music with ID %s: %v", musicID, err)
+		return nil, err
+	}
+	if before == nil {
+		log.Warnf("Music with ID %s not found", musicID)
+		return nil, nil
+	}
+
+	err = bumpVersion(ctx, tx, musicID, expectedVersion)
+	if errors.Is(err, models.ErrVersionMismatch) {
+		log.Warnf("Music with ID %s was changed concurrently", musicID)
+		return nil, err
+	}
+	if err != nil {
+		log.Errorf("Error updating version of music with ID %s: %v", musicID, err)
+		return nil, err
+	}
+
+	if err := edit(tx); err != nil {
+		return nil, err
+	}
+
+	after, err := loadSnapshot(ctx, tx, musicID, false)
+	if err != nil {
+		log.Errorf("Error fetching edited music with ID %s: %v", musicID, err)
+		return nil, err
+	}
+	err = insertRevision(ctx, tx, musicID, before, after, nil)
+	if err != nil {
+		log.Errorf("Error recording revision of music ID %s: %v", musicID, err)
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		log.Errorf("Error committing transaction: %v", err)
+		return nil, err
+	}
+
+	return m.GetTimedLyrics(ctx, musicID)
+}
+
+// InsertVerse inserts a verse before the verse at verse.Position and renumbers the following verses.
+// A position past the last verse appends the verse.
+func (m musicRepository) InsertVerse(ctx context.Context, musicID string, verse models.VerseInsert, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Inserting verse at position %d of music ID: %s", verse.Position, musicID)
+
+	res, err := m.editVerses(ctx, musicID, expectedVersion, func(tx pgx.Tx) error {
+		var count int
+		err := tx.QueryRow(ctx, `SELECT count(*) FROM verses WHERE music_id = $1`, musicID).Scan(&count)
+		if err != nil {
+			log.Errorf("Error counting verses of music ID %s: %v", musicID, err)
+			return err
+		}
+
+		position := verse.Position
+		if position < 1 || position > count {
+			position = count + 1
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE verses SET verse_number = verse_number + 1 WHERE music_id = $1 AND verse_number >= $2`, musicID, position)
+		if err != nil {
+			log.Errorf("Error renumbering verses of music ID %s: %v", musicID, err)
+			return err
+		}
+
+		sectionType := verse.SectionType
+		if sectionType == "" {
+			sectionType = models.SectionVerse
+		}
+		query := `
+			INSERT INTO verses (music_id, verse_text, verse_number, section_type, section_label)
+			VALUES ($1, $2, $3, $4, $5)
+		`
+		_, err = tx.Exec(ctx, query, musicID, verse.Text, position, sectionType, nullableString(verse.SectionLabel))
+		if err != nil {
+			log.Errorf("Error inserting verse into music ID %s: %v", musicID, err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res != nil {
+		log.Infof("Verse inserted into music ID %s", musicID)
+	}
+	return res, nil
+}
+
+// DeleteVerse deletes a verse together with its timed lines and renumbers the following verses.
+// Verses repeating the deleted one keep their text but no longer link to it.
+func (m musicRepository) DeleteVerse(ctx context.Context, musicID string, number int, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Deleting verse %d of music ID: %s", number, musicID)
+
+	res, err := m.editVerses(ctx, musicID, expectedVersion, func(tx pgx.Tx) error {
+		tag, err := tx.Exec(ctx, `DELETE FROM verses WHERE music_id = $1 AND verse_number = $2`, musicID, number)
+		if err != nil {
+			log.Errorf("Error deleting verse %d of music ID %s: %v", number, musicID, err)
+			return err
+		}
+		if tag.RowsAffected() == 0 {
+			log.Warnf("Verse %d of music ID %s not found", number, musicID)
+			return models.ErrVerseNotFound
+		}
+
+		_, err = tx.Exec(ctx, `UPDATE verses SET verse_number = verse_number - 1 WHERE music_id = $1 AND verse_number > $2`, musicID, number)
+		if err != nil {
+			log.Errorf("Error renumbering verses of music ID %s: %v", musicID, err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res != nil {
+		log.Infof("Verse %d of music ID %s deleted", number, musicID)
+	}
+	return res, nil
+}
+
+// ReorderVerses renumbers the verses of a song so that they follow order, which lists every current
+// verse number exactly once.
+func (m musicRepository) ReorderVerses(ctx context.Context, musicID string, order []int, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Reordering verses of music ID %s to %v", musicID, order)
+
+	res, err := m.editVerses(ctx, musicID, expectedVersion, func(tx pgx.Tx) error {
+		var matches bool
+		query := `
+			SELECT COALESCE(array_agg(verse_number ORDER BY verse_number), '{}') = (
+				SELECT COALESCE(array_agg(n ORDER BY n), '{}') FROM unnest($2::INT[]) AS n
+			)
+			FROM verses
+			WHERE music_id = $1
+		`
+		err := tx.QueryRow(ctx, query, musicID, order).Scan(&matches)
+		if err != nil {
+			log.Errorf("Error checking verses of music ID %s: %v", musicID, err)
+			return err
+		}
+		if !matches {
+			log.Warnf("Order %v does not match the verses of music ID %s", order, musicID)
+			return models.ErrInvalidVerseOrder
+		}
+
+		query = `
+			UPDATE verses v
+			SET verse_number = u.position
+			FROM unnest($2::INT[]) WITH ORDINALITY AS u(number, position)
+			WHERE v.music_id = $1 AND v.verse_number = u.number
+		`
+		_, err = tx.Exec(ctx, query, musicID, order)
+		if err != nil {
+			log.Errorf("Error renumbering verses of music ID %s: %v", musicID, err)
+			return err
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if res != nil {
+		log.Infof("Verses of music ID %s reordered", musicID)
+	}
+	return res, nil
+}
@@ -0,0 +1,316 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+)
+
+type tagRepository struct {
+	pool *pgxpool.Pool
+}
+
+// insertMusicTags attaches tags, which must already be normalized, to a song and keeps the
+// source of tags that were already attached.
+func insertMusicTags(ctx context.Context, q querier, musicID int, tags []string, source models.TagSource) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	query := `
+		WITH upserted AS (
+			INSERT INTO tags (name) SELECT unnest($2::TEXT[])
+			ON CONFLICT (name) DO UPDATE SET name = EXCLUDED.name
+			RETURNING id
+		), inserted AS (
+			INSERT INTO music_tags (music_id, tag_id, source)
+			SELECT $1, id, $3 FROM upserted
+			ON CONFLICT DO NOTHING
+			RETURNING tag_id
+		)
+		SELECT count(*) FROM inserted
+	`
+
+	var inserted int
+	return q.QueryRow(ctx, query, musicID, tags, source).Scan(&inserted)
+}
+
+// insertMatchingGenres files a song under the genres named like any of its tags.
+func insertMatchingGenres(ctx context.Context, q querier, musicID int, tags []string) error {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	query := `
+		WITH inserted AS (
+			INSERT INTO music_genres (music_id, genre_id)
+			SELECT $1, id FROM genres WHERE lower(name) = ANY($2::TEXT[])
+			ON CONFLICT DO NOTHING
+			RETURNING genre_id
+		)
+		SELECT count(*) FROM inserted
+	`
+
+	var inserted int
+	return q.QueryRow(ctx, query, musicID, tags).Scan(&inserted)
+}
+
+// loadMusicTags returns the genres and tags of the given songs keyed by song id, sorted by name.
+func loadMusicTags(ctx context.Context, q querier, musicIDs []string) (map[string]models.MusicTags, error) {
+	tags := map[string]models.MusicTags{}
+	if len(musicIDs) == 0 {
+		return tags, nil
+	}
+
+	ids, err := parseMusicIDs(musicIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	query := `
+		SELECT mt.music_id::TEXT, 'tag', t.name
+		FROM music_tags mt
+		JOIN tags t ON t.id = mt.tag_id
+		WHERE mt.music_id = ANY($1)
+		UNION ALL
+		SELECT mg.music_id::TEXT, 'genre', g.name
+		FROM music_genres mg
+		JOIN genres g ON g.id = mg.genre_id
+		WHERE mg.music_id = ANY($1)
+		ORDER BY 1, 2, 3
+	`
+
+	rows, err := q.Query(ctx, query, ids)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var musicID, kind, name string
+		if err := rows.Scan(&musicID, &kind, &name); err != nil {
+			return nil, err
+		}
+		musicTags := tags[musicID]
+		musicTags.MusicID = musicID
+		if kind == "genre" {
+			musicTags.Genres = append(musicTags.Genres, name)
+		} else {
+			musicTags.Tags = append(musicTags.Tags, name)
+		}
+		tags[musicID] = musicTags
+	}
+
+	return tags, rows.Err()
+}
+
+func (t tagRepository) CountTags(ctx context.Context) (int, error) {
+	query := `
+		SELECT count(DISTINCT mt.tag_id)
+		FROM music_tags mt
+		JOIN music m ON m.id = mt.music_id AND m.deleted_at IS NULL
+	`
+
+	var total int
+	err := t.pool.QueryRow(ctx, query).Scan(&total)
+	if err != nil {
+		log.Errorf("Error counting tags: %v", err)
+		return 0, err
+	}
+	return total, nil
+}
+
+func (t tagRepository) GetTags(ctx context.Context, page, pageSize int) ([]models.Tag, error) {
+	query := `
+		SELECT t.name, count(mt.music_id)
+		FROM tags t
+		JOIN music_tags mt ON mt.tag_id = t.id
+		JOIN music m ON m.id = mt.music_id AND m.deleted_at IS NULL
+		GROUP BY t.id
+		ORDER BY count(mt.music_id) DESC, t.name
+		LIMIT $1 OFFSET $2
+	`
+
+	rows, err := t.pool.Query(ctx, query, pageSize, (page-1)*pageSize)
+	if err != nil {
+		log.Errorf("Error fetching tags: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var tags []models.Tag
+	for rows.Next() {
+		var tag models.Tag
+		if err := rows.Scan(&tag.Name, &tag.MusicCount); err != nil {
+			log.Errorf("Error scanning tag row: %v", err)
+			return nil, err
+		}
+		tags = append(tags, tag)
+	}
+
+	return tags, rows.Err()
+}
+
+func (t tagRepository) GetGenres(ctx context.Context) ([]models.Genre, error) {
+	query := `
+		SELECT g.id, g.name, count(mg.music_id)
+		FROM genres g
+		LEFT JOIN (music_genres mg JOIN music m ON m.id = mg.music_id AND m.deleted_at IS NULL) ON mg.genre_id = g.id
+		GROUP BY g.id
+		ORDER BY g.name
+	`
+
+	rows, err := t.pool.Query(ctx, query)
+	if err != nil {
+		log.Errorf("Error fetching genres: %v", err)
+		return nil, err
+	}
+	defer rows.Close()
+
+	var genres []models.Genre
+	for rows.Next() {
+		var genre models.Genre
+		if err := rows.Scan(&genre.ID, &genre.Name, &genre.MusicCount); err != nil {
+			log.Errorf("Error scanning genre row: %v", err)
+			return nil, err
+		}
+		genres = append(genres, genre)
+	}
+
+	return genres, rows.Err()
+}
+
+func (t tagRepository) CreateGenre(ctx context.Context, name string) (*models.Genre, error) {
+	log.Infof("Creating genre: %s", name)
+
+	genre := models.Genre{Name: name}
+	err := t.pool.QueryRow(ctx, `INSERT INTO genres (name) VALUES ($1) RETURNING id`, name).Scan(&genre.ID)
+	if isPgError(err, pgUniqueViolation) {
+		log.Warnf("Genre %s already exists", name)
+		return nil, models.ErrGenreExists
+	}
+	if err != nil {
+		log.Errorf("Error creating genre %s: %v", name, err)
+		return nil, err
+	}
+
+	return &genre, nil
+}
+
+func (t tagRepository) GetMusicTags(ctx context.Context, musicID string) (*models.MusicTags, error) {
+	var exists bool
+	err := t.pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM music WHERE id = $1 AND deleted_at IS NULL)`, musicID).Scan(&exists)
+	if err != nil {
+		log.Errorf("Error checking music %s: %v", musicID, err)
+		return nil, err
+	}
+	if !exists {
+		return nil, nil
+	}
+
+	tags, err := loadMusicTags(ctx, t.pool, []string{musicID})
+	if err != nil {
+		log.Errorf("Error fetching tags of music %s: %v", musicID, err)
+		return nil, err
+	}
+
+	musicTags := tags[musicID]
+	musicTags.MusicID = musicID
+	return &musicTags, nil
+}
+
+func (t tagRepository) AddMusicTags(ctx context.Context, musicID string, tags []string) (*models.MusicTags, error) {
+	log.Infof("Tagging music %s with %v", musicID, tags)
+
+	id, err := strconv.Atoi(musicID)
+	if err != nil {
+		return nil, nil
+	}
+
+	existing, err := t.GetMusicTags(ctx, musicID)
+	if existing == nil || err != nil {
+		return nil, err
+	}
+
+	err = insertMusicTags(ctx, t.pool, id, tags, models.TagSourceCurator)
+	if isPgError(err, pgForeignKeyViolation) {
+		log.Warnf("Music %s not found", musicID)
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf("Error tagging music %s: %v", musicID, err)
+		return nil, err
+	}
+
+	return t.GetMusicTags(ctx, musicID)
+}
+
+func (t tagRepository) RemoveMusicTag(ctx context.Context, musicID, tag string) (*models.MusicTags, error) {
+	log.Infof("Removing tag %s from music %s", tag, musicID)
+
+	_, err := t.pool.Exec(ctx, `
+		DELETE FROM music_tags
+		WHERE music_id = $1 AND tag_id = (SELECT id FROM tags WHERE name = $2)
+	`, musicID, tag)
+	if err != nil {
+		log.Errorf("Error removing tag %s from music %s: %v", tag, musicID, err)
+		return nil, err
+	}
+
+	return t.GetMusicTags(ctx, musicID)
+}
+
+func (t tagRepository) AddMusicGenre(ctx context.Context, musicID, genre string) (*models.MusicTags, error) {
+	log.Infof("Adding music %s to genre %s", musicID, genre)
+
+	var genreID int
+	err := t.pool.QueryRow(ctx, `SELECT id FROM genres WHERE lower(name) = lower($1)`, genre).Scan(&genreID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		log.Warnf("Genre %s not found", genre)
+		return nil, models.ErrGenreNotFound
+	}
+	if err != nil {
+		log.Errorf("Error fetching genre %s: %v", genre, err)
+		return nil, err
+	}
+
+	_, err = t.pool.Exec(ctx, `
+		INSERT INTO music_genres (music_id, genre_id)
+		SELECT id, $2 FROM music WHERE id = $1 AND deleted_at IS NULL
+		ON CONFLICT DO NOTHING
+	`, musicID, genreID)
+	if isPgError(err, pgForeignKeyViolation) {
+		log.Warnf("Music %s not found", musicID)
+		return nil, nil
+	}
+	if err != nil {
+		log.Errorf("Error adding music %s to genre %s: %v", musicID, genre, err)
+		return nil, err
+	}
+
+	return t.GetMusicTags(ctx, musicID)
+}
+
+func (t tagRepository) RemoveMusicGenre(ctx context.Context, musicID, genre string) (*models.MusicTags, error) {
+	log.Infof("Removing music %s from genre %s", musicID, genre)
+
+	_, err := t.pool.Exec(ctx, `
+		DELETE FROM music_genres
+		WHERE music_id = $1 AND genre_id IN (SELECT id FROM genres WHERE lower(name) = lower($2))
+	`, musicID, genre)
+	if err != nil {
+		log.Errorf("Error removing music %s from genre %s: %v", musicID, genre, err)
+		return nil, err
+	}
+
+	return t.GetMusicTags(ctx, musicID)
+}
+
+func NewTagRepository(pool *pgxpool.Pool) models.TagRepository {
+	log.Info("Creating new tag repository")
+	return &tagRepository{pool: pool}
+}
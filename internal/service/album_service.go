@@ -0,0 +1,160 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+)
+
+type albumService struct {
+	albumRepository models.AlbumRepository
+}
+
+func ValidateAlbumID(albumID string) error {
+	if albumID == "" {
+		log.Warn("Validation failed: album id is empty")
+		return models.NewValidationError("id", "album id is required")
+	}
+	return nil
+}
+
+func ValidateAlbumTrack(track models.AlbumTrack) error {
+	if err := ValidateMusicID(track.MusicID); err != nil {
+		return err
+	}
+	if track.DiscNumber < 1 {
+		log.Warnf("Validation failed: disc number %d is not positive", track.DiscNumber)
+		return models.NewValidationError("disc_number", "disc number must be greater than zero")
+	}
+	if track.TrackNumber < 0 {
+		log.Warnf("Validation failed: track number %d is negative", track.TrackNumber)
+		return models.NewValidationError("track_number", "track number must be greater or equal to zero")
+	}
+	return nil
+}
+
+func (a albumService) CreateAlbum(ctx context.Context, album *models.Album) (*models.Album, error) {
+	log.Infof("Creating album: %s", album.Title)
+
+	if album.Title == "" {
+		log.Warn("Validation failed: album title is empty")
+		return nil, models.NewValidationError("title", "album title is required")
+	}
+	if len(album.Title) > 255 {
+		log.Warnf("Validation failed: album title %s is too long", album.Title)
+		return nil, models.NewValidationError("title", "album title must be shorter than 255 characters")
+	}
+
+	res, err := a.albumRepository.CreateAlbum(ctx, album)
+	if err != nil {
+		log.Errorf("Error creating album: %v", err)
+		return nil, err
+	}
+	if res == nil {
+		log.Warnf("Validation failed: artist %s not found", album.ArtistID)
+		return nil, models.NewFieldError("artist_id", fmt.Errorf("artist %s not found", album.ArtistID))
+	}
+
+	log.Infof("Album created successfully: %s", res.ID)
+	return res, nil
+}
+
+func (a albumService) GetAlbum(ctx context.Context, albumID string) (*models.Album, error) {
+	log.Infof("Fetching album with ID: %s", albumID)
+
+	err := ValidateAlbumID(albumID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	res, err := a.albumRepository.GetAlbum(ctx, albumID)
+	if err != nil {
+		log.Errorf("Error fetching album with ID %s: %v", albumID, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a albumService) GetAlbums(ctx context.Context, filters models.AlbumFilters, page, pageSize int) (*models.Page[models.Album], error) {
+	log.Infof("Fetching album list with filters: %+v", filters)
+
+	page, pageSize, err := NormalizePagination(page, pageSize)
+	if err != nil {
+		log.Warnf("Pagination validation failed: %v", err)
+		return nil, err
+	}
+
+	err = checkContext(ctx)
+	if err != nil {
+		log.Warnf("Context error: %v", err)
+		return nil, err
+	}
+
+	items, err := a.albumRepository.GetAlbums(ctx, filters, page, pageSize)
+	if err != nil {
+		log.Errorf("Error fetching album list: %v", err)
+		return nil, err
+	}
+
+	res, err := newPage(items, page, pageSize, func() (int, error) {
+		return a.albumRepository.CountAlbums(ctx, filters)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Successfully fetched %d albums", len(res.Items))
+	return res, nil
+}
+
+func (a albumService) AddTrack(ctx context.Context, albumID string, track models.AlbumTrack) (*models.AlbumTrack, error) {
+	log.Infof("Adding music %s to album %s", track.MusicID, albumID)
+
+	if track.DiscNumber == 0 {
+		track.DiscNumber = 1
+	}
+
+	err := ValidateAlbumID(albumID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+	err = ValidateAlbumTrack(track)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	res, err := a.albumRepository.AddTrack(ctx, albumID, track)
+	if err != nil {
+		log.Errorf("Error adding music %s to album %s: %v", track.MusicID, albumID, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a albumService) GetTracks(ctx context.Context, albumID string) ([]models.AlbumTrack, error) {
+	log.Infof("Fetching tracks of album %s", albumID)
+
+	err := ValidateAlbumID(albumID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	res, err := a.albumRepository.GetTracks(ctx, albumID)
+	if err != nil {
+		log.Errorf("Error fetching tracks of album %s: %v", albumID, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func NewAlbumService(albumRepository models.AlbumRepository) models.AlbumService {
+	log.Info("Creating new album service")
+	return &albumService{
+		albumRepository: albumRepository,
+	}
+}
@@ -0,0 +1,82 @@
+package service
+
+import (
+	"fmt"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"regexp"
+	"strings"
+)
+
+var (
+	featuringRegexp       = regexp.MustCompile(`(?i)\s*[(\[]?\s*\b(?:feat|ft|featuring)\b\.?\s*`)
+	artistSeparatorRegexp = regexp.MustCompile(`\s*(?:&|,)\s*`)
+)
+
+// ParseArtistCredits splits a group name such as "A & B feat. C" into primary and featured artists.
+// Names joined with "&" or "," are only split when isKnownArtist does not recognise the whole
+// name, so that e.g. "Simon & Garfunkel" stays a single artist once it is in the library.
+func ParseArtistCredits(groupName string, isKnownArtist func(name string) bool) []models.MusicArtist {
+	primaryPart, featuredPart := groupName, ""
+	if loc := featuringRegexp.FindStringIndex(groupName); loc != nil && loc[0] > 0 {
+		primaryPart = groupName[:loc[0]]
+		featuredPart = strings.TrimRight(groupName[loc[1]:], ")] ")
+	}
+
+	var credits []models.MusicArtist
+	seen := map[string]bool{}
+	add := func(part string, role models.ArtistRole) {
+		for _, name := range splitArtistNames(part, isKnownArtist) {
+			if seen[strings.ToLower(name)] {
+				continue
+			}
+			seen[strings.ToLower(name)] = true
+			credits = append(credits, models.MusicArtist{Name: name, Role: role})
+		}
+	}
+	add(primaryPart, models.RolePrimary)
+	add(featuredPart, models.RoleFeatured)
+
+	return credits
+}
+
+func splitArtistNames(part string, isKnownArtist func(name string) bool) []string {
+	part = strings.TrimSpace(part)
+	if part == "" {
+		return nil
+	}
+	if isKnownArtist != nil && isKnownArtist(part) {
+		return []string{part}
+	}
+
+	var names []string
+	for _, name := range artistSeparatorRegexp.Split(part, -1) {
+		if name = strings.TrimSpace(name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func ValidateArtistCredits(credits []models.MusicArtist) error {
+	for _, credit := range credits {
+		if credit.ArtistID == "" && strings.TrimSpace(credit.Name) == "" {
+			return models.NewValidationError("artists", "artist credit requires a name or an artist id")
+		}
+		if len(credit.Name) > 255 {
+			return models.NewValidationError("artists", "artist name must be shorter than 255 characters")
+		}
+		if !isArtistRole(credit.Role) {
+			return models.NewFieldError("artists", fmt.Errorf("unknown artist role %q", credit.Role))
+		}
+	}
+	return nil
+}
+
+func isArtistRole(role models.ArtistRole) bool {
+	for _, known := range models.ArtistRoles {
+		if role == known {
+			return true
+		}
+	}
+	return false
+}
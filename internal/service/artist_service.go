@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+type artistService struct {
+	artistRepository models.ArtistRepository
+}
+
+func ValidateArtistID(artistID string) error {
+	if artistID == "" {
+		log.Warn("Validation failed: artist id is empty")
+		return models.NewValidationError("id", "artist id is required")
+	}
+	return nil
+}
+
+func ValidateArtist(artist models.Artist) error {
+	if len(artist.Name) > 255 {
+		log.Warnf("Validation failed: artist name %s is too long", artist.Name)
+		return models.NewValidationError("name", "artist name must be shorter than 255 characters")
+	}
+	if len(artist.Country) > 64 {
+		log.Warnf("Validation failed: country %s is too long", artist.Country)
+		return models.NewValidationError("country", "country must be shorter than 64 characters")
+	}
+	if artist.FormedYear != nil && (*artist.FormedYear < 1000 || *artist.FormedYear > time.Now().Year()) {
+		log.Warnf("Validation failed: formed year %d is out of range", *artist.FormedYear)
+		return models.NewValidationError("formed_year", "formed year must be between 1000 and the current year")
+	}
+	return nil
+}
+
+func (a artistService) CreateArtist(ctx context.Context, artist *models.Artist) (*models.Artist, error) {
+	log.Infof("Creating artist: %s", artist.Name)
+
+	if artist.Name == "" {
+		log.Warn("Validation failed: artist name is empty")
+		return nil, models.NewValidationError("name", "artist name is required")
+	}
+	err := ValidateArtist(*artist)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	res, err := a.artistRepository.CreateArtist(ctx, artist)
+	if err != nil {
+		log.Errorf("Error creating artist: %v", err)
+		return nil, err
+	}
+
+	log.Infof("Artist created successfully: %s", res.ID)
+	return res, nil
+}
+
+func (a artistService) GetArtist(ctx context.Context, artistID string) (*models.Artist, error) {
+	log.Infof("Fetching artist with ID: %s", artistID)
+
+	err := ValidateArtistID(artistID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	res, err := a.artistRepository.GetArtist(ctx, artistID)
+	if err != nil {
+		log.Errorf("Error fetching artist with ID %s: %v", artistID, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (a artistService) GetArtists(ctx context.Context, filters models.ArtistFilters, page, pageSize int) (*models.Page[models.Artist], error) {
+	log.Infof("Fetching artist list with filters: %+v", filters)
+
+	page, pageSize, err := NormalizePagination(page, pageSize)
+	if err != nil {
+		log.Warnf("Pagination validation failed: %v", err)
+		return nil, err
+	}
+
+	err = checkContext(ctx)
+	if err != nil {
+		log.Warnf("Context error: %v", err)
+		return nil, err
+	}
+
+	items, err := a.artistRepository.GetArtists(ctx, filters, page, pageSize)
+	if err != nil {
+		log.Errorf("Error fetching artist list: %v", err)
+		return nil, err
+	}
+
+	res, err := newPage(items, page, pageSize, func() (int, error) {
+		return a.artistRepository.CountArtists(ctx, filters)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Successfully fetched %d artists", len(res.Items))
+	return res, nil
+}
+
+func (a artistService) UpdateArtist(ctx context.Context, artist models.Artist) (*models.Artist, error) {
+	log.Infof("Updating artist with ID: %s", artist.ID)
+
+	err := ValidateArtistID(artist.ID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+	err = ValidateArtist(artist)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	res, err := a.artistRepository.UpdateArtist(ctx, artist)
+	if err != nil {
+		log.Errorf("Error updating artist with ID %s: %v", artist.ID, err)
+		return nil, err
+	}
+
+	log.Infof("Artist with ID %s updated successfully", artist.ID)
+	return res, nil
+}
+
+func (a artistService) DeleteArtist(ctx context.Context, artistID string) error {
+	log.Infof("Deleting artist with ID: %s", artistID)
+
+	err := ValidateArtistID(artistID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return err
+	}
+
+	err = a.artistRepository.DeleteArtist(ctx, artistID)
+	if err != nil {
+		log.Errorf("Error deleting artist with ID %s: %v", artistID, err)
+		return err
+	}
+
+	log.Infof("Artist with ID %s deleted successfully", artistID)
+	return nil
+}
+
+func NewArtistService(artistRepository models.ArtistRepository) models.ArtistService {
+	log.Info("Creating new artist service")
+	return &artistService{
+		artistRepository: artistRepository,
+	}
+}
@@ -2,139 +2,170 @@ package service
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"github.com/Seven11Eleven/music_library/internal/config"
 	"github.com/Seven11Eleven/music_library/internal/domain/models"
 	log "github.com/sirupsen/logrus"
 	"net/http"
-	"net/url"
 	"strings"
 	"time"
 )
 
+const defaultEnrichmentTimeout = 10 * time.Second
+
 type DataEnrichmentService interface {
 	FetchEnrichedMusic(ctx context.Context, groupName, musicName string) (*models.Music, error)
+	ProviderStatuses() []ProviderStatus
 }
 
 type dataEnrichmentService struct {
-	config *config.Config
+	metadataProviders []MetadataProvider
+	lyricsProviders   []LyricsProvider
 }
 
-func (d dataEnrichmentService) FetchEnrichedMusic(_ context.Context, groupName, musicName string) (*models.Music, error) {
+func (d dataEnrichmentService) FetchEnrichedMusic(ctx context.Context, groupName, musicName string) (*models.Music, error) {
 	log.Infof("Starting enrichment for song '%s' by group '%s'", musicName, groupName)
 
-	encodedGroupName := url.QueryEscape(groupName)
-	encodedMusicName := url.QueryEscape(musicName)
-
-	apiKey := d.config.APIKey
-	urlDetails := fmt.Sprintf("http://ws.audioscrobbler.com/2.0/?method=track.getInfo&api_key=%s&artist=%s&track=%s&format=json", apiKey, encodedGroupName, encodedMusicName)
-	urlLyrics := fmt.Sprintf("https://lyrist.vercel.app/api/%s/%s", encodedMusicName, encodedGroupName)
-
-	log.Infof("Fetching track details from URL: %s", urlDetails)
-	resDetails, err := http.Get(urlDetails)
+	metadata, err := d.fetchMetadata(ctx, groupName, musicName)
 	if err != nil {
-		log.Errorf("Failed to fetch track details: %v", err)
 		return nil, err
 	}
-	defer resDetails.Body.Close()
 
-	if resDetails.StatusCode != http.StatusOK {
-		log.Errorf("Received non-200 status code from track details API: %s", resDetails.Status)
-		return nil, fmt.Errorf(resDetails.Status)
+	lyrics, err := d.fetchLyrics(ctx, groupName, musicName)
+	if err != nil {
+		return nil, err
 	}
 
-	var trackData struct {
-		Track struct {
-			URL  string `json:"url"`
-			Wiki struct {
-				Published string `json:"published"`
-			} `json:"wiki"`
-		} `json:"track"`
-	}
+	log.Info("Parsing verses from lyrics...")
+	verses := parseVerses(lyrics)
 
-	log.Info("Parsing track details response...")
-	if err := json.NewDecoder(resDetails.Body).Decode(&trackData); err != nil {
-		log.Errorf("Failed to parse track details: %v", err)
-		return nil, err
+	music := &models.Music{
+		ReleaseDate:          metadata.ReleaseDate,
+		Verses:               verses,
+		Link:                 metadata.Link,
+		SongName:             musicName,
+		GroupName:            groupName,
+		Album:                metadata.Album,
+		Tags:                 metadata.Tags,
+		ReleaseDatePrecision: metadata.ReleaseDatePrecision,
 	}
 
-	if trackData.Track.URL == "" {
-		log.Warnf("No track URL found for song '%s' by group '%s'", musicName, groupName)
-		return nil, fmt.Errorf("no track URL found for song %s by group %s", musicName, groupName)
-	}
+	log.Infof("Successfully enriched music for song '%s' by group '%s'", musicName, groupName)
+	return music, nil
+}
 
-	var releaseDate *time.Time
-	if trackData.Track.Wiki.Published != "" {
-		log.Infof("Parsing release date: %s", trackData.Track.Wiki.Published)
-		parsedDate, err := time.Parse("2 Jan 2006, 15:04", trackData.Track.Wiki.Published)
+func (d dataEnrichmentService) fetchMetadata(ctx context.Context, groupName, musicName string) (*TrackMetadata, error) {
+	lastErr := fmt.Errorf("no metadata providers configured")
+
+	for _, provider := range d.metadataProviders {
+		metadata, err := provider.FetchMetadata(ctx, groupName, musicName)
 		if err != nil {
-			log.Warnf("Error parsing release date, using default: %v", err)
-		} else {
-			releaseDate = &parsedDate
+			log.Warnf("Metadata provider '%s' failed, trying next: %v", provider.Name(), err)
+			lastErr = err
+			continue
 		}
-	}
 
-	log.Infof("Fetching lyrics from URL: %s", urlLyrics)
-	resLyrics, err := http.Get(urlLyrics)
-	if err != nil {
-		log.Errorf("Failed to fetch lyrics: %v", err)
-		return nil, err
+		log.Infof("Fetched track metadata from provider '%s'", provider.Name())
+		return metadata, nil
 	}
-	defer resLyrics.Body.Close()
 
-	if resLyrics.StatusCode != http.StatusOK {
-		log.Errorf("Received non-200 response code from lyrics API")
-		return nil, fmt.Errorf("received non-200 response code from lyrics API")
-	}
+	log.Errorf("All metadata providers failed for song '%s' by group '%s'", musicName, groupName)
+	return nil, models.WrapError(models.ErrUpstream, lastErr)
+}
 
-	var lyricsData struct {
-		Lyrics string `json:"lyrics"`
-	}
+func (d dataEnrichmentService) fetchLyrics(ctx context.Context, groupName, musicName string) (string, error) {
+	lastErr := fmt.Errorf("no lyrics providers configured")
 
-	log.Info("Parsing lyrics response...")
-	if err := json.NewDecoder(resLyrics.Body).Decode(&lyricsData); err != nil {
-		log.Errorf("Failed to parse lyrics: %v", err)
-		return nil, err
-	}
+	for _, provider := range d.lyricsProviders {
+		lyrics, err := provider.FetchLyrics(ctx, groupName, musicName)
+		if err != nil {
+			log.Warnf("Lyrics provider '%s' failed, trying next: %v", provider.Name(), err)
+			lastErr = err
+			continue
+		}
 
-	if strings.TrimSpace(lyricsData.Lyrics) == "" {
-		log.Warnf("No lyrics found for song '%s' by group '%s'", musicName, groupName)
-		return nil, fmt.Errorf("no lyrics found for song %s by group %s", musicName, groupName)
+		if strings.TrimSpace(lyrics) == "" {
+			log.Warnf("Lyrics provider '%s' found no lyrics for song '%s' by group '%s'", provider.Name(), musicName, groupName)
+			lastErr = fmt.Errorf("no lyrics found for song %s by group %s", musicName, groupName)
+			continue
+		}
+
+		log.Infof("Fetched lyrics from provider '%s'", provider.Name())
+		return lyrics, nil
 	}
 
-	log.Info("Parsing verses from lyrics...")
-	verses := parseVerses(lyricsData.Lyrics)
+	log.Errorf("All lyrics providers failed for song '%s' by group '%s'", musicName, groupName)
+	return "", models.WrapError(models.ErrUpstream, lastErr)
+}
 
-	music := &models.Music{
-		ReleaseDate: releaseDate,
-		Verses:      verses,
-		Link:        trackData.Track.URL,
-		SongName:    musicName,
-		GroupName:   groupName,
+func (d dataEnrichmentService) ProviderStatuses() []ProviderStatus {
+	statuses := make([]ProviderStatus, 0, len(d.metadataProviders)+len(d.lyricsProviders))
+
+	for _, provider := range d.metadataProviders {
+		if reporter, ok := provider.(statusReporter); ok {
+			status := reporter.Status()
+			status.Kind = "metadata"
+			statuses = append(statuses, status)
+		}
+	}
+	for _, provider := range d.lyricsProviders {
+		if reporter, ok := provider.(statusReporter); ok {
+			status := reporter.Status()
+			status.Kind = "lyrics"
+			statuses = append(statuses, status)
+		}
 	}
 
-	log.Infof("Successfully enriched music for song '%s' by group '%s'", musicName, groupName)
-	return music, nil
+	return statuses
 }
 
-func parseVerses(songText string) []models.Verse {
-	verseTexts := strings.Split(songText, "\n\n")
-	verses := make([]models.Verse, 0, len(verseTexts))
+type enrichmentOptions struct {
+	client  *http.Client
+	timeout time.Duration
+}
 
-	for i, verseText := range verseTexts {
-		verse := models.Verse{
-			Text:   verseText,
-			Number: i,
-		}
-		verses = append(verses, verse)
+type EnrichmentOption func(*enrichmentOptions)
+
+// WithHTTPClient makes every provider send its requests through client instead of a default one.
+func WithHTTPClient(client *http.Client) EnrichmentOption {
+	return func(o *enrichmentOptions) {
+		o.client = client
 	}
+}
 
-	log.Infof("Parsed %d verses from song text", len(verses))
-	return verses
+// WithTimeout overrides ENRICHMENT_TIMEOUT for the default client. It has no effect together with WithHTTPClient.
+func WithTimeout(timeout time.Duration) EnrichmentOption {
+	return func(o *enrichmentOptions) {
+		o.timeout = timeout
+	}
 }
 
-func NewDataEnrichmentService(cfg *config.Config) DataEnrichmentService {
+func NewDataEnrichmentService(cfg *config.Config, opts ...EnrichmentOption) DataEnrichmentService {
 	log.Info("Creating new data enrichment service")
-	return &dataEnrichmentService{config: cfg}
+
+	options := enrichmentOptions{timeout: cfg.EnrichmentTimeout}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	if options.timeout <= 0 {
+		options.timeout = defaultEnrichmentTimeout
+	}
+	if options.client == nil {
+		options.client = &http.Client{Timeout: options.timeout}
+	}
+
+	return NewDataEnrichmentServiceWithProviders(
+		buildMetadataProviders(cfg, options.client),
+		buildLyricsProviders(cfg, options.client),
+	)
+}
+
+// NewDataEnrichmentServiceWithProviders builds the service from an explicit provider chain.
+// Providers are tried in the given order until one of them succeeds.
+func NewDataEnrichmentServiceWithProviders(metadataProviders []MetadataProvider, lyricsProviders []LyricsProvider) DataEnrichmentService {
+	return &dataEnrichmentService{
+		metadataProviders: metadataProviders,
+		lyricsProviders:   lyricsProviders,
+	}
 }
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/config"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+const defaultDuplicateScanInterval = time.Hour
+
+// DuplicateScanner periodically refreshes the duplicate report.
+type DuplicateScanner struct {
+	musicService models.MusicService
+	interval     time.Duration
+}
+
+// Run scans right away and then every interval until ctx is cancelled.
+func (s *DuplicateScanner) Run(ctx context.Context) {
+	log.Infof("Starting duplicate scanner with interval %s", s.interval)
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := s.musicService.ScanDuplicates(ctx); err != nil {
+			log.Errorf("Duplicate scan failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info("Duplicate scanner stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func NewDuplicateScanner(musicService models.MusicService, cfg *config.Config) *DuplicateScanner {
+	log.Info("Creating new duplicate scanner")
+
+	interval := cfg.DuplicateScanInterval
+	if interval <= 0 {
+		interval = defaultDuplicateScanInterval
+	}
+
+	return &DuplicateScanner{
+		musicService: musicService,
+		interval:     interval,
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+	"sort"
+)
+
+const defaultDuplicateThreshold = 0.6
+
+// duplicateScore combines the name similarity of a pair with the similarity of its lyrics when both songs have lyrics.
+func duplicateScore(pair models.DuplicatePair) float64 {
+	score := (pair.TitleSimilarity + pair.GroupSimilarity) / 2
+	if pair.LyricsSimilarity != nil {
+		score = (score + *pair.LyricsSimilarity) / 2
+	}
+	return score
+}
+
+// clusterDuplicates joins pairs scoring at least threshold into clusters of songs that are
+// transitively similar. A cluster scores the average of its pairs.
+func clusterDuplicates(pairs []models.DuplicatePair, threshold float64) []models.DuplicateCluster {
+	parent := map[string]string{}
+	var find func(id string) string
+	find = func(id string) string {
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+
+	var matched []models.DuplicatePair
+	for _, pair := range pairs {
+		if duplicateScore(pair) < threshold {
+			continue
+		}
+		for _, id := range []string{pair.MusicID, pair.OtherID} {
+			if _, ok := parent[id]; !ok {
+				parent[id] = id
+			}
+		}
+		parent[find(pair.OtherID)] = find(pair.MusicID)
+		matched = append(matched, pair)
+	}
+
+	members := map[string][]string{}
+	for id := range parent {
+		root := find(id)
+		members[root] = append(members[root], id)
+	}
+
+	scores := map[string]float64{}
+	counts := map[string]int{}
+	for _, pair := range matched {
+		root := find(pair.MusicID)
+		scores[root] += duplicateScore(pair)
+		counts[root]++
+	}
+
+	clusters := make([]models.DuplicateCluster, 0, len(members))
+	for root, ids := range members {
+		sort.Slice(ids, func(i, j int) bool {
+			if len(ids[i]) != len(ids[j]) {
+				return len(ids[i]) < len(ids[j])
+			}
+			return ids[i] < ids[j]
+		})
+
+		cluster := models.DuplicateCluster{Score: scores[root] / float64(counts[root])}
+		for _, id := range ids {
+			cluster.Music = append(cluster.Music, models.Music{ID: id})
+		}
+		clusters = append(clusters, cluster)
+	}
+
+	sort.Slice(clusters, func(i, j int) bool {
+		if clusters[i].Score != clusters[j].Score {
+			return clusters[i].Score > clusters[j].Score
+		}
+		return clusters[i].Music[0].ID < clusters[j].Music[0].ID
+	})
+	return clusters
+}
+
+// ScanDuplicates clusters likely duplicates in the whole library and replaces the stored report.
+func (m musicService) ScanDuplicates(ctx context.Context) ([]models.DuplicateCluster, error) {
+	log.Info("Scanning library for duplicates")
+
+	pairs, err := m.musicRepository.FindDuplicatePairs(ctx, m.duplicateThreshold)
+	if err != nil {
+		log.Errorf("Error finding duplicate pairs: %v", err)
+		return nil, err
+	}
+
+	clusters := clusterDuplicates(pairs, m.duplicateThreshold)
+
+	err = m.musicRepository.ReplaceDuplicateClusters(ctx, clusters)
+	if err != nil {
+		log.Errorf("Error saving duplicate clusters: %v", err)
+		return nil, err
+	}
+
+	log.Infof("Duplicate scan found %d clusters", len(clusters))
+	return clusters, nil
+}
+
+func (m musicService) GetDuplicates(ctx context.Context) ([]models.DuplicateCluster, error) {
+	log.Info("Fetching duplicate report")
+
+	err := checkContext(ctx)
+	if err != nil {
+		log.Warnf("Context error: %v", err)
+		return nil, err
+	}
+
+	res, err := m.musicRepository.GetDuplicateClusters(ctx)
+	if err != nil {
+		log.Errorf("Error fetching duplicate clusters: %v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (m musicService) MergeMusic(ctx context.Context, survivorID string, mergeIDs []string, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Merging music %v into %s", mergeIDs, survivorID)
+
+	err := ValidateMusicID(survivorID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+	if len(mergeIDs) == 0 {
+		log.Warn("Validation failed: no music to merge")
+		return nil, models.NewValidationError("merge_ids", "at least one music id to merge is required")
+	}
+
+	seen := map[string]bool{survivorID: true}
+	var ids []string
+	for _, id := range mergeIDs {
+		if err := ValidateMusicID(id); err != nil {
+			log.Warnf("Validation failed: %v", err)
+			return nil, err
+		}
+		if id == survivorID {
+			log.Warnf("Validation failed: music %s cannot be merged into itself", id)
+			return nil, models.NewValidationError("merge_ids", "music cannot be merged into itself")
+		}
+		if !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	res, err := m.musicRepository.MergeMusic(ctx, survivorID, ids, expectedVersion)
+	if err != nil {
+		log.Errorf("Error merging music into %s: %v", survivorID, err)
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/config"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	defaultMetadataProvider = "lastfm"
+	defaultLyricsProvider   = "lyrist"
+)
+
+// TrackMetadata is everything a metadata provider knows about a track except its lyrics.
+type TrackMetadata struct {
+	Link        string
+	ReleaseDate *time.Time
+	Album       *models.AlbumRef
+	Tags        []string
+	// ReleaseDatePrecision tells which part of ReleaseDate the provider knows.
+	ReleaseDatePrecision models.DatePrecision
+}
+
+type MetadataProvider interface {
+	Name() string
+	FetchMetadata(ctx context.Context, groupName, musicName string) (*TrackMetadata, error)
+}
+
+type LyricsProvider interface {
+	Name() string
+	FetchLyrics(ctx context.Context, groupName, musicName string) (string, error)
+}
+
+// statusReporter is implemented by providers that guard their upstream with a circuit breaker.
+type statusReporter interface {
+	Status() ProviderStatus
+}
+
+type MetadataProviderFactory func(cfg *config.Config, client *http.Client) MetadataProvider
+
+type LyricsProviderFactory func(cfg *config.Config, client *http.Client) LyricsProvider
+
+var (
+	metadataProviderFactories = map[string]MetadataProviderFactory{
+		"lastfm": NewLastFMProvider,
+	}
+	lyricsProviderFactories = map[string]LyricsProviderFactory{
+		"lyrist": NewLyristProvider,
+	}
+)
+
+// RegisterMetadataProvider makes a metadata provider available under name for the METADATA_PROVIDERS setting.
+func RegisterMetadataProvider(name string, factory MetadataProviderFactory) {
+	metadataProviderFactories[strings.ToLower(name)] = factory
+}
+
+// RegisterLyricsProvider makes a lyrics provider available under name for the LYRICS_PROVIDERS setting.
+func RegisterLyricsProvider(name string, factory LyricsProviderFactory) {
+	lyricsProviderFactories[strings.ToLower(name)] = factory
+}
+
+func buildMetadataProviders(cfg *config.Config, client *http.Client) []MetadataProvider {
+	names := cfg.MetadataProviders
+	if len(names) == 0 {
+		names = []string{defaultMetadataProvider}
+	}
+
+	providers := make([]MetadataProvider, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		factory, ok := metadataProviderFactories[name]
+		if !ok {
+			log.Warnf("Unknown metadata provider '%s', skipping", name)
+			continue
+		}
+		providers = append(providers, factory(cfg, client))
+	}
+
+	log.Infof("Configured %d metadata providers", len(providers))
+	return providers
+}
+
+func buildLyricsProviders(cfg *config.Config, client *http.Client) []LyricsProvider {
+	names := cfg.LyricsProviders
+	if len(names) == 0 {
+		names = []string{defaultLyricsProvider}
+	}
+
+	providers := make([]LyricsProvider, 0, len(names))
+	for _, name := range names {
+		name = strings.ToLower(strings.TrimSpace(name))
+		factory, ok := lyricsProviderFactories[name]
+		if !ok {
+			log.Warnf("Unknown lyrics provider '%s', skipping", name)
+			continue
+		}
+		providers = append(providers, factory(cfg, client))
+	}
+
+	log.Infof("Configured %d lyrics providers", len(providers))
+	return providers
+}
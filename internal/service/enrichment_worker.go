@@ -0,0 +1,138 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"github.com/Seven11Eleven/music_library/internal/config"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+	"sync"
+	"time"
+)
+
+const (
+	defaultJobWorkers      = 4
+	defaultJobPollInterval = time.Second
+	defaultJobLease        = 2 * time.Minute
+	defaultJobRetryDelay   = 10 * time.Second
+	// jobStatusTimeout bounds recording the outcome of a job, which is done even while shutting down.
+	jobStatusTimeout = 5 * time.Second
+)
+
+// EnrichmentWorkerPool processes queued enrichment jobs by running them through MusicService.SaveMusic.
+type EnrichmentWorkerPool struct {
+	jobRepository models.JobRepository
+	musicService  models.MusicService
+	workers       int
+	pollInterval  time.Duration
+	lease         time.Duration
+	retryDelay    time.Duration
+}
+
+// Run starts the workers and blocks until ctx is cancelled and every worker has finished its current job.
+func (p *EnrichmentWorkerPool) Run(ctx context.Context) {
+	log.Infof("Starting %d enrichment workers", p.workers)
+
+	var wg sync.WaitGroup
+	for i := 0; i < p.workers; i++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			p.work(ctx, worker)
+		}(i)
+	}
+	wg.Wait()
+
+	log.Info("Enrichment workers stopped")
+}
+
+func (p *EnrichmentWorkerPool) work(ctx context.Context, worker int) {
+	ticker := time.NewTicker(p.pollInterval)
+	defer ticker.Stop()
+
+	for {
+		processed, err := p.ProcessNext(ctx)
+		if err != nil {
+			log.Errorf("Enrichment worker %d failed to process job: %v", worker, err)
+		}
+		if processed && err == nil {
+			continue
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessNext claims a single due job and runs it. It reports whether a job was claimed.
+func (p *EnrichmentWorkerPool) ProcessNext(ctx context.Context) (bool, error) {
+	job, err := p.jobRepository.ClaimJob(ctx, p.lease)
+	if err != nil {
+		return false, err
+	}
+	if job == nil {
+		return false, nil
+	}
+
+	jobCtx, cancel := context.WithTimeout(ctx, p.lease)
+	defer cancel()
+
+	query := job.Query
+	music, err := p.musicService.SaveMusic(jobCtx, &query)
+
+	// Исход задачи записывается и после отмены ctx, иначе задача висит до истечения аренды
+	statusCtx, cancelStatus := context.WithTimeout(context.WithoutCancel(ctx), jobStatusTimeout)
+	defer cancelStatus()
+
+	if err == nil {
+		return true, p.jobRepository.CompleteJob(statusCtx, job.ID, music)
+	}
+	// Задача прервана остановкой сервиса, а не провалилась, поэтому попытка не засчитывается
+	if ctx.Err() != nil {
+		log.Infof("Enrichment job %s interrupted by shutdown: %v", job.ID, err)
+		return true, p.jobRepository.ReleaseJob(statusCtx, job.ID)
+	}
+
+	log.Warnf("Enrichment job %s failed on attempt %d: %v", job.ID, job.Attempts, err)
+
+	// Possible duplicates won't go away by retrying, the client has to resubmit the song with force.
+	var duplicates *models.DuplicatesError
+	var retryAt *time.Time
+	if job.Attempts < job.MaxAttempts && !errors.As(err, &duplicates) {
+		next := time.Now().Add(p.retryDelay << (job.Attempts - 1))
+		retryAt = &next
+	}
+
+	return true, p.jobRepository.FailJob(statusCtx, job.ID, err.Error(), retryAt)
+}
+
+func NewEnrichmentWorkerPool(jobRepository models.JobRepository, musicService models.MusicService, cfg *config.Config) *EnrichmentWorkerPool {
+	log.Info("Creating new enrichment worker pool")
+
+	pool := &EnrichmentWorkerPool{
+		jobRepository: jobRepository,
+		musicService:  musicService,
+		workers:       cfg.JobWorkers,
+		pollInterval:  cfg.JobPollInterval,
+		lease:         cfg.JobLease,
+		retryDelay:    cfg.JobRetryDelay,
+	}
+
+	if pool.workers <= 0 {
+		pool.workers = defaultJobWorkers
+	}
+	if pool.pollInterval <= 0 {
+		pool.pollInterval = defaultJobPollInterval
+	}
+	if pool.lease <= 0 {
+		pool.lease = defaultJobLease
+	}
+	if pool.retryDelay <= 0 {
+		pool.retryDelay = defaultJobRetryDelay
+	}
+
+	return pool
+}
@@ -0,0 +1,59 @@
+package service
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/config"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const defaultJobMaxAttempts = 5
+
+type jobService struct {
+	jobRepository models.JobRepository
+	maxAttempts   int
+}
+
+func (j jobService) EnqueueMusic(ctx context.Context, music *models.MusicQuery) (*models.EnrichmentJob, error) {
+	log.Infof("Enqueueing music: %s by %s", music.SongName, music.GroupName)
+
+	job, err := j.jobRepository.CreateJob(ctx, *music, j.maxAttempts)
+	if err != nil {
+		log.Errorf("Error enqueueing music: %v", err)
+		return nil, err
+	}
+
+	log.Infof("Music enqueued as job %s", job.ID)
+	return job, nil
+}
+
+func (j jobService) GetJob(ctx context.Context, jobID string) (*models.EnrichmentJob, error) {
+	log.Infof("Fetching enrichment job with ID: %s", jobID)
+
+	if jobID == "" {
+		log.Warn("Validation failed: job id is empty")
+		return nil, models.NewValidationError("id", "job id is required")
+	}
+
+	job, err := j.jobRepository.GetJob(ctx, jobID)
+	if err != nil {
+		log.Errorf("Error fetching enrichment job %s: %v", jobID, err)
+		return nil, err
+	}
+
+	return job, nil
+}
+
+func NewJobService(jobRepository models.JobRepository, cfg *config.Config) models.JobService {
+	log.Info("Creating new job service")
+
+	maxAttempts := cfg.JobMaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = defaultJobMaxAttempts
+	}
+
+	return &jobService{
+		jobRepository: jobRepository,
+		maxAttempts:   maxAttempts,
+	}
+}
@@ -0,0 +1,185 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Seven11Eleven/music_library/internal/config"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	defaultLastFMBaseURL = "http://ws.audioscrobbler.com/2.0/"
+	maxLastFMTags        = 5
+)
+
+type lastFMProvider struct {
+	apiKey  string
+	baseURL string
+	client  *resilientClient
+}
+
+func (l lastFMProvider) Name() string {
+	return "lastfm"
+}
+
+func (l lastFMProvider) Status() ProviderStatus {
+	return l.client.status()
+}
+
+func (l lastFMProvider) FetchMetadata(ctx context.Context, groupName, musicName string) (*TrackMetadata, error) {
+	encodedGroupName := url.QueryEscape(groupName)
+	encodedMusicName := url.QueryEscape(musicName)
+
+	urlDetails := fmt.Sprintf("%s?method=track.getInfo&api_key=%s&artist=%s&track=%s&format=json", l.baseURL, l.apiKey, encodedGroupName, encodedMusicName)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlDetails, nil)
+	if err != nil {
+		log.Errorf("Failed to build track details request: %v", err)
+		return nil, err
+	}
+
+	log.Infof("Fetching track details from URL: %s", urlDetails)
+	resDetails, err := l.client.Do(req)
+	if err != nil {
+		log.Errorf("Failed to fetch track details: %v", err)
+		return nil, err
+	}
+	defer resDetails.Body.Close()
+
+	if resDetails.StatusCode != http.StatusOK {
+		log.Errorf("Received non-200 status code from track details API: %s", resDetails.Status)
+		return nil, fmt.Errorf(resDetails.Status)
+	}
+
+	var trackData struct {
+		Track struct {
+			URL  string `json:"url"`
+			Wiki struct {
+				Published string `json:"published"`
+			} `json:"wiki"`
+			Album struct {
+				Title string `json:"title"`
+				URL   string `json:"url"`
+				Attr  struct {
+					Position string `json:"position"`
+				} `json:"@attr"`
+			} `json:"album"`
+			TopTags struct {
+				Tag json.RawMessage `json:"tag"`
+			} `json:"toptags"`
+		} `json:"track"`
+	}
+
+	log.Info("Parsing track details response...")
+	if err := json.NewDecoder(resDetails.Body).Decode(&trackData); err != nil {
+		log.Errorf("Failed to parse track details: %v", err)
+		return nil, err
+	}
+
+	if trackData.Track.URL == "" {
+		log.Warnf("No track URL found for song '%s' by group '%s'", musicName, groupName)
+		return nil, fmt.Errorf("no track URL found for song %s by group %s", musicName, groupName)
+	}
+
+	var releaseDate *time.Time
+	var releaseDatePrecision models.DatePrecision
+	if trackData.Track.Wiki.Published != "" {
+		log.Infof("Parsing release date: %s", trackData.Track.Wiki.Published)
+		parsedDate, precision, err := parseLastFMDate(trackData.Track.Wiki.Published)
+		if err != nil {
+			log.Warnf("Error parsing release date, using default: %v", err)
+		} else {
+			releaseDate = &parsedDate
+			releaseDatePrecision = precision
+		}
+	}
+
+	var album *models.AlbumRef
+	if trackData.Track.Album.Title != "" {
+		album = &models.AlbumRef{
+			Title: trackData.Track.Album.Title,
+			Link:  trackData.Track.Album.URL,
+		}
+		if position, err := strconv.Atoi(trackData.Track.Album.Attr.Position); err == nil {
+			album.TrackNumber = position
+		}
+	}
+
+	return &TrackMetadata{
+		Link:                 trackData.Track.URL,
+		ReleaseDate:          releaseDate,
+		Album:                album,
+		Tags:                 parseLastFMTags(trackData.Track.TopTags.Tag),
+		ReleaseDatePrecision: releaseDatePrecision,
+	}, nil
+}
+
+// lastFMDateLayouts are the formats of dates published by Last.fm, which often only knows the year.
+var lastFMDateLayouts = []struct {
+	layout    string
+	precision models.DatePrecision
+}{
+	{"2 Jan 2006, 15:04", models.PrecisionDay},
+	{"2 Jan 2006", models.PrecisionDay},
+	{"Jan 2006", models.PrecisionMonth},
+	{"2006", models.PrecisionYear},
+}
+
+func parseLastFMDate(s string) (time.Time, models.DatePrecision, error) {
+	s = strings.TrimSpace(s)
+	for _, layout := range lastFMDateLayouts {
+		if t, err := time.Parse(layout.layout, s); err == nil {
+			return t, layout.precision, nil
+		}
+	}
+	return time.Time{}, "", fmt.Errorf("unknown date format %q", s)
+}
+
+// parseLastFMTags reads the top tags of a track, which Last.fm sends as an object instead of
+// an array when there is only one.
+func parseLastFMTags(raw json.RawMessage) []string {
+	type lastFMTag struct {
+		Name string `json:"name"`
+	}
+
+	var tags []lastFMTag
+	if err := json.Unmarshal(raw, &tags); err != nil {
+		var tag lastFMTag
+		if err := json.Unmarshal(raw, &tag); err != nil {
+			return nil
+		}
+		tags = []lastFMTag{tag}
+	}
+
+	var names []string
+	for _, tag := range tags {
+		if tag.Name == "" || len(tag.Name) > maxTagLength {
+			continue
+		}
+		names = append(names, tag.Name)
+		if len(names) == maxLastFMTags {
+			break
+		}
+	}
+	return names
+}
+
+func NewLastFMProvider(cfg *config.Config, client *http.Client) MetadataProvider {
+	baseURL := cfg.LastFMBaseURL
+	if baseURL == "" {
+		baseURL = defaultLastFMBaseURL
+	}
+
+	return &lastFMProvider{
+		apiKey:  cfg.APIKey,
+		baseURL: baseURL,
+		client:  newResilientClient("lastfm", cfg, client),
+	}
+}
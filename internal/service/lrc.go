@@ -0,0 +1,230 @@
+package service
+
+import (
+	"fmt"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	lrcTimeTagRegexp = regexp.MustCompile(`^\[(\d+):(\d{1,2})(?:[.:](\d{1,3}))?\]`)
+	lrcMetaTagRegexp = regexp.MustCompile(`^\[([a-zA-Z#]+):(.*)\]$`)
+	lrcWordTagRegexp = regexp.MustCompile(`<(\d+):(\d{1,2})(?:[.:](\d{1,3}))?>`)
+)
+
+// LRC is a parsed (enhanced) LRC document.
+type LRC struct {
+	Title  string
+	Artist string
+	Verses []models.Verse
+}
+
+type lrcLine struct {
+	startMs int64
+	text    string
+	words   []models.TimedWord
+	// newVerse is set for lines following an empty line, so that repeats of a line with
+	// several timestamps start a verse of their own as well.
+	newVerse bool
+}
+
+// ParseLRC reads LRC or enhanced LRC text. Lines are ordered by their timestamps and grouped
+// into verses at empty lines; a line with several timestamps is repeated at each of them.
+func ParseLRC(text string) (*LRC, error) {
+	doc := &LRC{}
+	var offsetMs int64
+	var lines []lrcLine
+	newVerse := true
+
+	for _, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		raw = strings.TrimSpace(raw)
+		if raw == "" {
+			newVerse = true
+			continue
+		}
+
+		var starts []int64
+		rest := raw
+		for {
+			match := lrcTimeTagRegexp.FindStringSubmatch(rest)
+			if match == nil {
+				break
+			}
+			starts = append(starts, lrcTimestamp(match[1], match[2], match[3]))
+			rest = rest[len(match[0]):]
+		}
+
+		if len(starts) == 0 {
+			if match := lrcMetaTagRegexp.FindStringSubmatch(raw); match != nil {
+				value := strings.TrimSpace(match[2])
+				switch strings.ToLower(match[1]) {
+				case "ti":
+					doc.Title = value
+				case "ar":
+					doc.Artist = value
+				case "offset":
+					offset, err := strconv.ParseInt(strings.TrimPrefix(value, "+"), 10, 64)
+					if err != nil {
+						return nil, fmt.Errorf("invalid LRC offset %q", value)
+					}
+					offsetMs = offset
+				}
+			}
+			continue
+		}
+
+		lineText, words := parseLRCWords(rest)
+		if lineText == "" {
+			// A timestamp without text marks an instrumental gap and ends the verse.
+			newVerse = true
+			continue
+		}
+		for _, start := range starts {
+			lines = append(lines, lrcLine{startMs: start, text: lineText, words: words, newVerse: newVerse})
+		}
+		newVerse = false
+	}
+
+	if len(lines) == 0 {
+		return nil, fmt.Errorf("LRC contains no timed lines")
+	}
+
+	sort.SliceStable(lines, func(i, j int) bool {
+		return lines[i].startMs < lines[j].startMs
+	})
+
+	var verse *models.Verse
+	for _, line := range lines {
+		if line.newVerse {
+			verse = nil
+		}
+		if verse == nil {
+			doc.Verses = append(doc.Verses, models.Verse{
+				Number:      len(doc.Verses) + 1,
+				SectionType: models.SectionVerse,
+			})
+			verse = &doc.Verses[len(doc.Verses)-1]
+		}
+
+		startMs := line.startMs - offsetMs
+		if startMs < 0 {
+			startMs = 0
+		}
+		var words []models.TimedWord
+		for _, word := range line.words {
+			word.StartMs -= offsetMs
+			if word.StartMs < 0 {
+				word.StartMs = 0
+			}
+			words = append(words, word)
+		}
+
+		verse.Lines = append(verse.Lines, models.LyricLine{
+			Number:  len(verse.Lines) + 1,
+			StartMs: startMs,
+			Text:    line.text,
+			Words:   words,
+		})
+	}
+
+	for i := range doc.Verses {
+		texts := make([]string, 0, len(doc.Verses[i].Lines))
+		for _, line := range doc.Verses[i].Lines {
+			texts = append(texts, line.Text)
+		}
+		doc.Verses[i].Text = strings.Join(texts, "\n")
+	}
+	markRepeatedSections(doc.Verses)
+
+	return doc, nil
+}
+
+func parseLRCWords(text string) (string, []models.TimedWord) {
+	matches := lrcWordTagRegexp.FindAllStringSubmatchIndex(text, -1)
+	if matches == nil {
+		return strings.TrimSpace(text), nil
+	}
+
+	var words []models.TimedWord
+	for i, match := range matches {
+		end := len(text)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		word := strings.TrimSpace(text[match[1]:end])
+		if word == "" {
+			continue
+		}
+		words = append(words, models.TimedWord{
+			StartMs: lrcTimestamp(text[match[2]:match[3]], text[match[4]:match[5]], lrcFraction(text, match)),
+			Text:    word,
+		})
+	}
+
+	plain := strings.Join(strings.Fields(lrcWordTagRegexp.ReplaceAllString(text, " ")), " ")
+	return plain, words
+}
+
+func lrcFraction(text string, match []int) string {
+	if match[6] < 0 {
+		return ""
+	}
+	return text[match[6]:match[7]]
+}
+
+func lrcTimestamp(minutes, seconds, fraction string) int64 {
+	mins, _ := strconv.ParseInt(minutes, 10, 64)
+	secs, _ := strconv.ParseInt(seconds, 10, 64)
+
+	var ms int64
+	if fraction != "" {
+		ms, _ = strconv.ParseInt(fraction, 10, 64)
+		for i := len(fraction); i < 3; i++ {
+			ms *= 10
+		}
+	}
+
+	return (mins*60+secs)*1000 + ms
+}
+
+func formatLRCTimestamp(ms int64) string {
+	return fmt.Sprintf("%02d:%02d.%02d", ms/60000, ms/1000%60, ms%1000/10)
+}
+
+// FormatLRC renders the timed lines of music as LRC, using enhanced word tags where word
+// timings are known. Verses are separated by empty lines.
+func FormatLRC(music *models.Music) string {
+	var builder strings.Builder
+
+	if music.SongName != "" {
+		fmt.Fprintf(&builder, "[ti:%s]\n", music.SongName)
+	}
+	if music.GroupName != "" {
+		fmt.Fprintf(&builder, "[ar:%s]\n", music.GroupName)
+	}
+
+	for i, verse := range music.Verses {
+		if i > 0 {
+			builder.WriteString("\n")
+		}
+		for _, line := range verse.Lines {
+			fmt.Fprintf(&builder, "[%s]", formatLRCTimestamp(line.StartMs))
+			if len(line.Words) == 0 {
+				builder.WriteString(line.Text)
+			} else {
+				for j, word := range line.Words {
+					if j > 0 {
+						builder.WriteString(" ")
+					}
+					fmt.Fprintf(&builder, "<%s>%s", formatLRCTimestamp(word.StartMs), word.Text)
+				}
+			}
+			builder.WriteString("\n")
+		}
+	}
+
+	return builder.String()
+}
@@ -0,0 +1,77 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Seven11Eleven/music_library/internal/config"
+	log "github.com/sirupsen/logrus"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+const defaultLyristBaseURL = "https://lyrist.vercel.app/api"
+
+type lyristProvider struct {
+	baseURL string
+	client  *resilientClient
+}
+
+func (l lyristProvider) Name() string {
+	return "lyrist"
+}
+
+func (l lyristProvider) Status() ProviderStatus {
+	return l.client.status()
+}
+
+func (l lyristProvider) FetchLyrics(ctx context.Context, groupName, musicName string) (string, error) {
+	encodedGroupName := url.QueryEscape(groupName)
+	encodedMusicName := url.QueryEscape(musicName)
+
+	urlLyrics := fmt.Sprintf("%s/%s/%s", l.baseURL, encodedMusicName, encodedGroupName)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, urlLyrics, nil)
+	if err != nil {
+		log.Errorf("Failed to build lyrics request: %v", err)
+		return "", err
+	}
+
+	log.Infof("Fetching lyrics from URL: %s", urlLyrics)
+	resLyrics, err := l.client.Do(req)
+	if err != nil {
+		log.Errorf("Failed to fetch lyrics: %v", err)
+		return "", err
+	}
+	defer resLyrics.Body.Close()
+
+	if resLyrics.StatusCode != http.StatusOK {
+		log.Errorf("Received non-200 response code from lyrics API")
+		return "", fmt.Errorf("received non-200 response code from lyrics API")
+	}
+
+	var lyricsData struct {
+		Lyrics string `json:"lyrics"`
+	}
+
+	log.Info("Parsing lyrics response...")
+	if err := json.NewDecoder(resLyrics.Body).Decode(&lyricsData); err != nil {
+		log.Errorf("Failed to parse lyrics: %v", err)
+		return "", err
+	}
+
+	return lyricsData.Lyrics, nil
+}
+
+func NewLyristProvider(cfg *config.Config, client *http.Client) LyricsProvider {
+	baseURL := cfg.LyristBaseURL
+	if baseURL == "" {
+		baseURL = defaultLyristBaseURL
+	}
+
+	return &lyristProvider{
+		baseURL: strings.TrimRight(baseURL, "/"),
+		client:  newResilientClient("lyrist", cfg, client),
+	}
+}
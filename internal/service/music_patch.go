@@ -0,0 +1,198 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	log "github.com/sirupsen/logrus"
+	"reflect"
+	"strings"
+)
+
+const documentDateLayout = "2006-01-02"
+
+// NewMusicDocument returns the representation of music that patches are applied to.
+func NewMusicDocument(music models.Music) models.MusicDocument {
+	doc := models.MusicDocument{
+		SongName:  music.SongName,
+		GroupName: music.GroupName,
+		Verses:    make([]models.VerseDocument, 0, len(music.Verses)),
+	}
+	if music.ReleaseDate != nil {
+		releaseDate := models.FormatReleaseDate(*music.ReleaseDate, music.ReleaseDatePrecision)
+		doc.ReleaseDate = &releaseDate
+	}
+	if music.Link != "" {
+		link := music.Link
+		doc.Link = &link
+	}
+	positions := map[int]int{}
+	for i, verse := range music.Verses {
+		positions[verse.Number] = i + 1
+	}
+	for _, verse := range music.Verses {
+		verseDoc := models.VerseDocument{
+			Text:         verse.Text,
+			SectionType:  verse.SectionType,
+			SectionLabel: verse.SectionLabel,
+		}
+		if verse.RepeatOf != nil {
+			if position, ok := positions[*verse.RepeatOf]; ok {
+				verseDoc.RepeatOf = &position
+			}
+		}
+		doc.Verses = append(doc.Verses, verseDoc)
+	}
+	return doc
+}
+
+// ApplyMusicPatch applies a merge patch or a JSON patch to doc and returns the patched document.
+func ApplyMusicPatch(doc models.MusicDocument, patchType models.PatchType, patch []byte) (models.MusicDocument, error) {
+	original, err := json.Marshal(doc)
+	if err != nil {
+		return models.MusicDocument{}, err
+	}
+
+	var patched []byte
+	switch patchType {
+	case models.PatchMerge:
+		patched, err = jsonpatch.MergePatch(original, patch)
+	case models.PatchJSON:
+		var ops jsonpatch.Patch
+		ops, err = jsonpatch.DecodePatch(patch)
+		if err == nil {
+			patched, err = ops.Apply(original)
+		}
+	default:
+		log.Warnf("Unsupported patch type %s", patchType)
+		return models.MusicDocument{}, fmt.Errorf("%w: %s", models.ErrUnsupportedPatch, patchType)
+	}
+	if err != nil {
+		log.Warnf("Failed to apply patch: %v", err)
+		return models.MusicDocument{}, fmt.Errorf("%w: %v", models.ErrInvalidPatch, err)
+	}
+
+	// Поля, которых нет в документе, не могут быть изменены патчем
+	var result models.MusicDocument
+	decoder := json.NewDecoder(bytes.NewReader(patched))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&result); err != nil {
+		log.Warnf("Patched music is not a valid document: %v", err)
+		return models.MusicDocument{}, fmt.Errorf("%w: %v", models.ErrInvalidPatch, err)
+	}
+	return result, nil
+}
+
+// NewMusicPatch validates a patched document and turns it into the update of the song.
+// The verses are only replaced if they differ from the ones in original.
+func NewMusicPatch(original, patched models.MusicDocument) (models.MusicPatch, error) {
+	patch := models.MusicPatch{
+		SongName:  strings.TrimSpace(patched.SongName),
+		GroupName: strings.TrimSpace(patched.GroupName),
+	}
+	if patch.SongName == "" || patch.GroupName == "" {
+		return models.MusicPatch{}, fmt.Errorf("%w: song_name and group_name are required", models.ErrInvalidPatch)
+	}
+
+	if patched.ReleaseDate != nil {
+		releaseDate, precision, err := models.ParseReleaseDate(*patched.ReleaseDate)
+		if err != nil {
+			return models.MusicPatch{}, fmt.Errorf("%w: %v", models.ErrInvalidPatch, err)
+		}
+		patch.ReleaseDate = &releaseDate
+		patch.ReleaseDatePrecision = precision
+	}
+	if patched.Link != nil && strings.TrimSpace(*patched.Link) != "" {
+		link := strings.TrimSpace(*patched.Link)
+		patch.Link = &link
+	}
+
+	if !reflect.DeepEqual(original.Verses, patched.Verses) {
+		patch.ReplaceVerses = true
+		for i, verse := range patched.Verses {
+			if strings.TrimSpace(verse.Text) == "" {
+				return models.MusicPatch{}, fmt.Errorf("%w: text of verse %d is empty", models.ErrInvalidPatch, i+1)
+			}
+			if verse.RepeatOf != nil && (*verse.RepeatOf < 1 || *verse.RepeatOf > i) {
+				return models.MusicPatch{}, fmt.Errorf("%w: verse %d can only repeat an earlier verse", models.ErrInvalidPatch, i+1)
+			}
+			sectionType := verse.SectionType
+			if sectionType == "" {
+				sectionType = models.SectionVerse
+			}
+			patch.Verses = append(patch.Verses, models.Verse{
+				Text:         verse.Text,
+				Number:       i + 1,
+				SectionType:  sectionType,
+				SectionLabel: verse.SectionLabel,
+				RepeatOf:     verse.RepeatOf,
+			})
+		}
+	}
+
+	// Патч не должен обходить правила, по которым проверяется тело PUT
+	updated := models.Music{
+		SongName:             patch.SongName,
+		GroupName:            patch.GroupName,
+		ReleaseDate:          patch.ReleaseDate,
+		ReleaseDatePrecision: patch.ReleaseDatePrecision,
+		Verses:               patch.Verses,
+	}
+	if patch.Link != nil {
+		updated.Link = *patch.Link
+	}
+	if err := ValidateRequest(&updated); err != nil {
+		return models.MusicPatch{}, fmt.Errorf("%w: %w", models.ErrInvalidPatch, err)
+	}
+	return patch, nil
+}
+
+// PatchMusic applies an RFC 7396 merge patch or an RFC 6902 JSON patch to a song. Unlike UpdateMusic,
+// a patch can clear the release date and the link and replace the whole list of verses.
+func (m musicService) PatchMusic(ctx context.Context, musicID string, patchType models.PatchType, patch []byte, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Patching music %s with %s", musicID, patchType)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	current, err := m.musicRepository.GetTimedLyrics(ctx, musicID)
+	if err != nil {
+		log.Errorf("Error fetching music %s: %v", musicID, err)
+		return nil, err
+	}
+	if current == nil {
+		log.Warnf("Music %s not found", musicID)
+		return nil, nil
+	}
+
+	original := NewMusicDocument(*current)
+	patched, err := ApplyMusicPatch(original, patchType, patch)
+	if err != nil {
+		return nil, err
+	}
+	update, err := NewMusicPatch(original, patched)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	// The patch was applied to the version just read, so a change made since then must not be overwritten
+	if expectedVersion == nil {
+		expectedVersion = &current.Version
+	}
+
+	res, err := m.musicRepository.PatchMusic(ctx, musicID, update, expectedVersion)
+	if err != nil {
+		log.Errorf("Error patching music %s: %v", musicID, err)
+		return nil, err
+	}
+
+	log.Infof("Music %s patched", musicID)
+	return res, nil
+}
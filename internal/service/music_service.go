@@ -2,61 +2,65 @@ package service
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"github.com/Seven11Eleven/music_library/internal/domain/models"
 	log "github.com/sirupsen/logrus"
-	"net/url"
 	"strings"
 	"time"
 )
 
+const maxDuplicateCandidates = 5
+
 type musicService struct {
 	musicRepository       models.MusicRepository
 	dataEnrichmentService DataEnrichmentService
+	artistRepository      models.ArtistRepository
+	similarityThreshold   float64
+	duplicateThreshold    float64
+	trashRetentionDays    int
 }
 
-func ValidateMusicName(musicName string) error {
-	if musicName == "" {
-		log.Warn("Validation failed: music song name is empty")
-		return errors.New("music song name is required")
+type MusicServiceOption func(*musicService)
+
+// WithSimilarityThreshold makes SaveMusic refuse songs whose title and group have at least this
+// trigram similarity to an existing song, unless the query is forced. A threshold of zero disables the check.
+func WithSimilarityThreshold(threshold float64) MusicServiceOption {
+	return func(m *musicService) {
+		m.similarityThreshold = threshold
 	}
-	return nil
 }
 
-func ValidateMusicFilters(musicFilters models.MusicFilters) error {
-	if musicFilters.ReleaseDate != nil && musicFilters.ReleaseDate.After(time.Now()) {
-		log.Warnf("Validation failed: release date %v is in the future", musicFilters.ReleaseDate)
-		return fmt.Errorf("release date cannot be in the future")
-	}
-	if musicFilters.Link != nil {
-		_, err := url.ParseRequestURI(*musicFilters.Link)
-		if err != nil {
-			log.Warnf("Validation failed: music link %s is invalid", *musicFilters.Link)
-			return fmt.Errorf("music link is invalid")
+// narrowReleaseDates turns the year and the decade of filters into the bounds of the release date,
+// keeping the narrowest of them.
+func narrowReleaseDates(filters models.MusicFilters) models.MusicFilters {
+	narrow := func(from, to time.Time) {
+		if filters.ReleaseDateFrom == nil || filters.ReleaseDateFrom.Before(from) {
+			filters.ReleaseDateFrom = &from
+		}
+		if filters.ReleaseDateTo == nil || filters.ReleaseDateTo.After(to) {
+			filters.ReleaseDateTo = &to
 		}
 	}
 
-	if musicFilters.SongName != nil && len(*musicFilters.SongName) > 255 {
-		log.Warnf("Validation failed: song name %s is too long", *musicFilters.SongName)
-		return fmt.Errorf("song name must be shorter than 255 characters")
+	if filters.Year != nil {
+		from := time.Date(*filters.Year, time.January, 1, 0, 0, 0, 0, time.UTC)
+		narrow(from, models.ReleaseDateEnd(from, models.PrecisionYear))
 	}
-	if musicFilters.GroupName != nil && len(*musicFilters.GroupName) > 255 {
-		log.Warnf("Validation failed: group name %s is too long", *musicFilters.GroupName)
-		return fmt.Errorf("group name must be shorter than 255 characters")
+	if filters.Decade != nil {
+		from := time.Date(*filters.Decade, time.January, 1, 0, 0, 0, 0, time.UTC)
+		narrow(from, from.AddDate(10, 0, -1))
 	}
-
-	return nil
+	return filters
 }
 
 func ValidatePagination(limit, offset int) error {
 	if limit <= 0 {
 		log.Warn("Validation failed: limit must be greater than zero")
-		return fmt.Errorf("limit must be greater than zero")
+		return models.NewValidationError("limit", "limit must be greater than zero")
 	}
 	if offset < 0 {
 		log.Warn("Validation failed: offset must be greater or equal to zero")
-		return fmt.Errorf("offset must be greater or equal to zero")
+		return models.NewValidationError("offset", "offset must be greater or equal to zero")
 	}
 	return nil
 }
@@ -64,7 +68,7 @@ func ValidatePagination(limit, offset int) error {
 func ValidateMusicID(musicID string) error {
 	if musicID == "" {
 		log.Warn("Validation failed: music song id is empty")
-		return errors.New("music song id is required")
+		return models.NewValidationError("id", "music song id is required")
 	}
 	return nil
 }
@@ -85,11 +89,49 @@ func (m musicService) SaveMusic(ctx context.Context, music *models.MusicQuery) (
 
 	log.Infof("Saving new music: %s by %s", music.SongName, music.GroupName)
 
-	err := ValidateMusicName(music.SongName)
+	err := ValidateArtistCredits(music.Artists)
 	if err != nil {
 		log.Warnf("Validation failed: %v", err)
 		return nil, err
 	}
+
+	var credits []models.MusicArtist
+	if music.ArtistID != "" {
+		if m.artistRepository == nil {
+			return nil, models.NewValidationError("artist_id", "saving music by artist id is not supported")
+		}
+		artist, err := m.artistRepository.GetArtist(ctx, music.ArtistID)
+		if err != nil {
+			log.Errorf("Error fetching artist %s: %v", music.ArtistID, err)
+			return nil, err
+		}
+		if artist == nil {
+			log.Warnf("Validation failed: artist %s not found", music.ArtistID)
+			return nil, models.NewFieldError("artist_id", fmt.Errorf("artist %s not found", music.ArtistID))
+		}
+		music.GroupName = strings.ToLower(artist.Name)
+		credits = []models.MusicArtist{{ArtistID: music.ArtistID, Name: music.GroupName, Role: models.RolePrimary}}
+	} else {
+		credits = ParseArtistCredits(music.GroupName, m.isKnownArtist(ctx))
+	}
+	for _, credit := range music.Artists {
+		credit.Name = strings.ToLower(strings.TrimSpace(credit.Name))
+		credits = append(credits, credit)
+	}
+
+	// Песня хранится под первым основным исполнителем, у сервиса метаданных ищем по всем основным
+	var primaryNames []string
+	for _, credit := range credits {
+		if credit.Role == models.RolePrimary && credit.Name != "" {
+			primaryNames = append(primaryNames, credit.Name)
+		}
+	}
+	enrichmentGroupName := music.GroupName
+	if len(primaryNames) > 0 {
+		music.GroupName = primaryNames[0]
+		enrichmentGroupName = strings.Join(primaryNames, " & ")
+	}
+
 	existingMusic, err := m.musicRepository.GetMusic(ctx, music.SongName, music.GroupName)
 	if err != nil {
 		log.Errorf("Error checking if music exists: %v", err)
@@ -101,14 +143,29 @@ func (m musicService) SaveMusic(ctx context.Context, music *models.MusicQuery) (
 		return existingMusic, nil
 	}
 
-	enrichedMusic, err := m.dataEnrichmentService.FetchEnrichedMusic(ctx, music.GroupName, music.SongName)
+	if m.similarityThreshold > 0 && !music.Force {
+		candidates, err := m.musicRepository.FindSimilarMusic(ctx, music.SongName, music.GroupName, m.similarityThreshold, maxDuplicateCandidates)
+		if err != nil {
+			log.Errorf("Error looking for similar music: %v", err)
+			return nil, err
+		}
+		if len(candidates) > 0 {
+			log.Warnf("Found %d possible duplicates of %s by %s", len(candidates), music.SongName, music.GroupName)
+			return nil, &models.DuplicatesError{Candidates: candidates}
+		}
+	}
+
+	enrichedMusic, err := m.dataEnrichmentService.FetchEnrichedMusic(ctx, enrichmentGroupName, music.SongName)
 	if err != nil {
 		log.Errorf("Error during data enrichment: %v", err)
 		return nil, err
 	}
 
 	enrichedMusic.SongName = strings.ToLower(enrichedMusic.SongName)
-	enrichedMusic.GroupName = strings.ToLower(enrichedMusic.GroupName)
+	enrichedMusic.GroupName = music.GroupName
+	enrichedMusic.ArtistID = music.ArtistID
+	enrichedMusic.Artists = credits
+	enrichedMusic.Tags = NormalizeTags(enrichedMusic.Tags)
 
 	res, err := m.musicRepository.SaveMusic(ctx, enrichedMusic)
 	if err != nil {
@@ -120,16 +177,67 @@ func (m musicService) SaveMusic(ctx context.Context, music *models.MusicQuery) (
 	return res, nil
 }
 
-func (m musicService) GetMusicsByFilters(ctx context.Context, filters models.MusicFilters, page, pageSize int) ([]models.Music, error) {
+func (m musicService) GetMusicsByFilters(ctx context.Context, filters models.MusicFilters, page, pageSize int) (*models.Page[models.Music], error) {
 	log.Infof("Fetching music list with filters: %+v", filters)
 
-	err := ValidateMusicFilters(filters)
+	filters.Tags = NormalizeTags(filters.Tags)
+	if filters.TagMode == "" {
+		filters.TagMode = models.TagModeAll
+	}
+
+	filters = narrowReleaseDates(filters)
+
+	page, pageSize, err := NormalizePagination(page, pageSize)
+	if err != nil {
+		log.Warnf("Pagination validation failed: %v", err)
+		return nil, err
+	}
+
+	err = checkContext(ctx)
+	if err != nil {
+		log.Warnf("Context error: %v", err)
+		return nil, err
+	}
+
+	items, err := m.musicRepository.GetMusicsByFilters(ctx, filters, page, pageSize)
+	if err != nil {
+		log.Errorf("Error fetching music list: %v", err)
+		return nil, err
+	}
+
+	res, err := newPage(items, page, pageSize, func() (int, error) {
+		return m.musicRepository.CountMusicsByFilters(ctx, filters)
+	})
 	if err != nil {
-		log.Warnf("Validation failed: %v", err)
 		return nil, err
 	}
+	log.Infof("Successfully fetched %d music records", len(res.Items))
+	return res, nil
+}
+
+// GetMusicsByCursor returns the songs matching filters that follow cursor in the order of filters.Sort.
+// Unlike pages, cursors stay stable when songs are added or deleted between requests.
+func (m musicService) GetMusicsByCursor(ctx context.Context, filters models.MusicFilters, cursor string, pageSize int) (*models.CursorPage[models.Music], error) {
+	log.Infof("Fetching music list after cursor %q with filters: %+v", cursor, filters)
+
+	filters.Tags = NormalizeTags(filters.Tags)
+	if filters.TagMode == "" {
+		filters.TagMode = models.TagModeAll
+	}
 
-	err = ValidatePagination(page, pageSize)
+	filters = narrowReleaseDates(filters)
+
+	after, err := DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	if cursor != "" {
+		err = validateMusicCursor(after, filters.Sort)
+		if err != nil {
+			return nil, err
+		}
+	}
+	_, pageSize, err = NormalizePagination(1, pageSize)
 	if err != nil {
 		log.Warnf("Pagination validation failed: %v", err)
 		return nil, err
@@ -141,16 +249,37 @@ func (m musicService) GetMusicsByFilters(ctx context.Context, filters models.Mus
 		return nil, err
 	}
 
-	res, err := m.musicRepository.GetMusicsByFilters(ctx, filters, page, pageSize)
+	items, err := m.musicRepository.GetMusicsAfter(ctx, filters, after, pageSize+1)
 	if err != nil {
 		log.Errorf("Error fetching music list: %v", err)
 		return nil, err
 	}
-	log.Infof("Successfully fetched %d music records", len(res))
+
+	res := newCursorPage(items, pageSize, func(music models.Music) models.Cursor {
+		return musicCursor(music, filters.Sort)
+	})
+	log.Infof("Successfully fetched %d music records", len(res.Items))
+	return res, nil
+}
+
+func (m musicService) GetMusicByID(ctx context.Context, musicID string, withVerses bool) (*models.Music, error) {
+	log.Infof("Fetching music with ID: %s", musicID)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	res, err := m.musicRepository.GetMusicByID(ctx, musicID, withVerses)
+	if err != nil {
+		log.Errorf("Error fetching music with ID %s: %v", musicID, err)
+		return nil, err
+	}
 	return res, nil
 }
 
-func (m musicService) GetMusicTextWithPaginationByVerse(ctx context.Context, musicID string, limit, offset int) (*models.Music, error) {
+func (m musicService) GetMusicTextWithPaginationByVerse(ctx context.Context, musicID string, filters models.VerseFilters, limit, offset int) (*models.Music, error) {
 	log.Infof("Fetching verses for music ID: %s with pagination limit %d, offset %d", musicID, limit, offset)
 
 	err := ValidateMusicID(musicID)
@@ -171,7 +300,7 @@ func (m musicService) GetMusicTextWithPaginationByVerse(ctx context.Context, mus
 		return nil, err
 	}
 
-	res, err := m.musicRepository.GetMusicTextWithPaginationByVerse(ctx, musicID, limit, offset)
+	res, err := m.musicRepository.GetMusicTextWithPaginationByVerse(ctx, musicID, filters, limit, offset)
 	if err != nil {
 		log.Errorf("Error fetching verses for music ID %s: %v", musicID, err)
 		return nil, err
@@ -181,7 +310,53 @@ func (m musicService) GetMusicTextWithPaginationByVerse(ctx context.Context, mus
 	return res, nil
 }
 
-func (m musicService) DeleteMusic(ctx context.Context, musicID string) error {
+// GetVersesByCursor returns the song with its verses that follow cursor in the order of their numbers.
+func (m musicService) GetVersesByCursor(ctx context.Context, musicID string, filters models.VerseFilters, cursor string, pageSize int) (*models.VersePage, error) {
+	log.Infof("Fetching verses for music ID: %s after cursor %q", musicID, cursor)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	if filters.AtMs != nil {
+		log.Warn("Validation failed: playback offset cannot be combined with a cursor")
+		return nil, models.NewFieldError("cursor", fmt.Errorf("%w: at_ms cannot be combined with a cursor", models.ErrInvalidCursor))
+	}
+
+	after, err := DecodeCursor(cursor)
+	if err != nil {
+		return nil, err
+	}
+	_, pageSize, err = NormalizePagination(1, pageSize)
+	if err != nil {
+		log.Warnf("Pagination validation failed: %v", err)
+		return nil, err
+	}
+
+	err = checkContext(ctx)
+	if err != nil {
+		log.Warnf("Context error: %v", err)
+		return nil, err
+	}
+
+	music, err := m.musicRepository.GetVersesAfter(ctx, musicID, filters, after.ID, pageSize+1)
+	if err != nil {
+		log.Errorf("Error fetching verses for music ID %s: %v", musicID, err)
+		return nil, err
+	}
+
+	verses := newCursorPage(music.Verses, pageSize, func(verse models.Verse) models.Cursor {
+		return models.Cursor{ID: verse.Number}
+	})
+	music.Verses = verses.Items
+
+	log.Infof("Successfully fetched %d verses for music ID %s", len(music.Verses), musicID)
+	return &models.VersePage{Music: music, PageSize: pageSize, NextCursor: verses.NextCursor}, nil
+}
+
+func (m musicService) DeleteMusic(ctx context.Context, musicID string, expectedVersion *int) error {
 	log.Infof("Deleting music with ID: %s", musicID)
 
 	err := ValidateMusicID(musicID)
@@ -190,7 +365,7 @@ func (m musicService) DeleteMusic(ctx context.Context, musicID string) error {
 		return err
 	}
 
-	err = m.musicRepository.DeleteMusic(ctx, musicID)
+	err = m.musicRepository.DeleteMusic(ctx, musicID, expectedVersion)
 	if err != nil {
 		log.Errorf("Error deleting music with ID %s: %v", musicID, err)
 		return err
@@ -200,7 +375,7 @@ func (m musicService) DeleteMusic(ctx context.Context, musicID string) error {
 	return nil
 }
 
-func (m musicService) UpdateMusic(ctx context.Context, music models.Music) (models.Music, error) {
+func (m musicService) UpdateMusic(ctx context.Context, music models.Music, expectedVersion *int) (models.Music, error) {
 	log.Infof("Updating music with ID: %s", music.ID)
 
 	err := ValidateMusicID(music.ID)
@@ -209,7 +384,7 @@ func (m musicService) UpdateMusic(ctx context.Context, music models.Music) (mode
 		return models.Music{}, err
 	}
 
-	res, err := m.musicRepository.UpdateMusic(ctx, music)
+	res, err := m.musicRepository.UpdateMusic(ctx, music, expectedVersion)
 	if err != nil {
 		log.Errorf("Error updating music with ID %s: %v", music.ID, err)
 		return models.Music{}, err
@@ -219,13 +394,152 @@ func (m musicService) UpdateMusic(ctx context.Context, music models.Music) (mode
 	return res, nil
 }
 
+func (m musicService) ImportLRC(ctx context.Context, musicID string, lrc string, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Importing LRC for music ID: %s", musicID)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	doc, err := ParseLRC(lrc)
+	if err != nil {
+		log.Warnf("Failed to parse LRC: %v", err)
+		return nil, models.NewFieldError("lrc", err)
+	}
+
+	res, err := m.musicRepository.ReplaceVerses(ctx, musicID, doc.Verses, expectedVersion)
+	if err != nil {
+		log.Errorf("Error saving timed lyrics for music ID %s: %v", musicID, err)
+		return nil, err
+	}
+
+	log.Infof("Imported %d timed verses for music ID %s", len(doc.Verses), musicID)
+	return res, nil
+}
+
+func (m musicService) ExportLRC(ctx context.Context, musicID string) (string, error) {
+	log.Infof("Exporting LRC for music ID: %s", musicID)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return "", err
+	}
+
+	music, err := m.musicRepository.GetTimedLyrics(ctx, musicID)
+	if err != nil {
+		log.Errorf("Error fetching timed lyrics for music ID %s: %v", musicID, err)
+		return "", err
+	}
+	if music == nil {
+		return "", nil
+	}
+
+	timed := false
+	for _, verse := range music.Verses {
+		if len(verse.Lines) > 0 {
+			timed = true
+			break
+		}
+	}
+	if !timed {
+		log.Warnf("Music with ID %s has no timed lyrics", musicID)
+		return "", nil
+	}
+
+	return FormatLRC(music), nil
+}
+
+func (m musicService) SearchMusic(ctx context.Context, query string, page, pageSize int) (*models.Page[models.SearchResult], error) {
+	log.Infof("Searching music by lyrics: %s", query)
+
+	query = strings.TrimSpace(query)
+	if query == "" {
+		log.Warn("Validation failed: search query is empty")
+		return nil, models.NewValidationError("q", "search query is required")
+	}
+
+	page, pageSize, err := NormalizePagination(page, pageSize)
+	if err != nil {
+		log.Warnf("Pagination validation failed: %v", err)
+		return nil, err
+	}
+
+	err = checkContext(ctx)
+	if err != nil {
+		log.Warnf("Context error: %v", err)
+		return nil, err
+	}
+
+	items, err := m.musicRepository.SearchMusic(ctx, query, pageSize, (page-1)*pageSize)
+	if err != nil {
+		log.Errorf("Error searching music: %v", err)
+		return nil, err
+	}
+
+	res, err := newPage(items, page, pageSize, func() (int, error) {
+		return m.musicRepository.CountSearchResults(ctx, query)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Search returned %d songs", len(res.Items))
+	return res, nil
+}
+
+// WithArtistRepository lets SaveMusic accept an artist ID instead of a group name and keeps
+// known artists with "&" in their name whole when parsing collaborations.
+func WithArtistRepository(artistRepository models.ArtistRepository) MusicServiceOption {
+	return func(m *musicService) {
+		m.artistRepository = artistRepository
+	}
+}
+
+// isKnownArtist reports whether a name belongs to an artist in the library, so that names
+// like "Simon & Garfunkel" are not split into several artists.
+func (m musicService) isKnownArtist(ctx context.Context) func(name string) bool {
+	if m.artistRepository == nil {
+		return nil
+	}
+	return func(name string) bool {
+		artist, err := m.artistRepository.GetArtistByName(ctx, name)
+		if err != nil {
+			log.Warnf("Error looking up artist %s: %v", name, err)
+			return false
+		}
+		return artist != nil
+	}
+}
+
+// WithDuplicateThreshold sets the minimum combined name and lyrics similarity for a duplicate scan
+// to consider two songs the same track.
+func WithDuplicateThreshold(threshold float64) MusicServiceOption {
+	return func(m *musicService) {
+		m.duplicateThreshold = threshold
+	}
+}
+
 func NewMusicService(
 	musicRepository models.MusicRepository,
 	dataEnrichmentService DataEnrichmentService,
+	opts ...MusicServiceOption,
 ) models.MusicService {
 	log.Info("Creating new music service")
-	return &musicService{
+	service := &musicService{
 		musicRepository:       musicRepository,
 		dataEnrichmentService: dataEnrichmentService,
 	}
+	for _, opt := range opts {
+		opt(service)
+	}
+	if service.duplicateThreshold <= 0 {
+		service.duplicateThreshold = defaultDuplicateThreshold
+	}
+	if service.trashRetentionDays <= 0 {
+		service.trashRetentionDays = defaultTrashRetentionDays
+	}
+	return service
 }
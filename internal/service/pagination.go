@@ -0,0 +1,102 @@
+package service
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	DefaultPageSize = 20
+	MaxPageSize     = 100
+)
+
+// NormalizePagination defaults a zero page to the first one and a zero page size to DefaultPageSize,
+// and caps the page size at MaxPageSize.
+func NormalizePagination(page, pageSize int) (int, int, error) {
+	if page < 0 {
+		log.Warnf("Validation failed: page %d is negative", page)
+		return 0, 0, models.NewValidationError("page", "page must be greater than zero")
+	}
+	if pageSize < 0 {
+		log.Warnf("Validation failed: page size %d is negative", pageSize)
+		return 0, 0, models.NewValidationError("page_size", "page size must be greater than zero")
+	}
+
+	if page == 0 {
+		page = 1
+	}
+	if pageSize == 0 {
+		pageSize = DefaultPageSize
+	}
+	if pageSize > MaxPageSize {
+		log.Debugf("Page size %d capped at %d", pageSize, MaxPageSize)
+		pageSize = MaxPageSize
+	}
+	return page, pageSize, nil
+}
+
+// newPage returns a page of items. The items are only counted with count when their total cannot be
+// told from the page itself, that is when the page is empty or full.
+func newPage[T any](items []T, page, pageSize int, count func() (int, error)) (*models.Page[T], error) {
+	if items == nil {
+		items = []T{}
+	}
+	res := &models.Page[T]{Items: items, Page: page, PageSize: pageSize}
+
+	if len(items) > 0 && len(items) < pageSize {
+		res.Total = (page-1)*pageSize + len(items)
+		return res, nil
+	}
+
+	total, err := count()
+	if err != nil {
+		log.Errorf("Error counting items: %v", err)
+		return nil, err
+	}
+	res.Total = total
+	return res, nil
+}
+
+// EncodeCursor returns the opaque string clients pass back to get the page following cursor.
+func EncodeCursor(cursor models.Cursor) string {
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor parses a cursor returned by EncodeCursor. An empty cursor points before the first item.
+func DecodeCursor(cursor string) (models.Cursor, error) {
+	if cursor == "" {
+		return models.Cursor{}, nil
+	}
+
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		log.Warnf("Validation failed: cursor %s is not base64: %v", cursor, err)
+		return models.Cursor{}, models.NewFieldError("cursor", models.ErrInvalidCursor)
+	}
+	var res models.Cursor
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&res); err != nil || res.ID < 1 {
+		log.Warnf("Validation failed: cursor %s is malformed", cursor)
+		return models.Cursor{}, models.NewFieldError("cursor", models.ErrInvalidCursor)
+	}
+	return res, nil
+}
+
+// newCursorPage returns a page of items fetched with one item more than pageSize; that item is
+// only used to tell whether there is a next page.
+func newCursorPage[T any](items []T, pageSize int, key func(T) models.Cursor) *models.CursorPage[T] {
+	if items == nil {
+		items = []T{}
+	}
+	res := &models.CursorPage[T]{Items: items, PageSize: pageSize}
+	if len(items) > pageSize {
+		res.Items = items[:pageSize]
+		res.NextCursor = EncodeCursor(key(res.Items[pageSize-1]))
+	}
+	return res
+}
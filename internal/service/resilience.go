@@ -0,0 +1,287 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/Seven11Eleven/music_library/internal/config"
+	log "github.com/sirupsen/logrus"
+	"io"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+var ErrCircuitOpen = errors.New("circuit breaker is open")
+
+type BreakerState string
+
+const (
+	BreakerClosed   BreakerState = "closed"
+	BreakerOpen     BreakerState = "open"
+	BreakerHalfOpen BreakerState = "half-open"
+)
+
+// ProviderStatus describes the circuit breaker of a single enrichment provider.
+type ProviderStatus struct {
+	Provider            string       `json:"provider"`
+	Kind                string       `json:"kind"`
+	State               BreakerState `json:"state"`
+	ConsecutiveFailures int          `json:"consecutive_failures"`
+	OpenedAt            *time.Time   `json:"opened_at,omitempty"`
+	RetryAt             *time.Time   `json:"retry_at,omitempty"`
+}
+
+type circuitBreaker struct {
+	mu          sync.Mutex
+	name        string
+	threshold   int
+	openTimeout time.Duration
+	state       BreakerState
+	failures    int
+	openedAt    time.Time
+	probing     bool
+}
+
+func newCircuitBreaker(name string, threshold int, openTimeout time.Duration) *circuitBreaker {
+	return &circuitBreaker{
+		name:        name,
+		threshold:   threshold,
+		openTimeout: openTimeout,
+		state:       BreakerClosed,
+	}
+}
+
+// allow reports whether a call may go upstream. Once the open timeout has passed a single probe is let through.
+func (b *circuitBreaker) allow() error {
+	if b.threshold <= 0 {
+		return nil
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	switch b.state {
+	case BreakerOpen:
+		if time.Since(b.openedAt) < b.openTimeout {
+			return fmt.Errorf("%s: %w", b.name, ErrCircuitOpen)
+		}
+		log.Infof("Circuit breaker for '%s' is half-open, probing upstream", b.name)
+		b.state = BreakerHalfOpen
+		b.probing = true
+		return nil
+	case BreakerHalfOpen:
+		if b.probing {
+			return fmt.Errorf("%s: %w", b.name, ErrCircuitOpen)
+		}
+		b.probing = true
+		return nil
+	default:
+		return nil
+	}
+}
+
+func (b *circuitBreaker) onSuccess() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	if b.state != BreakerClosed {
+		log.Infof("Circuit breaker for '%s' closed", b.name)
+	}
+	b.state = BreakerClosed
+	b.failures = 0
+	b.probing = false
+}
+
+func (b *circuitBreaker) onFailure() {
+	if b.threshold <= 0 {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.failures++
+	b.probing = false
+	if b.state == BreakerHalfOpen || b.failures >= b.threshold {
+		if b.state != BreakerOpen {
+			log.Warnf("Circuit breaker for '%s' opened after %d consecutive failures", b.name, b.failures)
+		}
+		b.state = BreakerOpen
+		b.openedAt = time.Now()
+	}
+}
+
+// abandon releases a half-open probe that was cancelled by the caller without counting it either way.
+func (b *circuitBreaker) abandon() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	b.probing = false
+}
+
+// status reports the state of the breaker. An open breaker whose timeout has passed is reported as
+// half-open, since the next call is let through as a probe.
+func (b *circuitBreaker) status() ProviderStatus {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+
+	status := ProviderStatus{
+		Provider:            b.name,
+		State:               b.state,
+		ConsecutiveFailures: b.failures,
+	}
+	if b.state == BreakerOpen {
+		openedAt := b.openedAt
+		status.OpenedAt = &openedAt
+
+		retryAt := b.openedAt.Add(b.openTimeout)
+		if time.Now().Before(retryAt) {
+			status.RetryAt = &retryAt
+		} else {
+			status.State = BreakerHalfOpen
+		}
+	}
+	return status
+}
+
+// resilientClient retries retryable upstream responses with exponential backoff
+// and keeps a circuit breaker per provider.
+type resilientClient struct {
+	client     *http.Client
+	breaker    *circuitBreaker
+	maxRetries int
+	baseDelay  time.Duration
+	maxDelay   time.Duration
+}
+
+func newResilientClient(name string, cfg *config.Config, client *http.Client) *resilientClient {
+	baseDelay := cfg.EnrichmentRetryBaseDelay
+	if baseDelay <= 0 {
+		baseDelay = 200 * time.Millisecond
+	}
+	maxDelay := cfg.EnrichmentRetryMaxDelay
+	if maxDelay <= 0 {
+		maxDelay = 5 * time.Second
+	}
+	openTimeout := cfg.BreakerOpenTimeout
+	if openTimeout <= 0 {
+		openTimeout = 30 * time.Second
+	}
+
+	return &resilientClient{
+		client:     client,
+		breaker:    newCircuitBreaker(name, cfg.BreakerFailureThreshold, openTimeout),
+		maxRetries: cfg.EnrichmentMaxRetries,
+		baseDelay:  baseDelay,
+		maxDelay:   maxDelay,
+	}
+}
+
+func (r *resilientClient) Do(req *http.Request) (*http.Response, error) {
+	if err := r.breaker.allow(); err != nil {
+		log.Warnf("Skipping request to %s: %v", req.URL.Host, err)
+		return nil, err
+	}
+
+	for attempt := 0; ; attempt++ {
+		res, err := r.client.Do(req)
+
+		if !isRetryable(req.Context(), res, err) {
+			switch {
+			case req.Context().Err() != nil:
+				r.breaker.abandon()
+			case err != nil || res.StatusCode >= http.StatusInternalServerError:
+				r.breaker.onFailure()
+			default:
+				r.breaker.onSuccess()
+			}
+			return res, err
+		}
+
+		delay := r.backoff(attempt, res)
+		if attempt >= r.maxRetries || delay > r.maxDelay {
+			r.breaker.onFailure()
+			return res, err
+		}
+
+		if err != nil {
+			log.Warnf("Request to %s failed, retrying in %s (attempt %d/%d): %v", req.URL.Host, delay, attempt+1, r.maxRetries, err)
+		} else {
+			log.Warnf("Request to %s returned %s, retrying in %s (attempt %d/%d)", req.URL.Host, res.Status, delay, attempt+1, r.maxRetries)
+			io.Copy(io.Discard, res.Body)
+			res.Body.Close()
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			r.breaker.abandon()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (r *resilientClient) status() ProviderStatus {
+	return r.breaker.status()
+}
+
+// backoff returns the delay before the next attempt. A Retry-After header always wins over the exponential delay.
+func (r *resilientClient) backoff(attempt int, res *http.Response) time.Duration {
+	if res != nil {
+		if retryAfter, ok := parseRetryAfter(res.Header.Get("Retry-After")); ok {
+			return retryAfter
+		}
+	}
+
+	delay := r.baseDelay << attempt
+	if delay <= 0 || delay > r.maxDelay {
+		delay = r.maxDelay
+	}
+	return delay
+}
+
+func isRetryable(ctx context.Context, res *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil
+	}
+
+	switch res.StatusCode {
+	case http.StatusRequestTimeout,
+		http.StatusTooManyRequests,
+		http.StatusInternalServerError,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	default:
+		return false
+	}
+}
+
+func parseRetryAfter(value string) (time.Duration, bool) {
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		delay := time.Until(date)
+		if delay < 0 {
+			delay = 0
+		}
+		return delay, true
+	}
+
+	return 0, false
+}
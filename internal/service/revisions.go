@@ -0,0 +1,76 @@
+package service
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func ValidateRevision(revision int) error {
+	if revision < 1 {
+		log.Warnf("Validation failed: revision %d is not positive", revision)
+		return models.NewValidationError("revision", "revision must be greater than zero")
+	}
+	return nil
+}
+
+func (m musicService) GetRevisions(ctx context.Context, musicID string) ([]models.Revision, error) {
+	log.Infof("Fetching revisions of music %s", musicID)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	res, err := m.musicRepository.GetRevisions(ctx, musicID)
+	if err != nil {
+		log.Errorf("Error fetching revisions of music %s: %v", musicID, err)
+		return nil, err
+	}
+
+	log.Infof("Fetched %d revisions of music %s", len(res), musicID)
+	return res, nil
+}
+
+func (m musicService) GetRevision(ctx context.Context, musicID string, revision int) (*models.Revision, error) {
+	log.Infof("Fetching revision %d of music %s", revision, musicID)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+	err = ValidateRevision(revision)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := m.musicRepository.GetRevision(ctx, musicID, revision)
+	if err != nil {
+		log.Errorf("Error fetching revision %d of music %s: %v", revision, musicID, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (m musicService) RestoreRevision(ctx context.Context, musicID string, revision int, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Restoring music %s to revision %d by %q", musicID, revision, models.AuthorFromContext(ctx))
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+	err = ValidateRevision(revision)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := m.musicRepository.RestoreRevision(ctx, musicID, revision, expectedVersion)
+	if err != nil {
+		log.Errorf("Error restoring music %s to revision %d: %v", musicID, revision, err)
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,119 @@
+package service
+
+import (
+	"fmt"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ParseSort parses a comma-separated list of sort fields, each descending if prefixed with a minus,
+// e.g. "-release_date,title".
+func ParseSort(sort string) ([]models.SortKey, error) {
+	if strings.TrimSpace(sort) == "" {
+		return nil, nil
+	}
+
+	var keys []models.SortKey
+	for _, part := range strings.Split(sort, ",") {
+		part = strings.TrimSpace(part)
+		key := models.SortKey{Field: models.SortField(strings.TrimPrefix(part, "-")), Desc: strings.HasPrefix(part, "-")}
+		keys = append(keys, key)
+	}
+	if err := ValidateSort(keys); err != nil {
+		return nil, err
+	}
+	return keys, nil
+}
+
+// FormatSort is the inverse of ParseSort.
+func FormatSort(keys []models.SortKey) string {
+	parts := make([]string, 0, len(keys))
+	for _, key := range keys {
+		part := string(key.Field)
+		if key.Desc {
+			part = "-" + part
+		}
+		parts = append(parts, part)
+	}
+	return strings.Join(parts, ",")
+}
+
+// ValidateSort checks that every key sorts by a known field and that no field is repeated.
+func ValidateSort(keys []models.SortKey) error {
+	seen := make(map[models.SortField]bool, len(keys))
+	for _, key := range keys {
+		known := false
+		for _, field := range models.SortFields {
+			known = known || key.Field == field
+		}
+		if !known {
+			log.Warnf("Validation failed: sort field %q is unknown", key.Field)
+			return models.NewFieldError("sort", fmt.Errorf("%w: unknown field %q, expected one of %v", models.ErrInvalidSort, key.Field, models.SortFields))
+		}
+		if seen[key.Field] {
+			log.Warnf("Validation failed: sort field %s is repeated", key.Field)
+			return models.NewFieldError("sort", fmt.Errorf("%w: field %s is repeated", models.ErrInvalidSort, key.Field))
+		}
+		seen[key.Field] = true
+	}
+	return nil
+}
+
+// musicCursor returns the cursor pointing after music in the list sorted by keys. Songs without
+// a release date are sorted after all others, so they are given an infinite one.
+func musicCursor(music models.Music, keys []models.SortKey) models.Cursor {
+	id, _ := strconv.Atoi(music.ID)
+	cursor := models.Cursor{ID: id, Sort: FormatSort(keys)}
+	for _, key := range keys {
+		var value string
+		switch key.Field {
+		case models.SortReleaseDate:
+			switch {
+			case music.ReleaseDate != nil:
+				value = music.ReleaseDate.Format(documentDateLayout)
+			case key.Desc:
+				value = "-infinity"
+			default:
+				value = "infinity"
+			}
+		case models.SortTitle:
+			value = music.SongName
+		case models.SortGroupName:
+			value = music.GroupName
+		case models.SortCreatedAt:
+			if music.CreatedAt != nil {
+				value = music.CreatedAt.Format(time.RFC3339Nano)
+			}
+		}
+		cursor.Values = append(cursor.Values, value)
+	}
+	return cursor
+}
+
+// validateMusicCursor checks that cursor was created by musicCursor for keys.
+func validateMusicCursor(cursor models.Cursor, keys []models.SortKey) error {
+	if cursor.Sort != FormatSort(keys) || len(cursor.Values) != len(keys) {
+		log.Warnf("Validation failed: cursor was created for sort %q", cursor.Sort)
+		return models.NewFieldError("cursor", fmt.Errorf("%w: cursor was created for another sort", models.ErrInvalidCursor))
+	}
+
+	for i, key := range keys {
+		var err error
+		switch key.Field {
+		case models.SortReleaseDate:
+			if value := cursor.Values[i]; value != "infinity" && value != "-infinity" {
+				_, err = time.Parse(documentDateLayout, value)
+			}
+		case models.SortCreatedAt:
+			_, err = time.Parse(time.RFC3339Nano, cursor.Values[i])
+		}
+		if err != nil {
+			log.Warnf("Validation failed: cursor value %q of %s is malformed", cursor.Values[i], key.Field)
+			return models.NewFieldError("cursor", fmt.Errorf("%w: malformed value of %s", models.ErrInvalidCursor, key.Field))
+		}
+	}
+	return nil
+}
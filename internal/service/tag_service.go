@@ -0,0 +1,222 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+	"strings"
+)
+
+const maxTagLength = 64
+
+type tagService struct {
+	tagRepository models.TagRepository
+}
+
+// NormalizeTags lower-cases tags, collapses their whitespace and drops empty and repeated ones.
+func NormalizeTags(tags []string) []string {
+	var normalized []string
+	seen := map[string]bool{}
+	for _, tag := range tags {
+		tag = strings.Join(strings.Fields(strings.ToLower(tag)), " ")
+		if tag == "" || seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	return normalized
+}
+
+func ValidateTags(tags []string) error {
+	for _, tag := range tags {
+		if len(tag) > maxTagLength {
+			log.Warnf("Validation failed: tag %s is too long", tag)
+			return models.NewFieldError("tags", fmt.Errorf("tag must be shorter than %d characters", maxTagLength))
+		}
+	}
+	return nil
+}
+
+func ValidateGenreName(name string) error {
+	if name == "" {
+		log.Warn("Validation failed: genre name is empty")
+		return models.NewValidationError("name", "genre name is required")
+	}
+	if len(name) > maxTagLength {
+		log.Warnf("Validation failed: genre name %s is too long", name)
+		return models.NewFieldError("name", fmt.Errorf("genre name must be shorter than %d characters", maxTagLength))
+	}
+	return nil
+}
+
+func (t tagService) GetTags(ctx context.Context, page, pageSize int) (*models.Page[models.Tag], error) {
+	log.Info("Fetching tags")
+
+	page, pageSize, err := NormalizePagination(page, pageSize)
+	if err != nil {
+		log.Warnf("Pagination validation failed: %v", err)
+		return nil, err
+	}
+
+	items, err := t.tagRepository.GetTags(ctx, page, pageSize)
+	if err != nil {
+		log.Errorf("Error fetching tags: %v", err)
+		return nil, err
+	}
+
+	res, err := newPage(items, page, pageSize, func() (int, error) {
+		return t.tagRepository.CountTags(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+func (t tagService) GetGenres(ctx context.Context) ([]models.Genre, error) {
+	log.Info("Fetching genres")
+
+	res, err := t.tagRepository.GetGenres(ctx)
+	if err != nil {
+		log.Errorf("Error fetching genres: %v", err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (t tagService) CreateGenre(ctx context.Context, name string) (*models.Genre, error) {
+	name = strings.TrimSpace(name)
+	log.Infof("Creating genre: %s", name)
+
+	err := ValidateGenreName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.tagRepository.CreateGenre(ctx, name)
+	if err != nil {
+		log.Errorf("Error creating genre %s: %v", name, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (t tagService) GetMusicTags(ctx context.Context, musicID string) (*models.MusicTags, error) {
+	log.Infof("Fetching tags of music %s", musicID)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	res, err := t.tagRepository.GetMusicTags(ctx, musicID)
+	if err != nil {
+		log.Errorf("Error fetching tags of music %s: %v", musicID, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (t tagService) AddMusicTags(ctx context.Context, musicID string, tags []string) (*models.MusicTags, error) {
+	log.Infof("Tagging music %s with %v", musicID, tags)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	tags = NormalizeTags(tags)
+	if len(tags) == 0 {
+		log.Warn("Validation failed: no tags given")
+		return nil, models.NewValidationError("tags", "at least one tag is required")
+	}
+	err = ValidateTags(tags)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.tagRepository.AddMusicTags(ctx, musicID, tags)
+	if err != nil {
+		log.Errorf("Error tagging music %s: %v", musicID, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (t tagService) RemoveMusicTag(ctx context.Context, musicID, tag string) (*models.MusicTags, error) {
+	log.Infof("Removing tag %s from music %s", tag, musicID)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	tags := NormalizeTags([]string{tag})
+	if len(tags) == 0 {
+		log.Warn("Validation failed: tag is empty")
+		return nil, models.NewValidationError("tag", "tag is required")
+	}
+
+	res, err := t.tagRepository.RemoveMusicTag(ctx, musicID, tags[0])
+	if err != nil {
+		log.Errorf("Error removing tag %s from music %s: %v", tag, musicID, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (t tagService) AddMusicGenre(ctx context.Context, musicID, genre string) (*models.MusicTags, error) {
+	genre = strings.TrimSpace(genre)
+	log.Infof("Adding music %s to genre %s", musicID, genre)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+	err = ValidateGenreName(genre)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.tagRepository.AddMusicGenre(ctx, musicID, genre)
+	if err != nil {
+		log.Errorf("Error adding music %s to genre %s: %v", musicID, genre, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (t tagService) RemoveMusicGenre(ctx context.Context, musicID, genre string) (*models.MusicTags, error) {
+	genre = strings.TrimSpace(genre)
+	log.Infof("Removing music %s from genre %s", musicID, genre)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+	err = ValidateGenreName(genre)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := t.tagRepository.RemoveMusicGenre(ctx, musicID, genre)
+	if err != nil {
+		log.Errorf("Error removing music %s from genre %s: %v", musicID, genre, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func NewTagService(tagRepository models.TagRepository) models.TagService {
+	log.Info("Creating new tag service")
+	return &tagService{
+		tagRepository: tagRepository,
+	}
+}
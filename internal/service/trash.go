@@ -0,0 +1,73 @@
+package service
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+const defaultTrashRetentionDays = 30
+
+// WithTrashRetention sets after how many days deleted songs are purged from the trash.
+func WithTrashRetention(days int) MusicServiceOption {
+	return func(m *musicService) {
+		m.trashRetentionDays = days
+	}
+}
+
+func (m musicService) GetTrash(ctx context.Context, page, pageSize int) (*models.Page[models.Music], error) {
+	log.Infof("Fetching trash, page %d, page size %d", page, pageSize)
+
+	page, pageSize, err := NormalizePagination(page, pageSize)
+	if err != nil {
+		log.Warnf("Pagination validation failed: %v", err)
+		return nil, err
+	}
+
+	items, err := m.musicRepository.GetTrash(ctx, page, pageSize)
+	if err != nil {
+		log.Errorf("Error fetching trash: %v", err)
+		return nil, err
+	}
+
+	res, err := newPage(items, page, pageSize, func() (int, error) {
+		return m.musicRepository.CountTrash(ctx)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	log.Infof("Fetched %d songs from trash", len(res.Items))
+	return res, nil
+}
+
+func (m musicService) RestoreMusic(ctx context.Context, musicID string) (*models.Music, error) {
+	log.Infof("Restoring music %s from trash", musicID)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	res, err := m.musicRepository.RestoreMusic(ctx, musicID)
+	if err != nil {
+		log.Errorf("Error restoring music %s: %v", musicID, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+// PurgeTrash permanently deletes songs that have been in the trash longer than the retention period.
+func (m musicService) PurgeTrash(ctx context.Context) (int64, error) {
+	deletedBefore := time.Now().AddDate(0, 0, -m.trashRetentionDays)
+	log.Infof("Purging songs deleted more than %d days ago", m.trashRetentionDays)
+
+	purged, err := m.musicRepository.PurgeDeletedMusic(ctx, deletedBefore)
+	if err != nil {
+		log.Errorf("Error purging trash: %v", err)
+		return 0, err
+	}
+	return purged, nil
+}
@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/config"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+	"time"
+)
+
+const defaultTrashPurgeInterval = 24 * time.Hour
+
+// TrashPurger periodically deletes songs that stayed in the trash past the retention period.
+type TrashPurger struct {
+	musicService models.MusicService
+	interval     time.Duration
+}
+
+// Run purges right away and then every interval until ctx is cancelled.
+func (p *TrashPurger) Run(ctx context.Context) {
+	log.Infof("Starting trash purger with interval %s", p.interval)
+
+	ticker := time.NewTicker(p.interval)
+	defer ticker.Stop()
+
+	for {
+		if _, err := p.musicService.PurgeTrash(ctx); err != nil {
+			log.Errorf("Trash purge failed: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			log.Info("Trash purger stopped")
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+func NewTrashPurger(musicService models.MusicService, cfg *config.Config) *TrashPurger {
+	log.Info("Creating new trash purger")
+
+	interval := cfg.TrashPurgeInterval
+	if interval <= 0 {
+		interval = defaultTrashPurgeInterval
+	}
+
+	return &TrashPurger{
+		musicService: musicService,
+		interval:     interval,
+	}
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"errors"
+	"fmt"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/go-playground/validator/v10"
+	log "github.com/sirupsen/logrus"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// validate checks requests against the rules in their validate tags. Fields are named by their JSON
+// names, which are also used in query parameters.
+var validate = newValidator()
+
+func newValidator() *validator.Validate {
+	v := validator.New(validator.WithRequiredStructEnabled())
+	v.RegisterTagNameFunc(func(field reflect.StructField) string {
+		name := strings.Split(field.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			return ""
+		}
+		return name
+	})
+
+	// Дата фильтра по дню выпуска принимается и с нулевым временем
+	v.RegisterAlias("release_day", "datetime=2006-01-02|datetime=2006-01-02T15:04:05Z")
+
+	rules := map[string]validator.Func{
+		"release_date": func(fl validator.FieldLevel) bool {
+			_, _, err := models.ParseReleaseDate(fl.Field().String())
+			return err == nil
+		},
+		"decade": func(fl validator.FieldLevel) bool {
+			_, err := ParseDecade(fl.Field().String())
+			return err == nil
+		},
+		"sort": func(fl validator.FieldLevel) bool {
+			_, err := ParseSort(fl.Field().String())
+			return err == nil
+		},
+	}
+	for tag, rule := range rules {
+		if err := v.RegisterValidation(tag, rule); err != nil {
+			log.Fatalf("Failed to register validation rule %s: %v", tag, err)
+		}
+	}
+	v.RegisterStructValidation(validateMusicListParams, models.MusicListParams{})
+	return v
+}
+
+// validateMusicListParams checks the rules that depend on several parameters of the music list or
+// on the current time. Parameters in invalid formats are already reported by their own rules.
+func validateMusicListParams(sl validator.StructLevel) {
+	params := sl.Current().Interface().(models.MusicListParams)
+
+	if releaseDate, err := parseReleaseDay(params.ReleaseDate); err == nil && releaseDate.After(time.Now()) {
+		sl.ReportError(params.ReleaseDate, "release_date", "ReleaseDate", "past", "")
+	}
+
+	from, _, fromErr := models.ParseReleaseDate(params.ReleaseDateFrom)
+	to, precision, toErr := models.ParseReleaseDate(params.ReleaseDateTo)
+	if fromErr == nil && toErr == nil && from.After(models.ReleaseDateEnd(to, precision)) {
+		sl.ReportError(params.ReleaseDateFrom, "release_date_from", "ReleaseDateFrom", "release_range", "")
+	}
+}
+
+// parseReleaseDay parses the release date filter, which may be given with a zero time.
+func parseReleaseDay(s string) (time.Time, error) {
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Parse("2006-01-02T15:04:05Z", s)
+	}
+	return t, nil
+}
+
+// ParseDecade parses a decade given as its first year, e.g. 1990 or 1990s.
+func ParseDecade(decade string) (int, error) {
+	res, err := strconv.Atoi(strings.TrimSuffix(decade, "s"))
+	if err != nil || res < 0 || res > 9990 || res%10 != 0 {
+		return 0, models.NewValidationError("decade", "decade must be a year divisible by 10, e.g. 1990")
+	}
+	return res, nil
+}
+
+// ValidateRequest checks a request against the rules in its validate tags and reports all violations
+// together.
+func ValidateRequest(req any) error {
+	err := validate.Struct(req)
+	var violations validator.ValidationErrors
+	if !errors.As(err, &violations) {
+		return err
+	}
+
+	fields := make([]models.FieldError, 0, len(violations))
+	for _, violation := range violations {
+		fields = append(fields, models.FieldError{Field: fieldPath(violation), Message: fieldMessage(violation)})
+	}
+	log.Warnf("Validation failed: %v", fields)
+	return models.NewValidationErrors(fields)
+}
+
+// fieldPath returns the path of an invalid field within the request, e.g. verses[2].text.
+func fieldPath(violation validator.FieldError) string {
+	namespace := violation.Namespace()
+	if i := strings.Index(namespace, "."); i >= 0 {
+		return namespace[i+1:]
+	}
+	return namespace
+}
+
+func fieldMessage(violation validator.FieldError) string {
+	field := fieldPath(violation)
+	param := violation.Param()
+	kind := violation.Kind()
+
+	switch violation.Tag() {
+	case "required", "required_without":
+		return field + " is required"
+	case "max", "min":
+		bound := "at most"
+		if violation.Tag() == "min" {
+			bound = "at least"
+		}
+		switch kind {
+		case reflect.String:
+			return fmt.Sprintf("%s must be %s %s characters long", field, bound, param)
+		case reflect.Slice:
+			return fmt.Sprintf("%s must have %s %s items", field, bound, param)
+		default:
+			return fmt.Sprintf("%s must be %s %s", field, bound, param)
+		}
+	case "lte":
+		if violation.Type() == reflect.TypeOf(time.Time{}) {
+			return field + " cannot be in the future"
+		}
+		return fmt.Sprintf("%s must be at most %s", field, param)
+	case "oneof":
+		return fmt.Sprintf("%s must be one of %s", field, strings.Join(strings.Fields(param), ", "))
+	case "past":
+		return field + " cannot be in the future"
+	case "release_range":
+		return field + " must not be after release_date_to"
+	case "url":
+		return field + " must be a valid URL"
+	case "datetime", "release_day":
+		return field + " must be a date in format YYYY-MM-DD"
+	case "release_date":
+		return field + " must be formatted as YYYY, YYYY-MM or YYYY-MM-DD"
+	case "decade":
+		return field + " must be a year divisible by 10, e.g. 1990 or 1990s"
+	case "sort":
+		if _, err := ParseSort(fmt.Sprint(violation.Value())); err != nil {
+			return fmt.Sprintf("%s: %v", field, err)
+		}
+	}
+	return field + " is invalid"
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+)
+
+func ValidateVerseNumber(number int) error {
+	if number < 1 {
+		log.Warnf("Validation failed: verse number %d is not positive", number)
+		return models.NewValidationError("number", "verse number must be greater than zero")
+	}
+	return nil
+}
+
+// ValidateVerseOrder checks that order lists distinct verse numbers. Whether they are exactly the
+// verses of the song is checked when reordering them.
+func ValidateVerseOrder(order []int) error {
+	if len(order) == 0 {
+		log.Warn("Validation failed: verse order is empty")
+		return models.NewFieldError("order", models.ErrInvalidVerseOrder)
+	}
+
+	seen := make(map[int]bool, len(order))
+	for _, number := range order {
+		if number < 1 || seen[number] {
+			log.Warnf("Validation failed: verse order %v has invalid or repeated number %d", order, number)
+			return models.NewFieldError("order", models.ErrInvalidVerseOrder)
+		}
+		seen[number] = true
+	}
+	return nil
+}
+
+func (m musicService) InsertVerse(ctx context.Context, musicID string, verse models.VerseInsert, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Inserting verse at position %d of music %s", verse.Position, musicID)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+
+	res, err := m.musicRepository.InsertVerse(ctx, musicID, verse, expectedVersion)
+	if err != nil {
+		log.Errorf("Error inserting verse into music %s: %v", musicID, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (m musicService) DeleteVerse(ctx context.Context, musicID string, number int, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Deleting verse %d of music %s", number, musicID)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+	err = ValidateVerseNumber(number)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := m.musicRepository.DeleteVerse(ctx, musicID, number, expectedVersion)
+	if err != nil {
+		log.Errorf("Error deleting verse %d of music %s: %v", number, musicID, err)
+		return nil, err
+	}
+	return res, nil
+}
+
+func (m musicService) ReorderVerses(ctx context.Context, musicID string, order []int, expectedVersion *int) (*models.Music, error) {
+	log.Infof("Reordering verses of music %s", musicID)
+
+	err := ValidateMusicID(musicID)
+	if err != nil {
+		log.Warnf("Validation failed: %v", err)
+		return nil, err
+	}
+	err = ValidateVerseOrder(order)
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := m.musicRepository.ReorderVerses(ctx, musicID, order, expectedVersion)
+	if err != nil {
+		log.Errorf("Error reordering verses of music %s: %v", musicID, err)
+		return nil, err
+	}
+	return res, nil
+}
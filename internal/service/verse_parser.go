@@ -0,0 +1,154 @@
+package service
+
+import (
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	log "github.com/sirupsen/logrus"
+	"regexp"
+	"strings"
+)
+
+var sectionHeaderRegexp = regexp.MustCompile(`^\[([^\[\]]+)\]$`)
+
+// sectionKeywords is checked in order, so more specific keywords must come before the ones they contain.
+var sectionKeywords = []struct {
+	keyword     string
+	sectionType models.SectionType
+}{
+	{"pre-chorus", models.SectionPreChorus},
+	{"pre chorus", models.SectionPreChorus},
+	{"prechorus", models.SectionPreChorus},
+	{"предприпев", models.SectionPreChorus},
+	{"post-chorus", models.SectionHook},
+	{"chorus", models.SectionChorus},
+	{"refrain", models.SectionChorus},
+	{"припев", models.SectionChorus},
+	{"hook", models.SectionHook},
+	{"verse", models.SectionVerse},
+	{"куплет", models.SectionVerse},
+	{"bridge", models.SectionBridge},
+	{"бридж", models.SectionBridge},
+	{"intro", models.SectionIntro},
+	{"вступление", models.SectionIntro},
+	{"интро", models.SectionIntro},
+	{"outro", models.SectionOutro},
+	{"аутро", models.SectionOutro},
+	{"концовка", models.SectionOutro},
+	{"interlude", models.SectionInterlude},
+	{"проигрыш", models.SectionInterlude},
+}
+
+// ParseSectionType maps a section header such as "Chorus", "Verse 2" or "Припев: Artist" to its section type.
+func ParseSectionType(label string) models.SectionType {
+	label = strings.ToLower(label)
+	if i := strings.Index(label, ":"); i >= 0 {
+		label = label[:i]
+	}
+
+	for _, section := range sectionKeywords {
+		if strings.Contains(label, section.keyword) {
+			return section.sectionType
+		}
+	}
+	return models.SectionOther
+}
+
+// parseVerses splits lyrics into verses. A verse ends at a blank line or right before a
+// "[Section]" header line; the header itself stays the first line of the verse text.
+// Sections repeating an earlier section of the same type (or a bare "[Chorus]" header
+// standing for it) are marked as a reference to its first occurrence.
+func parseVerses(songText string) []models.Verse {
+	songText = strings.ReplaceAll(songText, "\r\n", "\n")
+
+	var verses []models.Verse
+	var block []string
+	var label string
+
+	flush := func() {
+		if len(block) == 0 {
+			return
+		}
+		verse := models.Verse{
+			Text:        strings.Join(block, "\n"),
+			Number:      len(verses) + 1,
+			SectionType: models.SectionVerse,
+		}
+		if label != "" {
+			verse.SectionLabel = label
+			verse.SectionType = ParseSectionType(label)
+		}
+		verses = append(verses, verse)
+		block = nil
+		label = ""
+	}
+
+	for _, line := range strings.Split(songText, "\n") {
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			flush()
+			continue
+		}
+
+		if match := sectionHeaderRegexp.FindStringSubmatch(trimmed); match != nil {
+			flush()
+			label = strings.TrimSpace(match[1])
+		}
+		block = append(block, line)
+	}
+	flush()
+
+	markRepeatedSections(verses)
+
+	log.Infof("Parsed %d verses from song text", len(verses))
+	return verses
+}
+
+func markRepeatedSections(verses []models.Verse) {
+	type sectionKey struct {
+		sectionType models.SectionType
+		body        string
+	}
+
+	firstByBody := map[sectionKey]int{}
+	firstByType := map[models.SectionType]int{}
+
+	for i := range verses {
+		verse := &verses[i]
+		body := sectionBody(*verse)
+
+		if body == "" {
+			if first, ok := firstByType[verse.SectionType]; ok && isRefrain(verse.SectionType) {
+				verse.RepeatOf = &first
+			}
+			continue
+		}
+
+		key := sectionKey{sectionType: verse.SectionType, body: body}
+		if first, ok := firstByBody[key]; ok {
+			verse.RepeatOf = &first
+			continue
+		}
+
+		firstByBody[key] = verse.Number
+		if _, ok := firstByType[verse.SectionType]; !ok {
+			firstByType[verse.SectionType] = verse.Number
+		}
+	}
+}
+
+func isRefrain(sectionType models.SectionType) bool {
+	return sectionType == models.SectionChorus || sectionType == models.SectionPreChorus || sectionType == models.SectionHook
+}
+
+// sectionBody is the normalized verse text without its header, used to detect repeats.
+func sectionBody(verse models.Verse) string {
+	lines := strings.Split(verse.Text, "\n")
+	if verse.SectionLabel != "" && len(lines) > 0 {
+		lines = lines[1:]
+	}
+
+	normalized := make([]string, 0, len(lines))
+	for _, line := range lines {
+		normalized = append(normalized, strings.Join(strings.Fields(strings.ToLower(line)), " "))
+	}
+	return strings.TrimSpace(strings.Join(normalized, "\n"))
+}
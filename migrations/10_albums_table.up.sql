@@ -0,0 +1,22 @@
+CREATE TABLE albums(
+    id SERIAL PRIMARY KEY,
+    title VARCHAR(255) NOT NULL,
+    artist_id INT REFERENCES artists(id) ON DELETE RESTRICT,
+    release_date DATE,
+    link TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX albums_artist_title_idx ON albums((COALESCE(artist_id, 0)), (lower(title)));
+
+CREATE TABLE album_tracks(
+    album_id INT NOT NULL REFERENCES albums(id) ON DELETE CASCADE,
+    music_id INT NOT NULL REFERENCES music(id) ON DELETE CASCADE,
+    disc_number INT NOT NULL DEFAULT 1,
+    -- NULL when the position on the album is unknown, e.g. for tracks added by enrichment without one.
+    track_number INT,
+    PRIMARY KEY (album_id, music_id)
+);
+
+CREATE UNIQUE INDEX album_tracks_position_idx ON album_tracks(album_id, disc_number, track_number);
+CREATE INDEX album_tracks_music_idx ON album_tracks(music_id);
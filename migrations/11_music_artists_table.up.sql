@@ -0,0 +1,13 @@
+CREATE TABLE music_artists(
+    music_id INT NOT NULL REFERENCES music(id) ON DELETE CASCADE,
+    artist_id INT NOT NULL REFERENCES artists(id) ON DELETE RESTRICT,
+    role VARCHAR(16) NOT NULL,
+    position INT NOT NULL DEFAULT 0,
+    PRIMARY KEY (music_id, artist_id, role)
+);
+
+CREATE INDEX music_artists_artist_idx ON music_artists(artist_id);
+
+-- music.artist_id stays the primary artist the song is listed under; every credit, including that one, lives here.
+INSERT INTO music_artists (music_id, artist_id, role)
+SELECT id, artist_id, 'primary' FROM music WHERE artist_id IS NOT NULL;
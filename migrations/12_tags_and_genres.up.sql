@@ -0,0 +1,29 @@
+CREATE TABLE genres(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL
+);
+
+CREATE UNIQUE INDEX genres_name_idx ON genres (lower(name));
+
+-- Tag names are stored normalized: trimmed and lower case.
+CREATE TABLE tags(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(64) NOT NULL UNIQUE
+);
+
+CREATE TABLE music_tags(
+    music_id INT NOT NULL REFERENCES music(id) ON DELETE CASCADE,
+    tag_id INT NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    source VARCHAR(16) NOT NULL DEFAULT 'curator',
+    PRIMARY KEY (music_id, tag_id)
+);
+
+CREATE INDEX music_tags_tag_idx ON music_tags(tag_id);
+
+CREATE TABLE music_genres(
+    music_id INT NOT NULL REFERENCES music(id) ON DELETE CASCADE,
+    genre_id INT NOT NULL REFERENCES genres(id) ON DELETE CASCADE,
+    PRIMARY KEY (music_id, genre_id)
+);
+
+CREATE INDEX music_genres_genre_idx ON music_genres(genre_id);
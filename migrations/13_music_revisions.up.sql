@@ -0,0 +1,33 @@
+-- Every change of a song is kept as an immutable revision holding the changed fields and
+-- a snapshot of the song after the change, so that any revision can be restored.
+CREATE TABLE music_revisions(
+    music_id INT NOT NULL REFERENCES music(id) ON DELETE CASCADE,
+    revision INT NOT NULL,
+    author VARCHAR(255),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    restored_from INT,
+    changes JSONB NOT NULL DEFAULT '[]',
+    verse_changes JSONB NOT NULL DEFAULT '[]',
+    snapshot JSONB NOT NULL,
+    PRIMARY KEY (music_id, revision)
+);
+
+-- Existing songs start their history with their current state.
+INSERT INTO music_revisions (music_id, revision, snapshot)
+SELECT
+    m.id,
+    1,
+    jsonb_build_object(
+        'song_name', m.title,
+        'group_name', COALESCE(a.name, ''),
+        'artist_id', COALESCE(m.artist_id::TEXT, ''),
+        'release_date', to_char(m.release_date, 'YYYY-MM-DD"T"00:00:00"Z"'),
+        'link', COALESCE(m.link, ''),
+        'verses', COALESCE((
+            SELECT jsonb_agg(jsonb_build_object('number', v.verse_number, 'text', v.verse_text) ORDER BY v.verse_number)
+            FROM verses v
+            WHERE v.music_id = m.id
+        ), '[]')
+    )
+FROM music m
+LEFT JOIN artists a ON a.id = m.artist_id;
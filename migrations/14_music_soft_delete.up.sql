@@ -0,0 +1,3 @@
+ALTER TABLE music ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX music_deleted_at_idx ON music(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Incremented on every change of a song; exposed as its ETag for optimistic concurrency.
+ALTER TABLE music ADD COLUMN version INT NOT NULL DEFAULT 1;
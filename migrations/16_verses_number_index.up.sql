@@ -0,0 +1,2 @@
+-- Verses are paginated by their number within a song
+CREATE INDEX verses_music_number_idx ON verses(music_id, verse_number);
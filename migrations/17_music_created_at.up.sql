@@ -0,0 +1,4 @@
+-- Songs saved before this migration get the time it was applied
+ALTER TABLE music ADD COLUMN created_at TIMESTAMPTZ NOT NULL DEFAULT now();
+
+CREATE INDEX music_created_at_idx ON music(created_at);
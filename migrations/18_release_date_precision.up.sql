@@ -0,0 +1,5 @@
+-- Release dates known only to the year or the month are stored as the first day of that period
+ALTER TABLE music ADD COLUMN release_date_precision TEXT NOT NULL DEFAULT 'day'
+    CHECK (release_date_precision IN ('year', 'month', 'day'));
+
+CREATE INDEX music_release_date_idx ON music(release_date);
@@ -0,0 +1,17 @@
+CREATE TABLE enrichment_jobs(
+    id SERIAL PRIMARY KEY,
+    status VARCHAR(16) NOT NULL DEFAULT 'pending',
+    song_name VARCHAR(255) NOT NULL,
+    group_name VARCHAR(255) NOT NULL,
+    music_id INT REFERENCES music(id) ON DELETE SET NULL,
+    result JSONB,
+    attempts INT NOT NULL DEFAULT 0,
+    max_attempts INT NOT NULL,
+    last_error TEXT,
+    run_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    locked_until TIMESTAMPTZ,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX enrichment_jobs_queue_idx ON enrichment_jobs(run_at) WHERE status IN ('pending', 'running');
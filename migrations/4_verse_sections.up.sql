@@ -0,0 +1,6 @@
+ALTER TABLE verses
+    ADD COLUMN section_type VARCHAR(32) NOT NULL DEFAULT 'verse',
+    ADD COLUMN section_label VARCHAR(255),
+    ADD COLUMN repeat_of_verse_id INT REFERENCES verses(id) ON DELETE SET NULL;
+
+CREATE INDEX verses_music_section_idx ON verses(music_id, section_type);
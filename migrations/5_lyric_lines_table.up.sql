@@ -0,0 +1,10 @@
+CREATE TABLE lyric_lines(
+    id SERIAL PRIMARY KEY,
+    verse_id INT NOT NULL REFERENCES verses(id) ON DELETE CASCADE,
+    line_number INT NOT NULL,
+    start_ms INT NOT NULL,
+    line_text TEXT NOT NULL,
+    words JSONB
+);
+
+CREATE INDEX lyric_lines_verse_idx ON lyric_lines(verse_id, line_number);
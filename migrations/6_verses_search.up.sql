@@ -0,0 +1,52 @@
+CREATE TABLE search_settings(
+    id BOOLEAN PRIMARY KEY DEFAULT TRUE CHECK (id),
+    languages REGCONFIG[] NOT NULL DEFAULT ARRAY['russian', 'english']::REGCONFIG[]
+);
+
+INSERT INTO search_settings DEFAULT VALUES;
+
+-- Lyrics mix languages, so every verse is indexed with each configured dictionary.
+CREATE FUNCTION lyrics_tsvector(body TEXT) RETURNS TSVECTOR AS $$
+DECLARE
+    lang REGCONFIG;
+    result TSVECTOR := ''::TSVECTOR;
+BEGIN
+    FOR lang IN SELECT unnest(languages) FROM search_settings LOOP
+        result := result || to_tsvector(lang, body);
+    END LOOP;
+    RETURN result;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+CREATE FUNCTION lyrics_tsquery(query TEXT) RETURNS TSQUERY AS $$
+DECLARE
+    lang REGCONFIG;
+    result TSQUERY;
+BEGIN
+    FOR lang IN SELECT unnest(languages) FROM search_settings LOOP
+        IF result IS NULL THEN
+            result := websearch_to_tsquery(lang, query);
+        ELSE
+            result := result || websearch_to_tsquery(lang, query);
+        END IF;
+    END LOOP;
+    RETURN result;
+END;
+$$ LANGUAGE plpgsql STABLE;
+
+ALTER TABLE verses ADD COLUMN search_vector TSVECTOR;
+
+CREATE FUNCTION verses_search_vector_update() RETURNS TRIGGER AS $$
+BEGIN
+    NEW.search_vector := lyrics_tsvector(NEW.verse_text);
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER verses_search_vector_trigger
+    BEFORE INSERT OR UPDATE OF verse_text ON verses
+    FOR EACH ROW EXECUTE FUNCTION verses_search_vector_update();
+
+UPDATE verses SET search_vector = lyrics_tsvector(verse_text);
+
+CREATE INDEX verses_search_idx ON verses USING GIN (search_vector);
@@ -0,0 +1,6 @@
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+CREATE INDEX music_title_trgm_idx ON music USING GIN (title gin_trgm_ops);
+CREATE INDEX music_group_name_trgm_idx ON music USING GIN (group_name gin_trgm_ops);
+
+ALTER TABLE enrichment_jobs ADD COLUMN force BOOLEAN NOT NULL DEFAULT FALSE;
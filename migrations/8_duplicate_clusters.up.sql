@@ -0,0 +1,18 @@
+-- Drops bracketed suffixes such as "(Remastered 2011)" or "[Live]" and punctuation before comparing names.
+CREATE FUNCTION normalize_name(name TEXT) RETURNS TEXT AS $$
+    SELECT btrim(regexp_replace(
+        regexp_replace(lower(name), '\s*[\(\[][^\)\]]*[\)\]]', '', 'g'),
+        '[^[:alnum:]]+', ' ', 'g'
+    ))
+$$ LANGUAGE sql IMMUTABLE;
+
+CREATE INDEX music_normalized_title_trgm_idx ON music USING GIN (normalize_name(title) gin_trgm_ops);
+
+CREATE TABLE duplicate_clusters(
+    id SERIAL PRIMARY KEY,
+    music_ids INT[] NOT NULL,
+    score REAL NOT NULL,
+    detected_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE INDEX duplicate_clusters_music_idx ON duplicate_clusters USING GIN (music_ids);
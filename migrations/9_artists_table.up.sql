@@ -0,0 +1,26 @@
+CREATE TABLE artists(
+    id SERIAL PRIMARY KEY,
+    name VARCHAR(255) NOT NULL,
+    country VARCHAR(64),
+    formed_year INT,
+    description TEXT,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX artists_name_idx ON artists(lower(name));
+CREATE INDEX artists_name_trgm_idx ON artists USING GIN (name gin_trgm_ops);
+
+INSERT INTO artists (name)
+SELECT DISTINCT ON (lower(group_name)) group_name
+FROM music
+WHERE group_name IS NOT NULL AND group_name <> ''
+ORDER BY lower(group_name), group_name;
+
+ALTER TABLE music ADD COLUMN artist_id INT REFERENCES artists(id) ON DELETE RESTRICT;
+
+UPDATE music m SET artist_id = a.id FROM artists a WHERE lower(a.name) = lower(m.group_name);
+
+CREATE INDEX music_artist_idx ON music(artist_id);
+
+ALTER TABLE music DROP COLUMN group_name;
@@ -0,0 +1,65 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestCreateAlbum(t *testing.T) {
+	ctx := context.TODO()
+	mockAlbumRepo := new(mocks.AlbumRepository)
+
+	album := &models.Album{Title: "Mutter", GroupName: "Rammstein"}
+	mockAlbumRepo.On("CreateAlbum", ctx, album).Return(&models.Album{ID: "1", Title: "Mutter", ArtistID: "1", GroupName: "Rammstein"}, nil)
+
+	albumService := service.NewAlbumService(mockAlbumRepo)
+	created, err := albumService.CreateAlbum(ctx, album)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", created.ID)
+
+	mockAlbumRepo.AssertExpectations(t)
+}
+
+func TestCreateAlbum_ValidationFailed(t *testing.T) {
+	mockAlbumRepo := new(mocks.AlbumRepository)
+	albumService := service.NewAlbumService(mockAlbumRepo)
+
+	_, err := albumService.CreateAlbum(context.TODO(), &models.Album{GroupName: "Rammstein"})
+
+	assert.EqualError(t, err, "album title is required")
+	mockAlbumRepo.AssertNotCalled(t, "CreateAlbum", mock.Anything, mock.Anything)
+}
+
+func TestAddTrack_DefaultsToFirstDisc(t *testing.T) {
+	ctx := context.TODO()
+	mockAlbumRepo := new(mocks.AlbumRepository)
+
+	mockAlbumRepo.On("AddTrack", ctx, "1", models.AlbumTrack{MusicID: "5", DiscNumber: 1, TrackNumber: 4}).
+		Return(&models.AlbumTrack{MusicID: "5", SongName: "sonne", DiscNumber: 1, TrackNumber: 4}, nil)
+
+	albumService := service.NewAlbumService(mockAlbumRepo)
+	track, err := albumService.AddTrack(ctx, "1", models.AlbumTrack{MusicID: "5", TrackNumber: 4})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sonne", track.SongName)
+
+	mockAlbumRepo.AssertExpectations(t)
+}
+
+func TestAddTrack_PositionTaken(t *testing.T) {
+	ctx := context.TODO()
+	mockAlbumRepo := new(mocks.AlbumRepository)
+
+	mockAlbumRepo.On("AddTrack", ctx, "1", mock.Anything).Return(nil, models.ErrTrackPositionTaken)
+
+	albumService := service.NewAlbumService(mockAlbumRepo)
+	_, err := albumService.AddTrack(ctx, "1", models.AlbumTrack{MusicID: "6", TrackNumber: 4})
+
+	assert.ErrorIs(t, err, models.ErrTrackPositionTaken)
+}
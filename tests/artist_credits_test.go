@@ -0,0 +1,83 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestParseArtistCredits(t *testing.T) {
+	credits := service.ParseArtistCredits("rammstein & apocalyptica feat. sophie hunger, heppner", nil)
+
+	assert.Equal(t, []models.MusicArtist{
+		{Name: "rammstein", Role: models.RolePrimary},
+		{Name: "apocalyptica", Role: models.RolePrimary},
+		{Name: "sophie hunger", Role: models.RoleFeatured},
+		{Name: "heppner", Role: models.RoleFeatured},
+	}, credits)
+}
+
+func TestParseArtistCredits_Variants(t *testing.T) {
+	assert.Equal(t, []models.MusicArtist{
+		{Name: "daft punk", Role: models.RolePrimary},
+		{Name: "pharrell williams", Role: models.RoleFeatured},
+	}, service.ParseArtistCredits("daft punk (ft. pharrell williams)", nil))
+
+	assert.Equal(t, []models.MusicArtist{
+		{Name: "simon & garfunkel", Role: models.RolePrimary},
+	}, service.ParseArtistCredits("simon & garfunkel", func(name string) bool {
+		return name == "simon & garfunkel"
+	}))
+}
+
+func TestSaveMusic_FeaturedArtists(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockArtistRepo := new(mocks.ArtistRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	enriched := &models.Music{SongName: "mein herz brennt", GroupName: "rammstein & apocalyptica"}
+	mockArtistRepo.On("GetArtistByName", ctx, mock.Anything).Return(nil, nil)
+	mockMusicRepo.On("GetMusic", ctx, "mein herz brennt", "rammstein").Return(nil, nil)
+	mockDataEnrichmentService.On("FetchEnrichedMusic", ctx, "rammstein & apocalyptica", "mein herz brennt").Return(enriched, nil)
+	mockMusicRepo.On("SaveMusic", ctx, mock.MatchedBy(func(music *models.Music) bool {
+		return music.GroupName == "rammstein" && assert.ObjectsAreEqual([]models.MusicArtist{
+			{Name: "rammstein", Role: models.RolePrimary},
+			{Name: "apocalyptica", Role: models.RolePrimary},
+			{Name: "heppner", Role: models.RoleFeatured},
+			{Name: "richard kruspe", Role: models.RoleComposer},
+		}, music.Artists)
+	})).Return(&models.Music{ID: "1", SongName: "mein herz brennt", GroupName: "rammstein"}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService, service.WithArtistRepository(mockArtistRepo))
+	music, err := musicService.SaveMusic(ctx, &models.MusicQuery{
+		SongName:  "Mein Herz Brennt",
+		GroupName: "Rammstein & Apocalyptica feat. Heppner",
+		Artists:   []models.MusicArtist{{Name: "Richard Kruspe", Role: models.RoleComposer}},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", music.ID)
+
+	mockMusicRepo.AssertExpectations(t)
+	mockDataEnrichmentService.AssertExpectations(t)
+}
+
+func TestSaveMusic_UnknownArtistRole(t *testing.T) {
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	_, err := musicService.SaveMusic(context.TODO(), &models.MusicQuery{
+		SongName:  "Sonne",
+		GroupName: "Rammstein",
+		Artists:   []models.MusicArtist{{Name: "Till Lindemann", Role: "singer"}},
+	})
+
+	assert.EqualError(t, err, `unknown artist role "singer"`)
+	mockMusicRepo.AssertNotCalled(t, "GetMusic", mock.Anything, mock.Anything, mock.Anything)
+}
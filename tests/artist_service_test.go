@@ -0,0 +1,90 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestCreateArtist(t *testing.T) {
+	ctx := context.TODO()
+	mockArtistRepo := new(mocks.ArtistRepository)
+
+	artist := &models.Artist{Name: "Rammstein", Country: "DE", FormedYear: intPtr(1994)}
+	mockArtistRepo.On("CreateArtist", ctx, artist).Return(&models.Artist{ID: "1", Name: "Rammstein"}, nil)
+
+	artistService := service.NewArtistService(mockArtistRepo)
+	created, err := artistService.CreateArtist(ctx, artist)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", created.ID)
+
+	mockArtistRepo.AssertExpectations(t)
+}
+
+func TestCreateArtist_ValidationFailed(t *testing.T) {
+	mockArtistRepo := new(mocks.ArtistRepository)
+	artistService := service.NewArtistService(mockArtistRepo)
+
+	_, err := artistService.CreateArtist(context.TODO(), &models.Artist{Name: "Rammstein", FormedYear: intPtr(3000)})
+	assert.EqualError(t, err, "formed year must be between 1000 and the current year")
+
+	_, err = artistService.CreateArtist(context.TODO(), &models.Artist{})
+	assert.EqualError(t, err, "artist name is required")
+
+	mockArtistRepo.AssertNotCalled(t, "CreateArtist", mock.Anything, mock.Anything)
+}
+
+func TestDeleteArtist_InUse(t *testing.T) {
+	ctx := context.TODO()
+	mockArtistRepo := new(mocks.ArtistRepository)
+	mockArtistRepo.On("DeleteArtist", ctx, "1").Return(models.ErrArtistInUse)
+
+	artistService := service.NewArtistService(mockArtistRepo)
+	err := artistService.DeleteArtist(ctx, "1")
+
+	assert.ErrorIs(t, err, models.ErrArtistInUse)
+}
+
+func TestSaveMusic_ByArtistID(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockArtistRepo := new(mocks.ArtistRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	enriched := &models.Music{SongName: "Sonne", GroupName: "rammstein"}
+	mockArtistRepo.On("GetArtist", ctx, "1").Return(&models.Artist{ID: "1", Name: "Rammstein"}, nil)
+	mockMusicRepo.On("GetMusic", ctx, "sonne", "rammstein").Return(nil, nil)
+	mockDataEnrichmentService.On("FetchEnrichedMusic", ctx, "rammstein", "sonne").Return(enriched, nil)
+	mockMusicRepo.On("SaveMusic", ctx, mock.MatchedBy(func(music *models.Music) bool {
+		return music.ArtistID == "1"
+	})).Return(&models.Music{ID: "2", SongName: "sonne", GroupName: "Rammstein", ArtistID: "1"}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService, service.WithArtistRepository(mockArtistRepo))
+	music, err := musicService.SaveMusic(ctx, &models.MusicQuery{SongName: "Sonne", ArtistID: "1"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", music.ArtistID)
+
+	mockMusicRepo.AssertExpectations(t)
+	mockArtistRepo.AssertExpectations(t)
+}
+
+func TestSaveMusic_UnknownArtist(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockArtistRepo := new(mocks.ArtistRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockArtistRepo.On("GetArtist", ctx, "9").Return(nil, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService, service.WithArtistRepository(mockArtistRepo))
+	_, err := musicService.SaveMusic(ctx, &models.MusicQuery{SongName: "Sonne", ArtistID: "9"})
+
+	assert.EqualError(t, err, "artist 9 not found")
+	mockMusicRepo.AssertNotCalled(t, "GetMusic", mock.Anything, mock.Anything, mock.Anything)
+}
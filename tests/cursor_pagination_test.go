@@ -0,0 +1,144 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/api/http/controller"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	cursor := service.EncodeCursor(models.Cursor{ID: 42})
+
+	decoded, err := service.DecodeCursor(cursor)
+	assert.NoError(t, err)
+	assert.Equal(t, 42, decoded.ID)
+
+	decoded, err = service.DecodeCursor("")
+	assert.NoError(t, err)
+	assert.Equal(t, 0, decoded.ID)
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	for _, cursor := range []string{"not a cursor", "e30", service.EncodeCursor(models.Cursor{ID: -1}), "eyJ4IjoxfQ"} {
+		_, err := service.DecodeCursor(cursor)
+		assert.ErrorIs(t, err, models.ErrInvalidCursor, cursor)
+	}
+}
+
+func musicsWithIDs(from, to int) []models.Music {
+	var musics []models.Music
+	for id := from; id <= to; id++ {
+		musics = append(musics, models.Music{ID: strconv.Itoa(id)})
+	}
+	return musics
+}
+
+func TestGetMusicsByCursor_NextCursor(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("GetMusicsAfter", ctx, mock.Anything, models.Cursor{ID: 10}, 4).Return(musicsWithIDs(11, 14), nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.GetMusicsByCursor(ctx, models.MusicFilters{}, service.EncodeCursor(models.Cursor{ID: 10}), 3)
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Items, 3)
+	assert.Equal(t, "13", res.Items[2].ID)
+	assert.Equal(t, service.EncodeCursor(models.Cursor{ID: 13}), res.NextCursor)
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestGetMusicsByCursor_LastPage(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("GetMusicsAfter", ctx, mock.Anything, models.Cursor{}, service.DefaultPageSize+1).Return(musicsWithIDs(1, 2), nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.GetMusicsByCursor(ctx, models.MusicFilters{}, "", 0)
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Items, 2)
+	assert.Equal(t, service.DefaultPageSize, res.PageSize)
+	assert.Empty(t, res.NextCursor)
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestGetMusicsByCursor_InvalidCursor(t *testing.T) {
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	_, err := musicService.GetMusicsByCursor(context.TODO(), models.MusicFilters{}, "garbage!", 10)
+
+	assert.ErrorIs(t, err, models.ErrInvalidCursor)
+	mockMusicRepo.AssertNotCalled(t, "GetMusicsAfter", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestGetVersesByCursor(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	music := &models.Music{ID: "1", Verses: []models.Verse{{Number: 3}, {Number: 4}, {Number: 5}}}
+	mockMusicRepo.On("GetVersesAfter", ctx, "1", models.VerseFilters{}, 2, 3).Return(music, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.GetVersesByCursor(ctx, "1", models.VerseFilters{}, service.EncodeCursor(models.Cursor{ID: 2}), 2)
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Verses, 2)
+	assert.Equal(t, 2, res.PageSize)
+	assert.Equal(t, service.EncodeCursor(models.Cursor{ID: 4}), res.NextCursor)
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestGetVersesByCursor_MusicNotFound(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("GetVersesAfter", ctx, "42", models.VerseFilters{}, 0, 11).Return(nil, models.ErrMusicNotFound)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.GetVersesByCursor(ctx, "42", models.VerseFilters{}, "", 10)
+
+	assert.ErrorIs(t, err, models.ErrMusicNotFound)
+	assert.Nil(t, res)
+}
+
+func TestGetVersesOfMusic_MusicNotFound(t *testing.T) {
+	mockMusicService := mocks.NewMusicService(t)
+	mockMusicService.On("GetMusicTextWithPaginationByVerse", mock.Anything, "42", models.VerseFilters{}, 20, 0).Return(nil, models.ErrMusicNotFound)
+
+	app := fiber.New(fiber.Config{ErrorHandler: controller.ErrorHandler})
+	app.Get("/music/verses", controller.NewMusicController(mockMusicService, nil, 0).GetVersesOfMusic)
+
+	res, err := app.Test(httptest.NewRequest(http.MethodGet, "/music/verses?music_id=42", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Equal(t, "music not found", problemOf(t, res).Detail)
+}
+
+func TestGetVersesByCursor_RejectsPlaybackOffset(t *testing.T) {
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	atMs := int64(1000)
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	_, err := musicService.GetVersesByCursor(context.TODO(), "1", models.VerseFilters{AtMs: &atMs}, "", 10)
+
+	assert.ErrorIs(t, err, models.ErrInvalidCursor)
+}
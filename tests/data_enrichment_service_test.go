@@ -3,11 +3,14 @@ package service_test
 import (
 	"context"
 	"github.com/Seven11Eleven/music_library/internal/config"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
 	"github.com/Seven11Eleven/music_library/internal/service"
 	"github.com/jarcoal/httpmock"
 	"github.com/stretchr/testify/assert"
 	"net/http"
+	"net/http/httptest"
 	"testing"
+	"time"
 )
 
 func TestFetchEnrichedMusic_Success(t *testing.T) {
@@ -49,6 +52,58 @@ func TestFetchEnrichedMusic_Success(t *testing.T) {
 	assert.Equal(t, 1, info["GET https://lyrist.vercel.app/api/Sonne/Rammstein"])
 }
 
+func TestFetchEnrichedMusic_Album(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	dataEnrichmentService := service.NewDataEnrichmentService(&config.Config{APIKey: "test_api_key"})
+
+	httpmock.RegisterResponder("GET", "http://ws.audioscrobbler.com/2.0/?method=track.getInfo&api_key=test_api_key&artist=Rammstein&track=Sonne&format=json",
+		httpmock.NewStringResponder(http.StatusOK, `{
+			"track": {
+				"url": "http://www.example.com",
+				"album": {
+					"artist": "Rammstein",
+					"title": "Mutter",
+					"url": "https://www.last.fm/music/Rammstein/Mutter",
+					"@attr": {"position": "4"}
+				}
+			}
+		}`))
+	httpmock.RegisterResponder("GET", "https://lyrist.vercel.app/api/Sonne/Rammstein",
+		httpmock.NewStringResponder(http.StatusOK, `{"lyrics": "Eins, hier kommt die Sonne"}`))
+
+	music, err := dataEnrichmentService.FetchEnrichedMusic(context.Background(), "Rammstein", "Sonne")
+
+	assert.NoError(t, err)
+	assert.Equal(t, &models.AlbumRef{Title: "Mutter", Link: "https://www.last.fm/music/Rammstein/Mutter", TrackNumber: 4}, music.Album)
+}
+
+func TestFetchEnrichedMusic_Tags(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	dataEnrichmentService := service.NewDataEnrichmentService(&config.Config{APIKey: "test_api_key"})
+
+	httpmock.RegisterResponder("GET", "http://ws.audioscrobbler.com/2.0/?method=track.getInfo&api_key=test_api_key&artist=Rammstein&track=Sonne&format=json",
+		httpmock.NewStringResponder(http.StatusOK, `{
+			"track": {
+				"url": "http://www.example.com",
+				"toptags": {"tag": [
+					{"name": "Industrial Metal", "url": "https://www.last.fm/tag/industrial+metal"},
+					{"name": "german", "url": "https://www.last.fm/tag/german"}
+				]}
+			}
+		}`))
+	httpmock.RegisterResponder("GET", "https://lyrist.vercel.app/api/Sonne/Rammstein",
+		httpmock.NewStringResponder(http.StatusOK, `{"lyrics": "Eins, hier kommt die Sonne"}`))
+
+	music, err := dataEnrichmentService.FetchEnrichedMusic(context.Background(), "Rammstein", "Sonne")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"Industrial Metal", "german"}, music.Tags)
+}
+
 func TestFetchEnrichedMusic_TrackAPIError(t *testing.T) {
 	httpmock.Activate()
 	defer httpmock.DeactivateAndReset()
@@ -130,8 +185,62 @@ func TestFetchEnrichedMusic_NoLyricsFound(t *testing.T) {
 	assert.Error(t, err)
 	assert.Nil(t, music)
 	assert.Equal(t, "no lyrics found for song Sonne by group Rammstein", err.Error())
+	assert.ErrorIs(t, err, models.ErrUpstream)
 
 	info := httpmock.GetCallCountInfo()
 	assert.Equal(t, 1, info["GET http://ws.audioscrobbler.com/2.0/?method=track.getInfo&api_key=test_api_key&artist=Rammstein&track=Sonne&format=json"])
 	assert.Equal(t, 1, info["GET https://lyrist.vercel.app/api/Sonne/Rammstein"])
 }
+
+func TestFetchEnrichedMusic_ConfiguredBaseURLs(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/lastfm/", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "track.getInfo", r.URL.Query().Get("method"))
+		assert.Equal(t, "Rammstein", r.URL.Query().Get("artist"))
+		w.Write([]byte(`{"track": {"url": "http://www.example.com"}}`))
+	})
+	mux.HandleFunc("/lyrics/Sonne/Rammstein", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"lyrics": "first verse\n\nsecond verse"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cfg := &config.Config{
+		APIKey:        "test_api_key",
+		LastFMBaseURL: server.URL + "/lastfm/",
+		LyristBaseURL: server.URL + "/lyrics/",
+	}
+
+	dataEnrichmentService := service.NewDataEnrichmentService(cfg, service.WithHTTPClient(server.Client()))
+
+	music, err := dataEnrichmentService.FetchEnrichedMusic(context.Background(), "Rammstein", "Sonne")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://www.example.com", music.Link)
+	assert.Len(t, music.Verses, 2)
+}
+
+func TestFetchEnrichedMusic_CancelledContext(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+		}
+	}))
+	defer server.Close()
+
+	cfg := &config.Config{
+		LastFMBaseURL: server.URL,
+		LyristBaseURL: server.URL,
+	}
+
+	dataEnrichmentService := service.NewDataEnrichmentService(cfg, service.WithHTTPClient(server.Client()))
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	started := time.Now()
+	music, err := dataEnrichmentService.FetchEnrichedMusic(ctx, "Rammstein", "Sonne")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Nil(t, music)
+	assert.Less(t, time.Since(started), 2*time.Second)
+}
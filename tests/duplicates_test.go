@@ -0,0 +1,103 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/api/http/controller"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func floatPtr(f float64) *float64 {
+	return &f
+}
+
+func TestScanDuplicates(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("FindDuplicatePairs", ctx, 0.6).Return([]models.DuplicatePair{
+		{MusicID: "1", OtherID: "2", TitleSimilarity: 1, GroupSimilarity: 1, LyricsSimilarity: floatPtr(0.9)},
+		{MusicID: "2", OtherID: "10", TitleSimilarity: 0.8, GroupSimilarity: 1},
+		// Same title, different song.
+		{MusicID: "3", OtherID: "4", TitleSimilarity: 1, GroupSimilarity: 0.1, LyricsSimilarity: floatPtr(0.2)},
+	}, nil)
+	mockMusicRepo.On("ReplaceDuplicateClusters", ctx, mock.Anything).Return(nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	clusters, err := musicService.ScanDuplicates(ctx)
+
+	assert.NoError(t, err)
+	assert.Len(t, clusters, 1)
+	assert.Equal(t, []models.Music{{ID: "1"}, {ID: "2"}, {ID: "10"}}, clusters[0].Music)
+	assert.InDelta(t, (0.95+0.9)/2, clusters[0].Score, 1e-9)
+
+	mockMusicRepo.AssertCalled(t, "ReplaceDuplicateClusters", ctx, clusters)
+}
+
+func TestMergeMusic(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("MergeMusic", ctx, "1", []string{"2", "3"}, (*int)(nil)).
+		Return(&models.Music{ID: "1", SongName: "sonne"}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	music, err := musicService.MergeMusic(ctx, "1", []string{"2", "3", "2"}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", music.ID)
+
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestMergeMusic_IntoItself(t *testing.T) {
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	_, err := musicService.MergeMusic(context.TODO(), "1", []string{"2", "1"}, nil)
+
+	assert.EqualError(t, err, "music cannot be merged into itself")
+	mockMusicRepo.AssertNotCalled(t, "MergeMusic", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestMergeMusic_VersionMismatch(t *testing.T) {
+	mockMusicService := mocks.NewMusicService(t)
+	mockMusicService.On("MergeMusic", mock.Anything, "1", []string{"2"}, expectsVersion(3)).Return(nil, models.ErrVersionMismatch)
+
+	app := fiber.New(fiber.Config{ErrorHandler: controller.ErrorHandler})
+	app.Post("/music/:id/merge", controller.NewMusicController(mockMusicService, nil, 0).MergeMusic)
+
+	req := httptest.NewRequest(http.MethodPost, "/music/1/merge", strings.NewReader(`{"merge_ids":["2"]}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderIfMatch, `"3"`)
+	res, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusPreconditionFailed, res.StatusCode)
+}
+
+func TestMergeMusic_RequiresIfMatch(t *testing.T) {
+	mockMusicService := mocks.NewMusicService(t)
+
+	app := fiber.New(fiber.Config{ErrorHandler: controller.ErrorHandler})
+	app.Post("/music/:id/merge", controller.NewMusicController(mockMusicService, nil, 0).MergeMusic)
+
+	req := httptest.NewRequest(http.MethodPost, "/music/1/merge", strings.NewReader(`{"merge_ids":["2"]}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusPreconditionRequired, res.StatusCode)
+	mockMusicService.AssertNotCalled(t, "MergeMusic", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,96 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+type fakeMetadataProvider struct {
+	name     string
+	metadata *service.TrackMetadata
+	err      error
+	calls    int
+}
+
+func (f *fakeMetadataProvider) Name() string {
+	return f.name
+}
+
+func (f *fakeMetadataProvider) FetchMetadata(_ context.Context, _, _ string) (*service.TrackMetadata, error) {
+	f.calls++
+	return f.metadata, f.err
+}
+
+type fakeLyricsProvider struct {
+	name   string
+	lyrics string
+	err    error
+	calls  int
+}
+
+func (f *fakeLyricsProvider) Name() string {
+	return f.name
+}
+
+func (f *fakeLyricsProvider) FetchLyrics(_ context.Context, _, _ string) (string, error) {
+	f.calls++
+	return f.lyrics, f.err
+}
+
+func TestFetchEnrichedMusic_FallsBackToNextProvider(t *testing.T) {
+	failingMetadata := &fakeMetadataProvider{name: "broken", err: errors.New("boom")}
+	metadata := &fakeMetadataProvider{name: "backup", metadata: &service.TrackMetadata{Link: "http://www.example.com"}}
+	emptyLyrics := &fakeLyricsProvider{name: "empty", lyrics: "  "}
+	lyrics := &fakeLyricsProvider{name: "backup", lyrics: "first verse\n\nsecond verse"}
+
+	dataEnrichmentService := service.NewDataEnrichmentServiceWithProviders(
+		[]service.MetadataProvider{failingMetadata, metadata},
+		[]service.LyricsProvider{emptyLyrics, lyrics},
+	)
+
+	music, err := dataEnrichmentService.FetchEnrichedMusic(context.Background(), "Rammstein", "Sonne")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://www.example.com", music.Link)
+	assert.Len(t, music.Verses, 2)
+
+	assert.Equal(t, 1, failingMetadata.calls)
+	assert.Equal(t, 1, metadata.calls)
+	assert.Equal(t, 1, emptyLyrics.calls)
+	assert.Equal(t, 1, lyrics.calls)
+}
+
+func TestFetchEnrichedMusic_StopsAtFirstSuccessfulProvider(t *testing.T) {
+	metadata := &fakeMetadataProvider{name: "primary", metadata: &service.TrackMetadata{Link: "http://www.example.com"}}
+	unusedMetadata := &fakeMetadataProvider{name: "secondary", err: errors.New("should not be called")}
+	lyrics := &fakeLyricsProvider{name: "primary", lyrics: "only verse"}
+	unusedLyrics := &fakeLyricsProvider{name: "secondary", err: errors.New("should not be called")}
+
+	dataEnrichmentService := service.NewDataEnrichmentServiceWithProviders(
+		[]service.MetadataProvider{metadata, unusedMetadata},
+		[]service.LyricsProvider{lyrics, unusedLyrics},
+	)
+
+	_, err := dataEnrichmentService.FetchEnrichedMusic(context.Background(), "Rammstein", "Sonne")
+	assert.NoError(t, err)
+
+	assert.Equal(t, 0, unusedMetadata.calls)
+	assert.Equal(t, 0, unusedLyrics.calls)
+}
+
+func TestFetchEnrichedMusic_AllLyricsProvidersEmpty(t *testing.T) {
+	metadata := &fakeMetadataProvider{name: "primary", metadata: &service.TrackMetadata{Link: "http://www.example.com"}}
+	failingLyrics := &fakeLyricsProvider{name: "broken", err: errors.New("boom")}
+	emptyLyrics := &fakeLyricsProvider{name: "empty"}
+
+	dataEnrichmentService := service.NewDataEnrichmentServiceWithProviders(
+		[]service.MetadataProvider{metadata},
+		[]service.LyricsProvider{failingLyrics, emptyLyrics},
+	)
+
+	music, err := dataEnrichmentService.FetchEnrichedMusic(context.Background(), "Rammstein", "Sonne")
+	assert.Nil(t, music)
+	assert.EqualError(t, err, "no lyrics found for song Sonne by group Rammstein")
+}
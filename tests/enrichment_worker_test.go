@@ -0,0 +1,168 @@
+package service_test
+
+import (
+	"context"
+	"errors"
+	"github.com/Seven11Eleven/music_library/api/http/controller"
+	"github.com/Seven11Eleven/music_library/internal/config"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEnqueueMusic(t *testing.T) {
+	ctx := context.TODO()
+	mockJobRepo := new(mocks.JobRepository)
+
+	query := &models.MusicQuery{SongName: "Sonne", GroupName: "Rammstein"}
+	mockJobRepo.On("CreateJob", ctx, *query, 3).
+		Return(&models.EnrichmentJob{ID: "1", Status: models.JobPending, Query: *query, MaxAttempts: 3}, nil)
+
+	jobService := service.NewJobService(mockJobRepo, &config.Config{JobMaxAttempts: 3})
+
+	job, err := jobService.EnqueueMusic(ctx, query)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", job.ID)
+	assert.Equal(t, models.JobPending, job.Status)
+
+	mockJobRepo.AssertExpectations(t)
+}
+
+func TestEnqueueMusic_ValidationFailed(t *testing.T) {
+	mockJobRepo := new(mocks.JobRepository)
+	app := fiber.New(fiber.Config{ErrorHandler: controller.ErrorHandler})
+	app.Post("/music", controller.NewMusicController(mocks.NewMusicService(t), service.NewJobService(mockJobRepo, &config.Config{}), 0).SaveMusic)
+
+	req := httptest.NewRequest(http.MethodPost, "/music?async=true", strings.NewReader(`{"group_name":"Rammstein"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err := app.Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, "song_name is required", problemOf(t, res).Detail)
+	mockJobRepo.AssertNotCalled(t, "CreateJob", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessNext_Succeeded(t *testing.T) {
+	ctx := context.TODO()
+	mockJobRepo := new(mocks.JobRepository)
+	mockMusicService := new(mocks.MusicService)
+
+	query := models.MusicQuery{SongName: "sonne", GroupName: "rammstein"}
+	saved := &models.Music{ID: "7", SongName: "sonne", GroupName: "rammstein"}
+
+	mockJobRepo.On("ClaimJob", ctx, mock.Anything).
+		Return(&models.EnrichmentJob{ID: "1", Query: query, Attempts: 1, MaxAttempts: 3}, nil)
+	mockMusicService.On("SaveMusic", mock.Anything, &query).Return(saved, nil)
+	mockJobRepo.On("CompleteJob", mock.Anything, "1", saved).Return(nil)
+
+	pool := service.NewEnrichmentWorkerPool(mockJobRepo, mockMusicService, &config.Config{})
+	processed, err := pool.ProcessNext(ctx)
+
+	assert.NoError(t, err)
+	assert.True(t, processed)
+
+	mockJobRepo.AssertExpectations(t)
+	mockMusicService.AssertExpectations(t)
+}
+
+func TestProcessNext_RetriesThenDeadLetters(t *testing.T) {
+	ctx := context.TODO()
+	query := models.MusicQuery{SongName: "sonne", GroupName: "rammstein"}
+
+	mockJobRepo := new(mocks.JobRepository)
+	mockMusicService := new(mocks.MusicService)
+	mockMusicService.On("SaveMusic", mock.Anything, &query).Return(nil, errors.New("upstream down"))
+
+	mockJobRepo.On("ClaimJob", ctx, mock.Anything).
+		Return(&models.EnrichmentJob{ID: "1", Query: query, Attempts: 1, MaxAttempts: 2}, nil).Once()
+	mockJobRepo.On("FailJob", mock.Anything, "1", "upstream down", mock.MatchedBy(func(retryAt *time.Time) bool {
+		return retryAt != nil && retryAt.After(time.Now())
+	})).Return(nil).Once()
+
+	pool := service.NewEnrichmentWorkerPool(mockJobRepo, mockMusicService, &config.Config{JobRetryDelay: time.Second})
+	processed, err := pool.ProcessNext(ctx)
+	assert.NoError(t, err)
+	assert.True(t, processed)
+
+	mockJobRepo.On("ClaimJob", ctx, mock.Anything).
+		Return(&models.EnrichmentJob{ID: "1", Query: query, Attempts: 2, MaxAttempts: 2}, nil).Once()
+	mockJobRepo.On("FailJob", mock.Anything, "1", "upstream down", (*time.Time)(nil)).Return(nil).Once()
+
+	processed, err = pool.ProcessNext(ctx)
+	assert.NoError(t, err)
+	assert.True(t, processed)
+
+	mockJobRepo.AssertExpectations(t)
+}
+
+func TestProcessNext_DuplicatesAreNotRetried(t *testing.T) {
+	ctx := context.TODO()
+	query := models.MusicQuery{SongName: "sonne", GroupName: "rammstein"}
+	duplicates := &models.DuplicatesError{Candidates: []models.SimilarMusic{{Music: models.Music{ID: "3"}}}}
+
+	mockJobRepo := new(mocks.JobRepository)
+	mockMusicService := new(mocks.MusicService)
+	mockMusicService.On("SaveMusic", mock.Anything, &query).Return(nil, duplicates)
+
+	mockJobRepo.On("ClaimJob", ctx, mock.Anything).
+		Return(&models.EnrichmentJob{ID: "1", Query: query, Attempts: 1, MaxAttempts: 5}, nil)
+	mockJobRepo.On("FailJob", mock.Anything, "1", duplicates.Error(), (*time.Time)(nil)).Return(nil)
+
+	pool := service.NewEnrichmentWorkerPool(mockJobRepo, mockMusicService, &config.Config{})
+	processed, err := pool.ProcessNext(ctx)
+
+	assert.NoError(t, err)
+	assert.True(t, processed)
+	mockJobRepo.AssertExpectations(t)
+}
+
+func TestProcessNext_ReleasesJobOnShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	query := models.MusicQuery{SongName: "sonne", GroupName: "rammstein"}
+
+	mockJobRepo := new(mocks.JobRepository)
+	mockMusicService := new(mocks.MusicService)
+	mockJobRepo.On("ClaimJob", ctx, mock.Anything).
+		Return(&models.EnrichmentJob{ID: "1", Query: query, Attempts: 5, MaxAttempts: 5}, nil)
+	// Воркер останавливается, пока задача ещё выполняется
+	mockMusicService.On("SaveMusic", mock.Anything, &query).
+		Run(func(mock.Arguments) { cancel() }).
+		Return(nil, context.Canceled)
+	mockJobRepo.On("ReleaseJob", mock.MatchedBy(func(ctx context.Context) bool {
+		_, hasDeadline := ctx.Deadline()
+		return ctx.Err() == nil && hasDeadline
+	}), "1").Return(nil)
+
+	pool := service.NewEnrichmentWorkerPool(mockJobRepo, mockMusicService, &config.Config{})
+	processed, err := pool.ProcessNext(ctx)
+
+	assert.NoError(t, err)
+	assert.True(t, processed)
+	mockJobRepo.AssertExpectations(t)
+	mockJobRepo.AssertNotCalled(t, "FailJob", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestProcessNext_NoJobs(t *testing.T) {
+	ctx := context.TODO()
+	mockJobRepo := new(mocks.JobRepository)
+	mockMusicService := new(mocks.MusicService)
+
+	mockJobRepo.On("ClaimJob", ctx, mock.Anything).Return(nil, nil)
+
+	pool := service.NewEnrichmentWorkerPool(mockJobRepo, mockMusicService, &config.Config{})
+	processed, err := pool.ProcessNext(ctx)
+
+	assert.NoError(t, err)
+	assert.False(t, processed)
+	mockMusicService.AssertNotCalled(t, "SaveMusic", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,181 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/Seven11Eleven/music_library/api/http/controller"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestDomainErrorKinds(t *testing.T) {
+	assert.ErrorIs(t, models.ErrMusicNotFound, models.ErrNotFound)
+	assert.ErrorIs(t, models.ErrGenreNotFound, models.ErrNotFound)
+	assert.ErrorIs(t, models.ErrArtistExists, models.ErrConflict)
+	assert.ErrorIs(t, models.ErrInvalidSort, models.ErrValidation)
+	assert.NotErrorIs(t, models.ErrMusicNotFound, models.ErrConflict)
+
+	wrapped := fmt.Errorf("saving album: %w", models.ErrAlbumExists)
+	assert.ErrorIs(t, wrapped, models.ErrConflict)
+	assert.ErrorIs(t, wrapped, models.ErrAlbumExists)
+
+	cause := errors.New("connection refused")
+	upstream := models.WrapError(models.ErrUpstream, cause)
+	assert.EqualError(t, upstream, "connection refused")
+	assert.ErrorIs(t, upstream, models.ErrUpstream)
+	assert.ErrorIs(t, upstream, cause)
+}
+
+func TestValidationError(t *testing.T) {
+	err := service.ValidateRequest(&models.MusicQuery{GroupName: "Rammstein"})
+	assert.EqualError(t, err, "song_name is required")
+	assert.ErrorIs(t, err, models.ErrValidation)
+
+	var validation *models.ValidationError
+	assert.ErrorAs(t, err, &validation)
+	assert.Equal(t, []models.FieldError{{Field: "song_name", Message: "song_name is required"}}, validation.Fields)
+
+	_, err = service.ParseSort("popularity")
+	assert.ErrorIs(t, err, models.ErrValidation)
+	assert.ErrorIs(t, err, models.ErrInvalidSort)
+	assert.ErrorAs(t, err, &validation)
+	assert.Equal(t, "sort", validation.Fields[0].Field)
+}
+
+// problemApp serves GET /music/:id and POST /music with the central error handler.
+func problemApp(musicService models.MusicService) *fiber.App {
+	app := fiber.New(fiber.Config{ErrorHandler: controller.ErrorHandler})
+	musicController := controller.NewMusicController(musicService, nil, 0)
+	app.Get("/music/:id", musicController.GetMusic)
+	app.Post("/music", musicController.SaveMusic)
+	return app
+}
+
+func problemOf(t *testing.T, res *http.Response) controller.Problem {
+	assert.Equal(t, "application/problem+json", res.Header.Get(fiber.HeaderContentType))
+	var problem controller.Problem
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&problem))
+	return problem
+}
+
+func TestErrorHandler_NotFound(t *testing.T) {
+	mockMusicService := mocks.NewMusicService(t)
+	mockMusicService.On("GetMusicByID", mock.Anything, "42", false).Return(nil, nil)
+
+	res, err := problemApp(mockMusicService).Test(httptest.NewRequest(http.MethodGet, "/music/42", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+
+	problem := problemOf(t, res)
+	assert.Equal(t, controller.Problem{
+		Type:     "about:blank",
+		Title:    "Not Found",
+		Status:   http.StatusNotFound,
+		Detail:   "music not found",
+		Instance: "/music/42",
+	}, problem)
+}
+
+func TestErrorHandler_FieldErrors(t *testing.T) {
+	res, err := problemApp(mocks.NewMusicService(t)).Test(httptest.NewRequest(http.MethodGet, "/music/42?fields=lyrics", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	problem := problemOf(t, res)
+	assert.Equal(t, "unknown field lyrics", problem.Detail)
+	assert.Equal(t, []models.FieldError{{Field: "fields", Message: "unknown field lyrics"}}, problem.Errors)
+}
+
+func TestErrorHandler_Statuses(t *testing.T) {
+	tests := []struct {
+		err    error
+		status int
+	}{
+		{models.NewValidationError("song_name", "music song name is required"), http.StatusBadRequest},
+		{models.ErrArtistExists, http.StatusConflict},
+		{models.WrapError(models.ErrUpstream, errors.New("lyrics API is down")), http.StatusBadGateway},
+		{context.DeadlineExceeded, http.StatusGatewayTimeout},
+		{errors.New("connection reset by peer"), http.StatusInternalServerError},
+	}
+	for _, tt := range tests {
+		mockMusicService := mocks.NewMusicService(t)
+		mockMusicService.On("SaveMusic", mock.Anything, mock.Anything).Return(nil, tt.err)
+
+		req := httptest.NewRequest(http.MethodPost, "/music", strings.NewReader(`{"song_name":"Sonne","group_name":"Rammstein"}`))
+		req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+		res, err := problemApp(mockMusicService).Test(req)
+		assert.NoError(t, err)
+		assert.Equal(t, tt.status, res.StatusCode, tt.err.Error())
+
+		problem := problemOf(t, res)
+		assert.Equal(t, tt.status, problem.Status)
+		if tt.status == http.StatusInternalServerError {
+			assert.Empty(t, problem.Detail, "details of internal errors are not exposed")
+		} else {
+			assert.Equal(t, tt.err.Error(), problem.Detail)
+		}
+	}
+}
+
+func TestErrorHandler_InvalidBody(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/music", strings.NewReader(`{"song_name":`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err := problemApp(mocks.NewMusicService(t)).Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, "body", problemOf(t, res).Errors[0].Field)
+}
+
+func TestErrorHandler_MissingMusic(t *testing.T) {
+	mockMusicService := mocks.NewMusicService(t)
+	mockMusicService.On("DeleteMusic", mock.Anything, "42", mock.Anything).Return(models.ErrMusicNotFound)
+	mockMusicService.On("UpdateMusic", mock.Anything, mock.Anything, mock.Anything).Return(models.Music{}, models.ErrMusicNotFound)
+
+	app := fiber.New(fiber.Config{ErrorHandler: controller.ErrorHandler})
+	musicController := controller.NewMusicController(mockMusicService, nil, 0)
+	app.Delete("/music/:id", musicController.DeleteMusic)
+	app.Put("/music/:id", musicController.UpdateMusic)
+
+	req := httptest.NewRequest(http.MethodDelete, "/music/42", nil)
+	req.Header.Set(fiber.HeaderIfMatch, `"1"`)
+	res, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Equal(t, "music not found", problemOf(t, res).Detail)
+
+	req = httptest.NewRequest(http.MethodPut, "/music/42", strings.NewReader(`{"song_name":"Sonne"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderIfMatch, `"1"`)
+	res, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNotFound, res.StatusCode)
+	assert.Equal(t, "music not found", problemOf(t, res).Detail)
+}
+
+func TestErrorHandler_Duplicates(t *testing.T) {
+	candidates := []models.SimilarMusic{
+		{Music: models.Music{ID: "1", SongName: "sonne (remastered)", GroupName: "rammstein"}, Similarity: 0.66},
+	}
+	mockMusicService := mocks.NewMusicService(t)
+	mockMusicService.On("SaveMusic", mock.Anything, mock.Anything).Return(nil, &models.DuplicatesError{Candidates: candidates})
+
+	req := httptest.NewRequest(http.MethodPost, "/music", strings.NewReader(`{"song_name":"Sonne","group_name":"Rammstein"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err := problemApp(mockMusicService).Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusConflict, res.StatusCode)
+
+	problem := problemOf(t, res)
+	assert.Equal(t, "found 1 possible duplicates, set force to save anyway", problem.Detail)
+	assert.Equal(t, candidates, problem.Candidates)
+}
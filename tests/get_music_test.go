@@ -0,0 +1,35 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestGetMusicByID(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("GetMusicByID", ctx, "1", true).
+		Return(&models.Music{ID: "1", SongName: "sonne", Verses: []models.Verse{{Number: 1, Text: "Eins"}}}, nil)
+	mockMusicRepo.On("GetMusicByID", ctx, "2", false).Return(nil, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+
+	music, err := musicService.GetMusicByID(ctx, "1", true)
+	assert.NoError(t, err)
+	assert.Len(t, music.Verses, 1)
+
+	music, err = musicService.GetMusicByID(ctx, "2", false)
+	assert.NoError(t, err)
+	assert.Nil(t, music)
+
+	_, err = musicService.GetMusicByID(ctx, "", false)
+	assert.Error(t, err)
+
+	mockMusicRepo.AssertExpectations(t)
+}
@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestParseLRC(t *testing.T) {
+	doc, err := service.ParseLRC("[ti:Sonne]\n[ar:Rammstein]\n[offset:+100]\n[00:10.50]Eins, hier kommt die Sonne\n[00:12.00]Zwei, hier kommt die Sonne\n\n[00:20.00][00:40.00]Hier kommt die Sonne\n\n[00:30.00]<00:30.00>Sie <00:30.40>ist <00:30.75>der <00:31.10>hellste <00:31.60>Stern\n")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Sonne", doc.Title)
+	assert.Equal(t, "Rammstein", doc.Artist)
+	assert.Len(t, doc.Verses, 4)
+
+	assert.Equal(t, "Eins, hier kommt die Sonne\nZwei, hier kommt die Sonne", doc.Verses[0].Text)
+	assert.Len(t, doc.Verses[0].Lines, 2)
+	assert.Equal(t, int64(10400), doc.Verses[0].Lines[0].StartMs)
+	assert.Equal(t, int64(11900), doc.Verses[0].Lines[1].StartMs)
+
+	assert.Equal(t, "Sie ist der hellste Stern", doc.Verses[2].Text)
+	assert.Len(t, doc.Verses[2].Lines[0].Words, 5)
+	assert.Equal(t, models.TimedWord{StartMs: 30300, Text: "ist"}, doc.Verses[2].Lines[0].Words[1])
+
+	assert.Equal(t, "Hier kommt die Sonne", doc.Verses[3].Text)
+	assert.Equal(t, int64(39900), doc.Verses[3].Lines[0].StartMs)
+	assert.Equal(t, intPtr(2), doc.Verses[3].RepeatOf)
+}
+
+func TestParseLRC_NoTimedLines(t *testing.T) {
+	_, err := service.ParseLRC("[ti:Sonne]\nEins, hier kommt die Sonne\n")
+
+	assert.Error(t, err)
+}
+
+func TestFormatLRC(t *testing.T) {
+	music := &models.Music{
+		SongName:  "Sonne",
+		GroupName: "Rammstein",
+		Verses: []models.Verse{
+			{Number: 1, Lines: []models.LyricLine{
+				{Number: 1, StartMs: 10500, Text: "Eins, hier kommt die Sonne"},
+			}},
+			{Number: 2, Lines: []models.LyricLine{
+				{Number: 1, StartMs: 61230, Text: "Hier kommt", Words: []models.TimedWord{
+					{StartMs: 61230, Text: "Hier"},
+					{StartMs: 61500, Text: "kommt"},
+				}},
+			}},
+		},
+	}
+
+	lrc := service.FormatLRC(music)
+
+	assert.Equal(t, "[ti:Sonne]\n[ar:Rammstein]\n[00:10.50]Eins, hier kommt die Sonne\n\n[01:01.23]<01:01.23>Hier <01:01.50>kommt\n", lrc)
+
+	doc, err := service.ParseLRC(lrc)
+	assert.NoError(t, err)
+	assert.Len(t, doc.Verses, 2)
+	assert.Equal(t, music.Verses[1].Lines[0].Words, doc.Verses[1].Lines[0].Words)
+}
+
+func TestImportLRC(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("ReplaceVerses", ctx, "1", mock.MatchedBy(func(verses []models.Verse) bool {
+		return len(verses) == 1 && len(verses[0].Lines) == 2
+	}), (*int)(nil)).Return(&models.Music{ID: "1", SongName: "Sonne"}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	music, err := musicService.ImportLRC(ctx, "1", "[00:10.50]Eins, hier kommt die Sonne\n[00:12.00]Zwei, hier kommt die Sonne\n", nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "Sonne", music.SongName)
+
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestExportLRC_NoTimedLyrics(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("GetTimedLyrics", ctx, "1").
+		Return(&models.Music{ID: "1", Verses: []models.Verse{{Number: 1, Text: "Eins, zwei, drei"}}}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	lrc, err := musicService.ExportLRC(ctx, "1")
+
+	assert.NoError(t, err)
+	assert.Empty(t, lrc)
+
+	mockMusicRepo.AssertExpectations(t)
+}
@@ -0,0 +1,199 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Seven11Eleven/music_library/api/http/controller"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func patchTestMusic() *models.Music {
+	releaseDate := time.Date(2001, 2, 12, 0, 0, 0, 0, time.UTC)
+	return &models.Music{
+		ID:          "1",
+		SongName:    "Sonne",
+		GroupName:   "Rammstein",
+		ReleaseDate: &releaseDate,
+		Link:        "https://example.com/sonne",
+		Version:     4,
+		Verses: []models.Verse{
+			{Number: 1, Text: "Eins, zwei, drei", SectionType: models.SectionIntro},
+			{Number: 2, Text: "Hier kommt die Sonne", SectionType: models.SectionChorus},
+		},
+	}
+}
+
+func TestPatchMusic_MergePatchClearsFields(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("GetTimedLyrics", ctx, "1").Return(patchTestMusic(), nil)
+	expected := mock.MatchedBy(func(patch models.MusicPatch) bool {
+		return patch.SongName == "Sonne" && patch.Link == nil && patch.ReleaseDate == nil && !patch.ReplaceVerses
+	})
+	// The patch is applied to the version just read, so later changes are not overwritten.
+	mockMusicRepo.On("PatchMusic", ctx, "1", expected, expectsVersion(4)).Return(&models.Music{ID: "1", Version: 5}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.PatchMusic(ctx, "1", models.PatchMerge, []byte(`{"link": null, "release_date": null}`), nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 5, res.Version)
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestPatchMusic_JSONPatchReplacesVerses(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("GetTimedLyrics", ctx, "1").Return(patchTestMusic(), nil)
+	expected := mock.MatchedBy(func(patch models.MusicPatch) bool {
+		return patch.ReplaceVerses && len(patch.Verses) == 1 &&
+			patch.Verses[0].Number == 1 && patch.Verses[0].Text == "Hier kommt die Sonne" &&
+			patch.Link != nil && *patch.Link == "https://example.com/sonne"
+	})
+	mockMusicRepo.On("PatchMusic", mock.Anything, "1", expected, mock.Anything).Return(&models.Music{ID: "1"}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	_, err := musicService.PatchMusic(ctx, "1", models.PatchJSON, []byte(`[
+		{"op": "test", "path": "/verses/0/text", "value": "Eins, zwei, drei"},
+		{"op": "remove", "path": "/verses/0"}
+	]`), nil)
+
+	assert.NoError(t, err)
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestPatchMusic_Invalid(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("GetTimedLyrics", ctx, "1").Return(patchTestMusic(), nil)
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+
+	patches := map[string][]byte{
+		"required field cleared": []byte(`{"song_name": null}`),
+		"unknown field":          []byte(`{"id": "2"}`),
+		"bad date":               []byte(`{"release_date": "12.02.2001"}`),
+		"empty verse":            []byte(`{"verses": [{"text": ""}]}`),
+	}
+	for name, patch := range patches {
+		_, err := musicService.PatchMusic(ctx, "1", models.PatchMerge, patch, nil)
+		assert.ErrorIs(t, err, models.ErrInvalidPatch, name)
+	}
+
+	_, err := musicService.PatchMusic(ctx, "1", models.PatchJSON, []byte(`[{"op": "test", "path": "/song_name", "value": "Mutter"}]`), nil)
+	assert.ErrorIs(t, err, models.ErrInvalidPatch)
+
+	_, err = musicService.PatchMusic(ctx, "1", "text/plain", []byte(`{}`), nil)
+	assert.ErrorIs(t, err, models.ErrUnsupportedPatch)
+
+	mockMusicRepo.AssertNotCalled(t, "PatchMusic", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPatchMusic_NotFound(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("GetTimedLyrics", ctx, "1").Return(nil, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.PatchMusic(ctx, "1", models.PatchMerge, []byte(`{}`), nil)
+
+	assert.NoError(t, err)
+	assert.Nil(t, res)
+}
+
+func TestPatchMusic_ClearedLinkIsReadable(t *testing.T) {
+	patched := patchTestMusic()
+	patched.Link = ""
+	patched.Version = 5
+
+	mockMusicService := mocks.NewMusicService(t)
+	mockMusicService.On("PatchMusic", mock.Anything, "1", models.PatchMerge, []byte(`{"link": null}`), expectsVersion(4)).Return(patched, nil)
+	mockMusicService.On("GetMusicByID", mock.Anything, "1", false).Return(patched, nil)
+
+	app := fiber.New(fiber.Config{ErrorHandler: controller.ErrorHandler})
+	musicController := controller.NewMusicController(mockMusicService, nil, 0)
+	app.Patch("/music/:id", musicController.PatchMusic)
+	app.Get("/music/:id", musicController.GetMusic)
+
+	req := httptest.NewRequest(http.MethodPatch, "/music/1", strings.NewReader(`{"link": null}`))
+	req.Header.Set(fiber.HeaderContentType, "application/merge-patch+json")
+	req.Header.Set(fiber.HeaderIfMatch, `"4"`)
+	res, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	res, err = app.Test(httptest.NewRequest(http.MethodGet, "/music/1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, `"5"`, res.Header.Get(fiber.HeaderETag))
+
+	var body map[string]any
+	assert.NoError(t, json.NewDecoder(res.Body).Decode(&body))
+	assert.NotContains(t, body, "link")
+}
+
+func TestPatchMusic_RequestRules(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("GetTimedLyrics", ctx, "1").Return(patchTestMusic(), nil)
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+
+	patches := map[string]string{
+		`{"song_name": "` + strings.Repeat("a", 256) + `"}`:  "song_name",
+		`{"group_name": "` + strings.Repeat("a", 256) + `"}`: "group_name",
+		`{"link": "not a link"}`:                             "link",
+		`{"release_date": "2999-01-01"}`:                     "release_date",
+	}
+	for patch, field := range patches {
+		_, err := musicService.PatchMusic(ctx, "1", models.PatchMerge, []byte(patch), nil)
+		assert.ErrorIs(t, err, models.ErrInvalidPatch, field)
+		assert.Contains(t, fieldsOf(t, err), field)
+	}
+
+	mockMusicRepo.AssertNotCalled(t, "PatchMusic", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestPatchMusic_KeepsRepeatedSections(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	chorus := 2
+	music := patchTestMusic()
+	music.Verses = append(music.Verses, models.Verse{Number: 3, Text: "Hier kommt die Sonne", SectionType: models.SectionChorus, RepeatOf: &chorus})
+	mockMusicRepo.On("GetTimedLyrics", ctx, "1").Return(music, nil)
+	expected := mock.MatchedBy(func(patch models.MusicPatch) bool {
+		return patch.ReplaceVerses && len(patch.Verses) == 3 &&
+			patch.Verses[0].Text == "Eins, zwei, drei, vier" &&
+			patch.Verses[2].RepeatOf != nil && *patch.Verses[2].RepeatOf == 2
+	})
+	mockMusicRepo.On("PatchMusic", mock.Anything, "1", expected, mock.Anything).Return(&models.Music{ID: "1"}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	_, err := musicService.PatchMusic(ctx, "1", models.PatchJSON, []byte(`[{"op": "replace", "path": "/verses/0/text", "value": "Eins, zwei, drei, vier"}]`), nil)
+	assert.NoError(t, err)
+
+	_, err = musicService.PatchMusic(ctx, "1", models.PatchJSON, []byte(`[{"op": "replace", "path": "/verses/1/repeat_of", "value": 3}]`), nil)
+	assert.ErrorIs(t, err, models.ErrInvalidPatch)
+
+	mockMusicRepo.AssertExpectations(t)
+}
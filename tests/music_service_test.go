@@ -5,8 +5,12 @@ import (
 	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
 	"github.com/Seven11Eleven/music_library/internal/domain/models"
 	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/gofiber/fiber/v2"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"strings"
 	"testing"
 )
 
@@ -39,24 +43,17 @@ func TestSaveMusic(t *testing.T) {
 }
 
 func TestSaveMusic_ValidationFailed(t *testing.T) {
-	ctx := context.TODO()
-
-	mockMusicRepo := new(mocks.MusicRepository)
-	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
-
-	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
-
-	musicQuery := &models.MusicQuery{
-		SongName:  "",
-		GroupName: "Rammstein",
-	}
+	mockMusicService := new(mocks.MusicService)
 
-	_, err := musicService.SaveMusic(ctx, musicQuery)
+	req := httptest.NewRequest(http.MethodPost, "/music", strings.NewReader(`{"song_name":"","group_name":"Rammstein"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err := problemApp(mockMusicService).Test(req)
 
-	assert.EqualError(t, err, "music song name is required")
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, "song_name is required", problemOf(t, res).Detail)
 
-	mockMusicRepo.AssertNotCalled(t, "GetMusic", mock.Anything, mock.Anything, mock.Anything)
-	mockDataEnrichmentService.AssertNotCalled(t, "FetchEnrichedMusic", mock.Anything, mock.Anything, mock.Anything)
+	mockMusicService.AssertNotCalled(t, "SaveMusic", mock.Anything, mock.Anything)
 }
 
 func TestGetMusicsByFilters(t *testing.T) {
@@ -76,9 +73,9 @@ func TestGetMusicsByFilters(t *testing.T) {
 	musics, err := musicService.GetMusicsByFilters(ctx, filters, 1, 10)
 
 	assert.NoError(t, err)
-	assert.Len(t, musics, 2)
-	assert.Equal(t, "Sonne", musics[0].SongName)
-	assert.Equal(t, "Du Hast", musics[1].SongName)
+	assert.Len(t, musics.Items, 2)
+	assert.Equal(t, "Sonne", musics.Items[0].SongName)
+	assert.Equal(t, "Du Hast", musics.Items[1].SongName)
 
 	mockMusicRepo.AssertExpectations(t)
 }
@@ -88,7 +85,7 @@ func TestGetMusicTextWithPaginationByVerse(t *testing.T) {
 	mockMusicRepo := new(mocks.MusicRepository)
 	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
 
-	mockMusicRepo.On("GetMusicTextWithPaginationByVerse", ctx, "1", 10, 0).
+	mockMusicRepo.On("GetMusicTextWithPaginationByVerse", ctx, "1", models.VerseFilters{}, 10, 0).
 		Return(&models.Music{
 			ID:       "1",
 			SongName: "Sonne",
@@ -100,7 +97,7 @@ func TestGetMusicTextWithPaginationByVerse(t *testing.T) {
 
 	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
 
-	music, err := musicService.GetMusicTextWithPaginationByVerse(ctx, "1", 10, 0)
+	music, err := musicService.GetMusicTextWithPaginationByVerse(ctx, "1", models.VerseFilters{}, 10, 0)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "Sonne", music.SongName)
@@ -115,10 +112,10 @@ func TestDeleteMusic(t *testing.T) {
 	mockMusicRepo := new(mocks.MusicRepository)
 	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
 
-	mockMusicRepo.On("DeleteMusic", ctx, "1").Return(nil)
+	mockMusicRepo.On("DeleteMusic", ctx, "1", (*int)(nil)).Return(nil)
 
 	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
-	err := musicService.DeleteMusic(ctx, "1")
+	err := musicService.DeleteMusic(ctx, "1", nil)
 
 	assert.NoError(t, err)
 
@@ -130,16 +127,97 @@ func TestUpdateMusic(t *testing.T) {
 	mockMusicRepo := new(mocks.MusicRepository)
 	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
 
-	mockMusicRepo.On("UpdateMusic", ctx, mock.Anything).
+	mockMusicRepo.On("UpdateMusic", ctx, mock.Anything, (*int)(nil)).
 		Return(models.Music{ID: "1", SongName: "Sonne", GroupName: "Rammstein"}, nil)
 
 	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
 
 	music := models.Music{ID: "1", SongName: "Sonne", GroupName: "Rammstein"}
-	updatedMusic, err := musicService.UpdateMusic(ctx, music)
+	updatedMusic, err := musicService.UpdateMusic(ctx, music, nil)
 
 	assert.NoError(t, err)
 	assert.Equal(t, "Sonne", updatedMusic.SongName)
 
 	mockMusicRepo.AssertExpectations(t)
 }
+
+func TestSearchMusic(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("SearchMusic", ctx, "hier kommt die sonne", 10, 10).
+		Return([]models.SearchResult{
+			{
+				Music: models.Music{ID: "1", SongName: "Sonne"},
+				Rank:  0.5,
+				Matches: []models.SearchMatch{
+					{VerseNumber: 2, Snippet: "<b>Hier</b> <b>kommt</b> die <b>Sonne</b>"},
+				},
+			},
+		}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	results, err := musicService.SearchMusic(ctx, "  hier kommt die sonne ", 2, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, results.Items, 1)
+	assert.Equal(t, 2, results.Items[0].Matches[0].VerseNumber)
+
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestSearchMusic_EmptyQuery(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	_, err := musicService.SearchMusic(ctx, " ", 1, 10)
+
+	assert.EqualError(t, err, "search query is required")
+
+	mockMusicRepo.AssertNotCalled(t, "SearchMusic")
+}
+
+func TestSaveMusic_PossibleDuplicates(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	candidates := []models.SimilarMusic{
+		{Music: models.Music{ID: "1", SongName: "sonne (remastered)", GroupName: "rammstein"}, Similarity: 0.66},
+	}
+	mockMusicRepo.On("GetMusic", ctx, "sonne", "rammstein").Return(nil, nil)
+	mockMusicRepo.On("FindSimilarMusic", ctx, "sonne", "rammstein", 0.4, mock.Anything).Return(candidates, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService, service.WithSimilarityThreshold(0.4))
+	_, err := musicService.SaveMusic(ctx, &models.MusicQuery{SongName: "Sonne", GroupName: "Rammstein"})
+
+	var duplicates *models.DuplicatesError
+	assert.ErrorAs(t, err, &duplicates)
+	assert.Equal(t, candidates, duplicates.Candidates)
+
+	mockDataEnrichmentService.AssertNotCalled(t, "FetchEnrichedMusic", mock.Anything, mock.Anything, mock.Anything)
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestSaveMusic_ForceSkipsDuplicateCheck(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	enriched := &models.Music{SongName: "Sonne", GroupName: "Rammstein"}
+	mockMusicRepo.On("GetMusic", ctx, "sonne", "rammstein").Return(nil, nil)
+	mockDataEnrichmentService.On("FetchEnrichedMusic", ctx, "rammstein", "sonne").Return(enriched, nil)
+	mockMusicRepo.On("SaveMusic", ctx, enriched).Return(&models.Music{ID: "2", SongName: "sonne", GroupName: "rammstein"}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService, service.WithSimilarityThreshold(0.4))
+	music, err := musicService.SaveMusic(ctx, &models.MusicQuery{SongName: "Sonne", GroupName: "Rammstein", Force: true})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2", music.ID)
+
+	mockMusicRepo.AssertNotCalled(t, "FindSimilarMusic", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	mockMusicRepo.AssertExpectations(t)
+}
@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestParseSort(t *testing.T) {
+	keys, err := service.ParseSort("-release_date, title,created_at")
+	assert.NoError(t, err)
+	assert.Equal(t, []models.SortKey{
+		{Field: models.SortReleaseDate, Desc: true},
+		{Field: models.SortTitle},
+		{Field: models.SortCreatedAt},
+	}, keys)
+	assert.Equal(t, "-release_date,title,created_at", service.FormatSort(keys))
+
+	keys, err = service.ParseSort("")
+	assert.NoError(t, err)
+	assert.Empty(t, keys)
+}
+
+func TestParseSort_Invalid(t *testing.T) {
+	for _, sort := range []string{"id", "title,-title", "title,", "release_date; DROP TABLE music"} {
+		_, err := service.ParseSort(sort)
+		assert.ErrorIs(t, err, models.ErrInvalidSort, sort)
+	}
+}
+
+func TestValidateRequest_InvalidSort(t *testing.T) {
+	err := service.ValidateRequest(&models.MusicListParams{Sort: "title,-title"})
+	assert.ErrorIs(t, err, models.ErrValidation)
+	assert.Contains(t, fieldsOf(t, err), "sort")
+}
+
+func TestGetMusicsByCursor_SortedCursor(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	releaseDate := time.Date(2001, 3, 7, 0, 0, 0, 0, time.UTC)
+	items := []models.Music{
+		{ID: "7", SongName: "B", ReleaseDate: &releaseDate},
+		{ID: "3", SongName: "A"},
+		{ID: "5", SongName: "C"},
+	}
+	filters := models.MusicFilters{Sort: []models.SortKey{{Field: models.SortReleaseDate, Desc: true}, {Field: models.SortTitle}}}
+	mockMusicRepo.On("GetMusicsAfter", ctx, mock.Anything, models.Cursor{}, 3).Return(items, nil).Once()
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.GetMusicsByCursor(ctx, filters, "", 2)
+	assert.NoError(t, err)
+
+	// Songs without a release date are sorted last, so the cursor gives them the smallest one
+	next, err := service.DecodeCursor(res.NextCursor)
+	assert.NoError(t, err)
+	assert.Equal(t, models.Cursor{ID: 3, Sort: "-release_date,title", Values: []string{"-infinity", "A"}}, next)
+
+	mockMusicRepo.On("GetMusicsAfter", ctx, mock.Anything, next, 3).Return(items[2:], nil).Once()
+	res, err = musicService.GetMusicsByCursor(ctx, filters, res.NextCursor, 2)
+	assert.NoError(t, err)
+	assert.Len(t, res.Items, 1)
+	assert.Empty(t, res.NextCursor)
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestGetMusicsByCursor_CursorOfAnotherSort(t *testing.T) {
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+
+	cursor := service.EncodeCursor(models.Cursor{ID: 3, Sort: "title", Values: []string{"A"}})
+	filters := models.MusicFilters{Sort: []models.SortKey{{Field: models.SortTitle, Desc: true}}}
+	_, err := musicService.GetMusicsByCursor(context.TODO(), filters, cursor, 2)
+	assert.ErrorIs(t, err, models.ErrInvalidCursor)
+
+	cursor = service.EncodeCursor(models.Cursor{ID: 3, Sort: "created_at", Values: []string{"yesterday"}})
+	filters = models.MusicFilters{Sort: []models.SortKey{{Field: models.SortCreatedAt}}}
+	_, err = musicService.GetMusicsByCursor(context.TODO(), filters, cursor, 2)
+	assert.ErrorIs(t, err, models.ErrInvalidCursor)
+
+	mockMusicRepo.AssertNotCalled(t, "GetMusicsAfter", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,83 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestNormalizePagination(t *testing.T) {
+	page, pageSize, err := service.NormalizePagination(0, 0)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, page)
+	assert.Equal(t, service.DefaultPageSize, pageSize)
+
+	page, pageSize, err = service.NormalizePagination(3, 1000)
+	assert.NoError(t, err)
+	assert.Equal(t, 3, page)
+	assert.Equal(t, service.MaxPageSize, pageSize)
+
+	_, _, err = service.NormalizePagination(-1, 10)
+	assert.Error(t, err)
+
+	_, _, err = service.NormalizePagination(1, -10)
+	assert.Error(t, err)
+}
+
+func TestGetMusicsByFilters_DefaultPaginationAndTotal(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	full := make([]models.Music, service.DefaultPageSize)
+	mockMusicRepo.On("GetMusicsByFilters", ctx, mock.Anything, 1, service.DefaultPageSize).Return(full, nil)
+	mockMusicRepo.On("CountMusicsByFilters", ctx, mock.Anything).Return(45, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.GetMusicsByFilters(ctx, models.MusicFilters{}, 0, 0)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 45, res.Total)
+	assert.Equal(t, 1, res.Page)
+	assert.Equal(t, service.DefaultPageSize, res.PageSize)
+	assert.True(t, res.HasNext())
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestGetMusicsByFilters_TotalOfLastPage(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("GetMusicsByFilters", ctx, mock.Anything, 3, 10).Return(make([]models.Music, 4), nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.GetMusicsByFilters(ctx, models.MusicFilters{}, 3, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 24, res.Total)
+	assert.False(t, res.HasNext())
+	mockMusicRepo.AssertNotCalled(t, "CountMusicsByFilters", mock.Anything, mock.Anything)
+}
+
+func TestGetMusicsByFilters_PageBeyondEnd(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("GetMusicsByFilters", ctx, mock.Anything, 9, 10).Return(nil, nil)
+	mockMusicRepo.On("CountMusicsByFilters", ctx, mock.Anything).Return(24, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.GetMusicsByFilters(ctx, models.MusicFilters{}, 9, 10)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, res.Items)
+	assert.Empty(t, res.Items)
+	assert.Equal(t, 24, res.Total)
+	mockMusicRepo.AssertExpectations(t)
+}
@@ -0,0 +1,49 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+// expectsVersion matches the expected version passed to a change of a song.
+func expectsVersion(version int) any {
+	return mock.MatchedBy(func(expected *int) bool {
+		return expected != nil && *expected == version
+	})
+}
+
+func TestUpdateMusic_VersionMismatch(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("UpdateMusic", ctx, mock.Anything, expectsVersion(2)).
+		Return(models.Music{}, models.ErrVersionMismatch)
+
+	version := 2
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	_, err := musicService.UpdateMusic(ctx, models.Music{ID: "1", SongName: "sonne"}, &version)
+
+	assert.ErrorIs(t, err, models.ErrVersionMismatch)
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestDeleteMusic_VersionMismatch(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("DeleteMusic", ctx, "1", expectsVersion(5)).Return(models.ErrVersionMismatch)
+
+	version := 5
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	err := musicService.DeleteMusic(ctx, "1", &version)
+
+	assert.ErrorIs(t, err, models.ErrVersionMismatch)
+	mockMusicRepo.AssertExpectations(t)
+}
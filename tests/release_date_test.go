@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/config"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func date(year int, month time.Month, day int) time.Time {
+	return time.Date(year, month, day, 0, 0, 0, 0, time.UTC)
+}
+
+func TestParseReleaseDate(t *testing.T) {
+	tests := []struct {
+		input     string
+		date      time.Time
+		precision models.DatePrecision
+	}{
+		{"1999", date(1999, time.January, 1), models.PrecisionYear},
+		{"1999-04", date(1999, time.April, 1), models.PrecisionMonth},
+		{"1999-04-06", date(1999, time.April, 6), models.PrecisionDay},
+	}
+	for _, tt := range tests {
+		releaseDate, precision, err := models.ParseReleaseDate(tt.input)
+		assert.NoError(t, err, tt.input)
+		assert.Equal(t, tt.date, releaseDate, tt.input)
+		assert.Equal(t, tt.precision, precision, tt.input)
+		assert.Equal(t, tt.input, models.FormatReleaseDate(releaseDate, precision))
+	}
+
+	_, _, err := models.ParseReleaseDate("Apr 1999")
+	assert.ErrorIs(t, err, models.ErrInvalidReleaseDate)
+}
+
+func TestReleaseDateEnd(t *testing.T) {
+	assert.Equal(t, date(1999, time.December, 31), models.ReleaseDateEnd(date(1999, time.January, 1), models.PrecisionYear))
+	assert.Equal(t, date(2000, time.February, 29), models.ReleaseDateEnd(date(2000, time.February, 1), models.PrecisionMonth))
+	assert.Equal(t, date(1999, time.April, 6), models.ReleaseDateEnd(date(1999, time.April, 6), models.PrecisionDay))
+	assert.Equal(t, "1999-04-06", models.FormatReleaseDate(date(1999, time.April, 6), ""))
+}
+
+func TestGetMusicsByFilters_YearAndDecadeNarrowRange(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	year := 1995
+	decade := 1990
+	from := date(1995, time.June, 1)
+	filters := models.MusicFilters{Year: &year, Decade: &decade, ReleaseDateFrom: &from}
+
+	mockMusicRepo.On("GetMusicsByFilters", ctx, mock.MatchedBy(func(f models.MusicFilters) bool {
+		return f.ReleaseDateFrom.Equal(from) && f.ReleaseDateTo.Equal(date(1995, time.December, 31))
+	}), 1, 10).Return([]models.Music{{ID: "1"}}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.GetMusicsByFilters(ctx, filters, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, res.Items, 1)
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestValidateRequest_EmptyReleaseDateRange(t *testing.T) {
+	err := service.ValidateRequest(&models.MusicListParams{ReleaseDateFrom: "2001", ReleaseDateTo: "2000"})
+	assert.Equal(t, map[string]string{"release_date_from": "release_date_from must not be after release_date_to"}, fieldsOf(t, err))
+
+	// Конец диапазона включает весь месяц, поэтому день внутри него допустим
+	assert.NoError(t, service.ValidateRequest(&models.MusicListParams{ReleaseDateFrom: "1999-04-10", ReleaseDateTo: "1999-04"}))
+
+	err = service.ValidateRequest(&models.MusicListParams{ReleaseDate: time.Now().AddDate(1, 0, 0).Format("2006-01-02")})
+	assert.Equal(t, map[string]string{"release_date": "release_date cannot be in the future"}, fieldsOf(t, err))
+}
+
+func TestMusicDocument_PartialReleaseDate(t *testing.T) {
+	releaseDate := date(1999, time.January, 1)
+	music := models.Music{SongName: "Sonne", GroupName: "Rammstein", ReleaseDate: &releaseDate, ReleaseDatePrecision: models.PrecisionYear}
+
+	doc := service.NewMusicDocument(music)
+	assert.Equal(t, "1999", *doc.ReleaseDate)
+
+	patched, err := service.ApplyMusicPatch(doc, models.PatchMerge, []byte(`{"release_date": "2001-02"}`))
+	assert.NoError(t, err)
+	patch, err := service.NewMusicPatch(doc, patched)
+	assert.NoError(t, err)
+	assert.Equal(t, date(2001, time.February, 1), *patch.ReleaseDate)
+	assert.Equal(t, models.PrecisionMonth, patch.ReleaseDatePrecision)
+
+	patched, err = service.ApplyMusicPatch(doc, models.PatchMerge, []byte(`{"release_date": "Feb 2001"}`))
+	assert.NoError(t, err)
+	_, err = service.NewMusicPatch(doc, patched)
+	assert.ErrorIs(t, err, models.ErrInvalidPatch)
+}
+
+func TestFetchEnrichedMusic_YearOnlyReleaseDate(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/lastfm/", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"track": {"url": "http://www.example.com", "wiki": {"published": "1997"}}}`))
+	})
+	mux.HandleFunc("/lyrics/Sonne/Rammstein", func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"lyrics": "first verse"}`))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	cfg := &config.Config{
+		APIKey:        "test_api_key",
+		LastFMBaseURL: server.URL + "/lastfm/",
+		LyristBaseURL: server.URL + "/lyrics/",
+	}
+	dataEnrichmentService := service.NewDataEnrichmentService(cfg, service.WithHTTPClient(server.Client()))
+
+	music, err := dataEnrichmentService.FetchEnrichedMusic(context.Background(), "Rammstein", "Sonne")
+	assert.NoError(t, err)
+	assert.Equal(t, date(1997, time.January, 1), *music.ReleaseDate)
+	assert.Equal(t, models.PrecisionYear, music.ReleaseDatePrecision)
+}
@@ -0,0 +1,84 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/repository"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"os"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// testPool connects to the database in TEST_DATABASE_URL, which must have the migrations applied.
+// Tests that run SQL are skipped without it.
+func testPool(t *testing.T) *pgxpool.Pool {
+	url := os.Getenv("TEST_DATABASE_URL")
+	if url == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+	pool, err := pgxpool.New(context.Background(), url)
+	if err != nil {
+		t.Fatalf("connecting to test database: %v", err)
+	}
+	t.Cleanup(pool.Close)
+	return pool
+}
+
+// insertTestMusic saves songs by a new artist with a unique name and removes them after the test.
+// It returns the name of the artist and the IDs of the songs.
+func insertTestMusic(t *testing.T, pool *pgxpool.Pool, titles ...string) (string, []string) {
+	ctx := context.Background()
+	groupName := "rammstein " + strconv.FormatInt(time.Now().UnixNano(), 36)
+
+	var artistID int
+	err := pool.QueryRow(ctx, `INSERT INTO artists (name) VALUES ($1) RETURNING id`, groupName).Scan(&artistID)
+	if err != nil {
+		t.Fatalf("inserting artist: %v", err)
+	}
+	t.Cleanup(func() {
+		_, _ = pool.Exec(ctx, `DELETE FROM music WHERE artist_id = $1`, artistID)
+		_, _ = pool.Exec(ctx, `DELETE FROM artists WHERE id = $1`, artistID)
+	})
+
+	ids := make([]string, 0, len(titles))
+	for _, title := range titles {
+		var id int
+		err := pool.QueryRow(ctx, `INSERT INTO music (title, artist_id) VALUES ($1, $2) RETURNING id`, title, artistID).Scan(&id)
+		if err != nil {
+			t.Fatalf("inserting music: %v", err)
+		}
+		ids = append(ids, strconv.Itoa(id))
+	}
+	return groupName, ids
+}
+
+func TestFindSimilarMusic_Database(t *testing.T) {
+	pool := testPool(t)
+	groupName, ids := insertTestMusic(t, pool, "sonne (remastered)")
+
+	candidates, err := repository.NewMusicRepository(pool).FindSimilarMusic(context.Background(), "sonne", groupName, 0.3, 5)
+	assert.NoError(t, err)
+	if assert.NotEmpty(t, candidates) {
+		assert.Equal(t, ids[0], candidates[0].ID)
+		assert.GreaterOrEqual(t, candidates[0].Similarity, 0.3)
+	}
+}
+
+func TestFindDuplicatePairs_Database(t *testing.T) {
+	pool := testPool(t)
+	_, ids := insertTestMusic(t, pool, "mein teil", "mein teil (live)")
+
+	pairs, err := repository.NewMusicRepository(pool).FindDuplicatePairs(context.Background(), 0.6)
+	assert.NoError(t, err)
+	found := false
+	for _, pair := range pairs {
+		if pair.MusicID == ids[0] && pair.OtherID == ids[1] {
+			found = true
+			assert.Equal(t, 1.0, pair.TitleSimilarity)
+			assert.Equal(t, 1.0, pair.GroupSimilarity)
+		}
+	}
+	assert.True(t, found, "pair of %v not found", ids)
+}
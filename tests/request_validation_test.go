@@ -0,0 +1,194 @@
+package service_test
+
+import (
+	"github.com/Seven11Eleven/music_library/api/http/controller"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/gofiber/fiber/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fieldsOf returns the invalid fields reported by err with their messages.
+func fieldsOf(t *testing.T, err error) map[string]string {
+	var validation *models.ValidationError
+	if !assert.ErrorAs(t, err, &validation) {
+		return nil
+	}
+	fields := map[string]string{}
+	for _, field := range validation.Fields {
+		fields[field.Field] = field.Message
+	}
+	return fields
+}
+
+func TestValidateRequest_MusicQuery(t *testing.T) {
+	err := service.ValidateRequest(&models.MusicQuery{})
+	assert.ErrorIs(t, err, models.ErrValidation)
+	assert.Equal(t, map[string]string{
+		"song_name":  "song_name is required",
+		"group_name": "group_name is required",
+	}, fieldsOf(t, err))
+
+	assert.NoError(t, service.ValidateRequest(&models.MusicQuery{SongName: "Sonne", ArtistID: "1"}))
+	assert.NoError(t, service.ValidateRequest(&models.MusicQuery{SongName: "Sonne", GroupName: "Rammstein"}))
+
+	err = service.ValidateRequest(&models.MusicQuery{SongName: "Sonne", GroupName: strings.Repeat("a", 256)})
+	assert.Equal(t, map[string]string{"group_name": "group_name must be at most 255 characters long"}, fieldsOf(t, err))
+}
+
+func TestValidateRequest_Music(t *testing.T) {
+	future := time.Now().AddDate(1, 0, 0)
+	err := service.ValidateRequest(&models.Music{
+		SongName:    strings.Repeat("a", 256),
+		Link:        "not a link",
+		ReleaseDate: &future,
+		Verses:      []models.Verse{{Text: "Eins, hier kommt die Sonne"}, {Text: "", SectionType: "solo"}},
+	})
+	assert.Equal(t, map[string]string{
+		"song_name":              "song_name must be at most 255 characters long",
+		"link":                   "link must be a valid URL",
+		"release_date":           "release_date cannot be in the future",
+		"verses[1].text":         "verses[1].text is required",
+		"verses[1].section_type": "verses[1].section_type must be one of verse, pre-chorus, chorus, hook, bridge, intro, outro, interlude, other",
+	}, fieldsOf(t, err))
+
+	past := time.Date(2001, time.January, 2, 0, 0, 0, 0, time.UTC)
+	assert.NoError(t, service.ValidateRequest(&models.Music{SongName: "Sonne", ReleaseDate: &past, Link: "https://example.com/sonne"}))
+}
+
+func TestValidateRequest_MusicListParams(t *testing.T) {
+	year := 0
+	err := service.ValidateRequest(&models.MusicListParams{
+		ReleaseDate:     "02.01.2001",
+		ReleaseDateFrom: "Apr 1999",
+		Year:            &year,
+		Decade:          "1995",
+		TagMode:         "some",
+		Sort:            "popularity",
+		PageSize:        -1,
+	})
+	fields := fieldsOf(t, err)
+	var names []string
+	for name := range fields {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	assert.Equal(t, []string{"decade", "page_size", "release_date", "release_date_from", "sort", "tag_mode", "year"}, names)
+	assert.Equal(t, "release_date_from must be formatted as YYYY, YYYY-MM or YYYY-MM-DD", fields["release_date_from"])
+	assert.Equal(t, "release_date must be a date in format YYYY-MM-DD", fields["release_date"])
+	assert.Contains(t, fields["sort"], `unknown field "popularity"`)
+
+	assert.NoError(t, service.ValidateRequest(&models.MusicListParams{
+		ReleaseDate:   "2001-01-02T00:00:00Z",
+		ReleaseDateTo: "1999-04",
+		Decade:        "1990s",
+		Sort:          "-release_date,title",
+	}))
+}
+
+func TestParseDecade(t *testing.T) {
+	decade, err := service.ParseDecade("1990s")
+	assert.NoError(t, err)
+	assert.Equal(t, 1990, decade)
+
+	_, err = service.ParseDecade("1995")
+	assert.ErrorIs(t, err, models.ErrValidation)
+}
+
+func TestSaveMusic_ValidatesRequest(t *testing.T) {
+	req := httptest.NewRequest(http.MethodPost, "/music", strings.NewReader(`{"song_name":"Sonne"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	res, err := problemApp(mocks.NewMusicService(t)).Test(req)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, []models.FieldError{{Field: "group_name", Message: "group_name is required"}}, problemOf(t, res).Errors)
+}
+
+func TestGetMusicList_InvalidParams(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: controller.ErrorHandler})
+	app.Get("/music/info", controller.NewMusicController(mocks.NewMusicService(t), nil, 0).GetMusicList)
+
+	res, err := app.Test(httptest.NewRequest(http.MethodGet, "/music/info?decade=1995&tag_mode=some&song_name=sonne", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	problem := problemOf(t, res)
+	assert.Len(t, problem.Errors, 2)
+
+	res, err = app.Test(httptest.NewRequest(http.MethodGet, "/music/info?year=nineteen", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, "query", problemOf(t, res).Errors[0].Field)
+}
+
+func TestGetMusicList_Filters(t *testing.T) {
+	from := time.Date(1990, time.January, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(1999, time.April, 30, 0, 0, 0, 0, time.UTC)
+	decade := 1990
+	songName := "sonne"
+	mockMusicService := mocks.NewMusicService(t)
+	mockMusicService.On("GetMusicsByFilters", mock.Anything, mock.MatchedBy(func(filters models.MusicFilters) bool {
+		return assert.Equal(t, models.MusicFilters{
+			SongName:        &songName,
+			Tags:            []string{"industrial", "metal"},
+			TagMode:         models.TagModeAny,
+			ReleaseDateFrom: &from,
+			ReleaseDateTo:   &to,
+			Decade:          &decade,
+			Sort:            []models.SortKey{{Field: models.SortReleaseDate, Desc: true}},
+		}, filters)
+	}), 2, 10).Return(&models.Page[models.Music]{Items: []models.Music{}, Page: 2, PageSize: 10}, nil)
+
+	app := fiber.New(fiber.Config{ErrorHandler: controller.ErrorHandler})
+	app.Get("/music/info", controller.NewMusicController(mockMusicService, nil, 0).GetMusicList)
+
+	res, err := app.Test(httptest.NewRequest(http.MethodGet, "/music/info?song_name=sonne&tag=industrial,metal&tag_mode=any&release_date_from=1990&release_date_to=1999-04&decade=1990s&sort=-release_date&page=2&page_size=10", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+}
+
+func TestGetTrash_InvalidParams(t *testing.T) {
+	app := fiber.New(fiber.Config{ErrorHandler: controller.ErrorHandler})
+	app.Get("/music/trash", controller.NewMusicController(mocks.NewMusicService(t), nil, 0).GetTrash)
+
+	res, err := app.Test(httptest.NewRequest(http.MethodGet, "/music/trash?page=-1", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, []models.FieldError{{Field: "page", Message: "page must be at least 0"}}, problemOf(t, res).Errors)
+
+	res, err = app.Test(httptest.NewRequest(http.MethodGet, "/music/trash?page_size=many", nil))
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	assert.Equal(t, "query", problemOf(t, res).Errors[0].Field)
+}
+
+func TestUpdateMusic_PartialBody(t *testing.T) {
+	mockMusicService := mocks.NewMusicService(t)
+	mockMusicService.On("UpdateMusic", mock.Anything, models.Music{ID: "42", Link: "https://example.com/sonne"}, expectsVersion(1)).
+		Return(models.Music{ID: "42", SongName: "sonne", GroupName: "rammstein", Link: "https://example.com/sonne", Version: 2}, nil)
+
+	app := fiber.New(fiber.Config{ErrorHandler: controller.ErrorHandler})
+	app.Put("/music/:id", controller.NewMusicController(mockMusicService, nil, 0).UpdateMusic)
+
+	req := httptest.NewRequest(http.MethodPut, "/music/42", strings.NewReader(`{"link":"https://example.com/sonne"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderIfMatch, `"1"`)
+	res, err := app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	req = httptest.NewRequest(http.MethodPut, "/music/42", strings.NewReader(`{"link":"not a link"}`))
+	req.Header.Set(fiber.HeaderContentType, fiber.MIMEApplicationJSON)
+	req.Header.Set(fiber.HeaderIfMatch, `"1"`)
+	res, err = app.Test(req)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+}
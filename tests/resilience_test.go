@@ -0,0 +1,125 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/config"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func newFlakyUpstream(failures int32, failureStatus int, retryAfter string) (*httptest.Server, *int32) {
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		call := atomic.AddInt32(&calls, 1)
+		if call <= failures {
+			if retryAfter != "" {
+				w.Header().Set("Retry-After", retryAfter)
+			}
+			w.WriteHeader(failureStatus)
+			return
+		}
+		if r.URL.Query().Get("method") != "" {
+			w.Write([]byte(`{"track": {"url": "http://www.example.com"}}`))
+			return
+		}
+		w.Write([]byte(`{"lyrics": "only verse"}`))
+	}))
+	return server, &calls
+}
+
+func TestFetchEnrichedMusic_RetriesTransientErrors(t *testing.T) {
+	server, calls := newFlakyUpstream(2, http.StatusServiceUnavailable, "")
+	defer server.Close()
+
+	cfg := &config.Config{
+		LastFMBaseURL:            server.URL,
+		LyristBaseURL:            server.URL,
+		EnrichmentMaxRetries:     3,
+		EnrichmentRetryBaseDelay: time.Millisecond,
+	}
+
+	dataEnrichmentService := service.NewDataEnrichmentService(cfg, service.WithHTTPClient(server.Client()))
+
+	music, err := dataEnrichmentService.FetchEnrichedMusic(context.Background(), "Rammstein", "Sonne")
+	assert.NoError(t, err)
+	assert.Equal(t, "http://www.example.com", music.Link)
+	assert.Equal(t, int32(4), atomic.LoadInt32(calls))
+}
+
+func TestFetchEnrichedMusic_GivesUpWhenRetryAfterTooLong(t *testing.T) {
+	server, calls := newFlakyUpstream(1, http.StatusTooManyRequests, "120")
+	defer server.Close()
+
+	cfg := &config.Config{
+		LastFMBaseURL:           server.URL,
+		LyristBaseURL:           server.URL,
+		EnrichmentMaxRetries:    3,
+		EnrichmentRetryMaxDelay: time.Second,
+	}
+
+	dataEnrichmentService := service.NewDataEnrichmentService(cfg, service.WithHTTPClient(server.Client()))
+
+	music, err := dataEnrichmentService.FetchEnrichedMusic(context.Background(), "Rammstein", "Sonne")
+	assert.Error(t, err)
+	assert.Nil(t, music)
+	assert.Equal(t, int32(1), atomic.LoadInt32(calls))
+}
+
+func TestFetchEnrichedMusic_CircuitBreakerOpens(t *testing.T) {
+	server, calls := newFlakyUpstream(100, http.StatusInternalServerError, "")
+	defer server.Close()
+
+	cfg := &config.Config{
+		LastFMBaseURL:           server.URL,
+		LyristBaseURL:           server.URL,
+		BreakerFailureThreshold: 2,
+		BreakerOpenTimeout:      time.Minute,
+	}
+
+	dataEnrichmentService := service.NewDataEnrichmentService(cfg, service.WithHTTPClient(server.Client()))
+
+	for i := 0; i < 3; i++ {
+		_, err := dataEnrichmentService.FetchEnrichedMusic(context.Background(), "Rammstein", "Sonne")
+		assert.Error(t, err)
+	}
+
+	_, err := dataEnrichmentService.FetchEnrichedMusic(context.Background(), "Rammstein", "Sonne")
+	assert.ErrorIs(t, err, service.ErrCircuitOpen)
+	assert.Equal(t, int32(2), atomic.LoadInt32(calls))
+
+	statuses := dataEnrichmentService.ProviderStatuses()
+	assert.Len(t, statuses, 2)
+	assert.Equal(t, "lastfm", statuses[0].Provider)
+	assert.Equal(t, service.BreakerOpen, statuses[0].State)
+	assert.NotNil(t, statuses[0].RetryAt)
+	assert.Equal(t, service.BreakerClosed, statuses[1].State)
+}
+
+func TestProviderStatuses_HalfOpenAfterTimeout(t *testing.T) {
+	server, _ := newFlakyUpstream(100, http.StatusInternalServerError, "")
+	defer server.Close()
+
+	cfg := &config.Config{
+		LastFMBaseURL:           server.URL,
+		LyristBaseURL:           server.URL,
+		BreakerFailureThreshold: 1,
+		BreakerOpenTimeout:      50 * time.Millisecond,
+	}
+
+	dataEnrichmentService := service.NewDataEnrichmentService(cfg, service.WithHTTPClient(server.Client()))
+	_, err := dataEnrichmentService.FetchEnrichedMusic(context.Background(), "Rammstein", "Sonne")
+	assert.Error(t, err)
+	assert.Equal(t, service.BreakerOpen, dataEnrichmentService.ProviderStatuses()[0].State)
+
+	time.Sleep(60 * time.Millisecond)
+
+	status := dataEnrichmentService.ProviderStatuses()[0]
+	assert.Equal(t, service.BreakerHalfOpen, status.State)
+	assert.NotNil(t, status.OpenedAt)
+	assert.Nil(t, status.RetryAt, "retry_at is not reported once it has passed")
+}
@@ -0,0 +1,89 @@
+package service_test
+
+import (
+	"context"
+	"encoding/json"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestAuthorFromContext(t *testing.T) {
+	ctx := models.ContextWithAuthor(context.TODO(), "curator")
+
+	assert.Equal(t, "curator", models.AuthorFromContext(ctx))
+	assert.Empty(t, models.AuthorFromContext(context.TODO()))
+}
+
+func TestVerseSnapshot_Sections(t *testing.T) {
+	first := 1
+	verse := models.VerseSnapshot{Number: 3, Text: "Hier kommt die Sonne", SectionType: models.SectionChorus, SectionLabel: "Chorus 2", RepeatOf: &first}
+
+	data, err := json.Marshal(verse)
+	assert.NoError(t, err)
+	var decoded models.VerseSnapshot
+	assert.NoError(t, json.Unmarshal(data, &decoded))
+	assert.Equal(t, verse, decoded)
+
+	// Снимки, сделанные до появления секций, читаются без них
+	var legacy models.VerseSnapshot
+	assert.NoError(t, json.Unmarshal([]byte(`{"number": 1, "text": "Eins"}`), &legacy))
+	assert.Equal(t, models.VerseSnapshot{Number: 1, Text: "Eins"}, legacy)
+}
+
+func TestGetRevision(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	revision := &models.Revision{
+		MusicID: "1",
+		Number:  2,
+		Author:  "curator",
+		Changes: []models.FieldChange{{Field: "song_name", Old: "sone", New: "sonne"}},
+		Snapshot: &models.MusicSnapshot{
+			SongName:  "sonne",
+			GroupName: "rammstein",
+			Verses:    []models.VerseSnapshot{{Number: 1, Text: "Eins, hier kommt die Sonne"}},
+		},
+	}
+	mockMusicRepo.On("GetRevision", ctx, "1", 2).Return(revision, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.GetRevision(ctx, "1", 2)
+
+	assert.NoError(t, err)
+	assert.Equal(t, revision, res)
+
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestRestoreRevision(t *testing.T) {
+	ctx := models.ContextWithAuthor(context.TODO(), "curator")
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("RestoreRevision", ctx, "1", 1, (*int)(nil)).Return(&models.Music{ID: "1", SongName: "sonne"}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	music, err := musicService.RestoreRevision(ctx, "1", 1, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "sonne", music.SongName)
+
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestRestoreRevision_InvalidRevision(t *testing.T) {
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	_, err := musicService.RestoreRevision(context.TODO(), "1", 0, nil)
+
+	assert.EqualError(t, err, "revision must be greater than zero")
+	mockMusicRepo.AssertNotCalled(t, "RestoreRevision", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,80 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestNormalizeTags(t *testing.T) {
+	tags := service.NormalizeTags([]string{" Industrial  Metal", "industrial metal", "", "German"})
+
+	assert.Equal(t, []string{"industrial metal", "german"}, tags)
+}
+
+func TestAddMusicTags(t *testing.T) {
+	ctx := context.TODO()
+	mockTagRepo := new(mocks.TagRepository)
+
+	mockTagRepo.On("AddMusicTags", ctx, "1", []string{"industrial metal", "german"}).
+		Return(&models.MusicTags{MusicID: "1", Tags: []string{"german", "industrial metal"}}, nil)
+
+	tagService := service.NewTagService(mockTagRepo)
+	tags, err := tagService.AddMusicTags(ctx, "1", []string{"Industrial Metal", "german"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"german", "industrial metal"}, tags.Tags)
+
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestAddMusicTags_Empty(t *testing.T) {
+	mockTagRepo := new(mocks.TagRepository)
+
+	tagService := service.NewTagService(mockTagRepo)
+	_, err := tagService.AddMusicTags(context.TODO(), "1", []string{" "})
+
+	assert.EqualError(t, err, "at least one tag is required")
+	mockTagRepo.AssertNotCalled(t, "AddMusicTags", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAddMusicGenre_NotFound(t *testing.T) {
+	ctx := context.TODO()
+	mockTagRepo := new(mocks.TagRepository)
+
+	mockTagRepo.On("AddMusicGenre", ctx, "1", "polka").Return(nil, models.ErrGenreNotFound)
+
+	tagService := service.NewTagService(mockTagRepo)
+	_, err := tagService.AddMusicGenre(ctx, "1", " polka ")
+
+	assert.ErrorIs(t, err, models.ErrGenreNotFound)
+	mockTagRepo.AssertExpectations(t)
+}
+
+func TestGetMusicsByFilters_Tags(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("GetMusicsByFilters", ctx, models.MusicFilters{
+		Tags:    []string{"industrial metal", "german"},
+		TagMode: models.TagModeAll,
+	}, 1, 10).Return([]models.Music{{ID: "1", SongName: "Sonne"}}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	musics, err := musicService.GetMusicsByFilters(ctx, models.MusicFilters{Tags: []string{"Industrial Metal", "german"}}, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, musics.Items, 1)
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestValidateRequest_UnknownTagMode(t *testing.T) {
+	err := service.ValidateRequest(&models.MusicListParams{TagMode: "some"})
+
+	assert.EqualError(t, err, "tag_mode must be one of all, any")
+}
@@ -0,0 +1,66 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestGetTrash(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	deletedAt := time.Now()
+	mockMusicRepo.On("GetTrash", ctx, 1, 10).
+		Return([]models.Music{{ID: "1", SongName: "sonne", DeletedAt: &deletedAt}}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	musics, err := musicService.GetTrash(ctx, 1, 10)
+
+	assert.NoError(t, err)
+	assert.Len(t, musics.Items, 1)
+	assert.Equal(t, &deletedAt, musics.Items[0].DeletedAt)
+
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestRestoreMusic_NotInTrash(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("RestoreMusic", ctx, "1").Return(nil, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	music, err := musicService.RestoreMusic(ctx, "1")
+
+	assert.NoError(t, err)
+	assert.Nil(t, music)
+
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestPurgeTrash_UsesRetention(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	expected := time.Now().AddDate(0, 0, -7)
+	mockMusicRepo.On("PurgeDeletedMusic", ctx, mock.MatchedBy(func(deletedBefore time.Time) bool {
+		return deletedBefore.Sub(expected).Abs() < time.Minute
+	})).Return(int64(3), nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService, service.WithTrashRetention(7))
+	purged, err := musicService.PurgeTrash(ctx)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), purged)
+
+	mockMusicRepo.AssertExpectations(t)
+}
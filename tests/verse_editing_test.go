@@ -0,0 +1,101 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/mocks"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestInsertVerse(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	verse := models.VerseInsert{Position: 1, Text: "Eins, hier kommt die Sonne", SectionType: models.SectionIntro}
+	mockMusicRepo.On("InsertVerse", ctx, "1", verse, (*int)(nil)).
+		Return(&models.Music{ID: "1", Verses: []models.Verse{{Number: 1, Text: verse.Text}}}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.InsertVerse(ctx, "1", verse, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, verse.Text, res.Verses[0].Text)
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestValidateRequest_VerseInsert(t *testing.T) {
+	assert.EqualError(t, service.ValidateRequest(&models.VerseInsert{}), "text is required")
+	assert.EqualError(t, service.ValidateRequest(&models.VerseInsert{Text: "Zwei", Position: -1}), "position must be at least 0")
+	assert.ErrorIs(t, service.ValidateRequest(&models.VerseInsert{Text: "Zwei", SectionType: "solo"}), models.ErrValidation)
+	assert.NoError(t, service.ValidateRequest(&models.VerseInsert{Text: "Zwei", SectionType: models.SectionChorus}))
+}
+
+func TestDeleteVerse(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("DeleteVerse", ctx, "1", 5, (*int)(nil)).Return(nil, models.ErrVerseNotFound)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+
+	_, err := musicService.DeleteVerse(ctx, "1", 5, nil)
+	assert.ErrorIs(t, err, models.ErrVerseNotFound)
+
+	_, err = musicService.DeleteVerse(ctx, "1", 0, nil)
+	assert.Error(t, err)
+
+	mockMusicRepo.AssertNumberOfCalls(t, "DeleteVerse", 1)
+}
+
+func TestDeleteVerse_LastVerse(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	withoutVerses := &models.Music{ID: "1", SongName: "sonne", GroupName: "rammstein", Version: 3}
+	mockMusicRepo.On("DeleteVerse", ctx, "1", 1, (*int)(nil)).Return(withoutVerses, nil)
+	mockMusicRepo.On("GetMusic", ctx, "sonne", "rammstein").Return(withoutVerses, nil)
+	mockMusicRepo.On("GetTimedLyrics", ctx, "1").Return(withoutVerses, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+
+	res, err := musicService.DeleteVerse(ctx, "1", 1, nil)
+	assert.NoError(t, err)
+	assert.Empty(t, res.Verses)
+
+	// Песня без куплетов по-прежнему находится при повторном сохранении и экспортируется пустой
+	res, err = musicService.SaveMusic(ctx, &models.MusicQuery{SongName: "Sonne", GroupName: "Rammstein"})
+	assert.NoError(t, err)
+	assert.Equal(t, "1", res.ID)
+	assert.Empty(t, res.Verses)
+
+	lrc, err := musicService.ExportLRC(ctx, "1")
+	assert.NoError(t, err)
+	assert.Empty(t, lrc)
+}
+
+func TestReorderVerses(t *testing.T) {
+	ctx := context.TODO()
+	mockMusicRepo := new(mocks.MusicRepository)
+	mockDataEnrichmentService := new(mocks.DataEnrichmentService)
+
+	mockMusicRepo.On("ReorderVerses", ctx, "1", []int{2, 1, 3}, (*int)(nil)).Return(&models.Music{ID: "1"}, nil)
+
+	musicService := service.NewMusicService(mockMusicRepo, mockDataEnrichmentService)
+	res, err := musicService.ReorderVerses(ctx, "1", []int{2, 1, 3}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1", res.ID)
+	mockMusicRepo.AssertExpectations(t)
+}
+
+func TestValidateVerseOrder(t *testing.T) {
+	assert.NoError(t, service.ValidateVerseOrder([]int{3, 1, 2}))
+	assert.ErrorIs(t, service.ValidateVerseOrder(nil), models.ErrInvalidVerseOrder)
+	assert.ErrorIs(t, service.ValidateVerseOrder([]int{1, 1, 2}), models.ErrInvalidVerseOrder)
+	assert.ErrorIs(t, service.ValidateVerseOrder([]int{0, 1}), models.ErrInvalidVerseOrder)
+}
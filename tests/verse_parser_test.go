@@ -0,0 +1,70 @@
+package service_test
+
+import (
+	"context"
+	"github.com/Seven11Eleven/music_library/internal/domain/models"
+	"github.com/Seven11Eleven/music_library/internal/service"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func enrichLyrics(t *testing.T, lyrics string) []models.Verse {
+	dataEnrichmentService := service.NewDataEnrichmentServiceWithProviders(
+		[]service.MetadataProvider{&fakeMetadataProvider{name: "fake", metadata: &service.TrackMetadata{Link: "http://www.example.com"}}},
+		[]service.LyricsProvider{&fakeLyricsProvider{name: "fake", lyrics: lyrics}},
+	)
+
+	music, err := dataEnrichmentService.FetchEnrichedMusic(context.Background(), "Rammstein", "Sonne")
+	assert.NoError(t, err)
+	return music.Verses
+}
+
+func TestParseVerses_Sections(t *testing.T) {
+	verses := enrichLyrics(t, "[Verse 1]\nEins, hier kommt die Sonne\n[Chorus]\nHier kommt die Sonne\n\n[Verse 2]\nZwei, hier kommt die Sonne\n\n[Chorus]\nhier kommt  die Sonne\n\n[Bridge]\nSie ist der hellste Stern von allen\n\n[Chorus]")
+
+	assert.Len(t, verses, 6)
+
+	expected := []struct {
+		sectionType models.SectionType
+		label       string
+		repeatOf    *int
+	}{
+		{models.SectionVerse, "Verse 1", nil},
+		{models.SectionChorus, "Chorus", nil},
+		{models.SectionVerse, "Verse 2", nil},
+		{models.SectionChorus, "Chorus", intPtr(2)},
+		{models.SectionBridge, "Bridge", nil},
+		{models.SectionChorus, "Chorus", intPtr(2)},
+	}
+	for i, want := range expected {
+		assert.Equal(t, i+1, verses[i].Number)
+		assert.Equal(t, want.sectionType, verses[i].SectionType)
+		assert.Equal(t, want.label, verses[i].SectionLabel)
+		assert.Equal(t, want.repeatOf, verses[i].RepeatOf)
+	}
+
+	assert.Equal(t, "[Chorus]\nHier kommt die Sonne", verses[1].Text)
+}
+
+func TestParseVerses_WithoutHeaders(t *testing.T) {
+	verses := enrichLyrics(t, "first verse\n\n\n\nsecond verse\n")
+
+	assert.Len(t, verses, 2)
+	assert.Equal(t, "first verse", verses[0].Text)
+	assert.Equal(t, models.SectionVerse, verses[0].SectionType)
+	assert.Empty(t, verses[0].SectionLabel)
+	assert.Equal(t, "second verse", verses[1].Text)
+}
+
+func TestParseSectionType(t *testing.T) {
+	assert.Equal(t, models.SectionPreChorus, service.ParseSectionType("Pre-Chorus"))
+	assert.Equal(t, models.SectionChorus, service.ParseSectionType("Chorus: Till Lindemann"))
+	assert.Equal(t, models.SectionChorus, service.ParseSectionType("Припев"))
+	assert.Equal(t, models.SectionVerse, service.ParseSectionType("Куплет 2"))
+	assert.Equal(t, models.SectionOutro, service.ParseSectionType("Outro"))
+	assert.Equal(t, models.SectionOther, service.ParseSectionType("Spoken"))
+}
+
+func intPtr(i int) *int {
+	return &i
+}